@@ -29,16 +29,10 @@ func main() {
 		return
 	}
 
-	// Instantiate an int range filter to specify min and max vcpus
-	vcpusRange := selector.Int32RangeFilter{
-		LowerBound: 2,
-		UpperBound: 4,
-	}
+	// Instantiate an int32 range filter to specify min and max vcpus
+	vcpusRange := selector.NewRange[int32](2, 4)
 	// Instantiate a byte quantity range filter to specify min and max memory in GiB
-	memoryRange := selector.ByteQuantityRangeFilter{
-		LowerBound: bytequantity.FromGiB(2),
-		UpperBound: bytequantity.FromGiB(4),
-	}
+	memoryRange := selector.NewRange(bytequantity.FromGiB(2), bytequantity.FromGiB(4))
 	// Create a variable for the CPU Architecture so that it can be passed as a pointer
 	// when creating the Filter struct
 	cpuArch := ec2types.ArchitectureTypeX8664
@@ -47,8 +41,8 @@ func main() {
 	// The full struct definition can be found here for all of the supported filters:
 	// https://github.com/aws/amazon-ec2-instance-selector/blob/main/pkg/selector/types.go
 	filters := selector.Filters{
-		VCpusRange:      &vcpusRange,
-		MemoryRange:     &memoryRange,
+		VCpusRange:      vcpusRange,
+		MemoryRange:     memoryRange,
 		CPUArchitecture: &cpuArch,
 	}
 
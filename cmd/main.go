@@ -13,16 +13,21 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"reflect"
 	"strings"
 	"sync"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"dario.cat/mergo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -30,7 +35,9 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/multierr"
 
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
 	commandline "github.com/aws/amazon-ec2-instance-selector/v3/pkg/cli"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/env"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
@@ -44,7 +51,7 @@ const (
 	defaultRegionEnvVar = "AWS_DEFAULT_REGION"
 	defaultProfile      = "default"
 	awsConfigFile       = "~/.aws/config"
-	// 0 means the last price
+	// 0 means the last price, which is the default of the spot-days flag
 	// increasing this results in a lot more API calls to EC2 which can slow things down.
 	spotPricingDaysBack = 0
 
@@ -91,6 +98,19 @@ const (
 	denyList                         = "deny-list"
 	virtualizationType               = "virtualization-type"
 	pricePerHour                     = "price-per-hour"
+	pricePerVCPU                     = "price-per-vcpu"
+	pricePerGiBMemory                = "price-per-gib-memory"
+	pricePerGPU                      = "price-per-gpu"
+	pricePerGiBGPUMemory             = "price-per-gib-gpu-memory"
+	pricePerGbpsNetwork              = "price-per-gbps-network"
+	operatingSystem                  = "operating-system"
+	tenancy                          = "tenancy"
+	licenseModel                     = "license-model"
+	pricingModel                     = "pricing-model"
+	spotDays                         = "spot-days"
+	spotVolatility                   = "spot-volatility"
+	spotAZStrategy                   = "spot-az-strategy"
+	spotDiscount                     = "spot-discount"
 	instanceStorage                  = "instance-storage"
 	diskType                         = "disk-type"
 	diskEncryption                   = "disk-encryption"
@@ -104,14 +124,26 @@ const (
 	dedicatedHosts                   = "dedicated-hosts"
 	debug                            = "debug"
 	generation                       = "generation"
+	family                           = "family"
+	nameAttributes                   = "name-attributes"
+	size                             = "size"
 	instanceTypes                    = "instance-types"
+	where                            = "where"
+	regions                          = "regions"
+	regionMode                       = "region-mode"
 )
 
 // Aggregate Filter Flags.
 const (
-	instanceTypeBase = "base-instance-type"
-	flexible         = "flexible"
-	service          = "service"
+	instanceTypeBase     = "base-instance-type"
+	flexible             = "flexible"
+	baseLowPercentile    = "base-instance-type-low-percentile"
+	baseHighPercentile   = "base-instance-type-high-percentile"
+	similarity           = "similarity"
+	similarityAttributes = "similarity-attributes"
+	service              = "service"
+	anyOf                = "any-of"
+	noneOf               = "none-of"
 )
 
 // Configuration Flag Constants.
@@ -127,8 +159,80 @@ const (
 	cacheDir      = "cache-dir"
 	sortDirection = "sort-direction"
 	sortBy        = "sort-by"
+	explain       = "explain"
+	score         = "score"
+	scoreFile     = "score-file"
+	flexibleFile  = "flexible-profiles-file"
+	dryRun        = "dry-run"
+	filtersFile   = "filters-file"
 )
 
+// Subcommand Constants.
+const (
+	whyNot = "why-not"
+)
+
+// filterKeyFlags maps the selector filter keys reported by diagnostics to the corresponding filter flags.
+var filterKeyFlags = map[string]string{
+	"cpuArchitecture":                  cpuArchitecture,
+	"cpuManufacturer":                  cpuManufacturer,
+	"usageClass":                       usageClass,
+	"rootDeviceType":                   rootDeviceType,
+	"hibernationSupported":             hibernationSupport,
+	"vcpusRange":                       vcpus,
+	"memoryRange":                      memory,
+	"gpuMemoryRange":                   gpuMemoryTotal,
+	"gpusRange":                        gpus,
+	"gpuManufacturer":                  gpuManufacturer,
+	"gpuModel":                         gpuModel,
+	"inferenceAcceleratorsRange":       inferenceAccelerators,
+	"inferenceAcceleartorManufacturer": inferenceAcceleratorManufacturer,
+	"inferenceAcceleratorModel":        inferenceAcceleratorModel,
+	"placementGroupStrategy":           placementGroupStrategy,
+	"hypervisor":                       hypervisor,
+	"baremetal":                        baremetal,
+	"burstable":                        burstSupport,
+	"fpga":                             fpgaSupport,
+	"enaSupport":                       enaSupport,
+	"efaSupport":                       efaSupport,
+	"vcpusToMemoryRatio":               vcpusToMemoryRatio,
+	"vcpusToMemoryRatioRange":          vcpusToMemoryRatio,
+	"currentGeneration":                currentGeneration,
+	"networkInterfaces":                networkInterfaces,
+	"networkPerformance":               networkPerformance,
+	"networkEncryption":                networkEncryption,
+	"ipv6":                             ipv6,
+	"allowList":                        allowList,
+	"denyList":                         denyList,
+	"instanceTypes":                    instanceTypes,
+	"virtualizationType":               virtualizationType,
+	"instanceStorageRange":             instanceStorage,
+	"diskEncryption":                   diskEncryption,
+	"diskType":                         diskType,
+	"nvme":                             nvme,
+	"ebsOptimized":                     ebsOptimized,
+	"ebsOptimizedBaselineBandwidth":    ebsOptimizedBaselineBandwidth,
+	"ebsOptimizedBaselineIOPS":         ebsOptimizedBaselineIOPS,
+	"ebsOptimizedBaselineThroughput":   ebsOptimizedBaselineThroughput,
+	"freeTier":                         freeTier,
+	"autoRecovery":                     autoRecovery,
+	"dedicatedHosts":                   dedicatedHosts,
+	"generation":                       generation,
+	"families":                         family,
+	"nameAttributes":                   nameAttributes,
+	"sizeRange":                        size,
+	"expression":                       where,
+	"pricePerHour":                     pricePerHour,
+	"pricePerVCPU":                     pricePerVCPU,
+	"pricePerGiBMemory":                pricePerGiBMemory,
+	"pricePerGPU":                      pricePerGPU,
+	"pricePerGiBGPUMemory":             pricePerGiBGPUMemory,
+	"pricePerGbpsNetwork":              pricePerGbpsNetwork,
+	"spotVolatility":                   spotVolatility,
+	"spotDiscount":                     spotDiscount,
+	"location":                         availabilityZones,
+}
+
 // versionID is overridden at compilation with the version based on the git tag
 var versionID = "dev"
 
@@ -163,59 +267,11 @@ Full docs can be found at github.com/aws/amazon-` + binName
 	}
 
 	// Registers flags with specific input types from the cli pkg
-	// Filter Flags - These will be grouped at the top of the help flags
-
-	cli.Int32MinMaxRangeFlags(vcpus, cli.StringMe("c"), nil, "Number of vcpus available to the instance type.")
-	cli.ByteQuantityMinMaxRangeFlags(memory, cli.StringMe("m"), nil, "Amount of Memory available (Example: 4 GiB)")
-	cli.RatioFlag(vcpusToMemoryRatio, nil, nil, "The ratio of vcpus to GiBs of memory. (Example: 1:2)")
-	cli.StringOptionsFlag(cpuArchitecture, cli.StringMe("a"), nil, "CPU architecture [x86_64, amd64, x86_64_mac, i386, arm64, or arm64_mac]", []string{"x86_64", "x86_64_mac", "amd64", "i386", "arm64", "arm64_mac"})
-	cli.StringOptionsFlag(cpuManufacturer, nil, nil, "CPU manufacturer [amd, intel, aws, apple]", []string{"amd", "intel", "aws", "apple"})
-	cli.Int32MinMaxRangeFlags(gpus, cli.StringMe("g"), nil, "Total Number of GPUs (Example: 4)")
-	cli.ByteQuantityMinMaxRangeFlags(gpuMemoryTotal, nil, nil, "Number of GPUs' total memory (Example: 4 GiB)")
-	cli.StringFlag(gpuManufacturer, nil, nil, "GPU Manufacturer name (Example: NVIDIA)", nil)
-	cli.StringFlag(gpuModel, nil, nil, "GPU Model name (Example: K520)", nil)
-	cli.IntMinMaxRangeFlags(inferenceAccelerators, nil, nil, "Total Number of inference accelerators (Example: 4)")
-	cli.StringFlag(inferenceAcceleratorManufacturer, nil, nil, "Inference Accelerator Manufacturer name (Example: AWS)", nil)
-	cli.StringFlag(inferenceAcceleratorModel, nil, nil, "Inference Accelerator Model name (Example: Inferentia)", nil)
-	cli.StringOptionsFlag(placementGroupStrategy, nil, nil, "Placement group strategy: [cluster, partition, spread]", []string{"cluster", "partition", "spread"})
-	cli.StringOptionsFlag(usageClass, cli.StringMe("u"), nil, "Usage class: [spot or on-demand]", []string{"spot", "on-demand"})
-	cli.StringOptionsFlag(rootDeviceType, nil, nil, "Supported root device types: [ebs or instance-store]", []string{"ebs", "instance-store"})
-	cli.BoolFlag(enaSupport, cli.StringMe("e"), nil, "Instance types where ENA is supported or required")
-	cli.BoolFlag(efaSupport, nil, nil, "Instance types that support Elastic Fabric Adapters (EFA)")
-	cli.BoolFlag(hibernationSupport, nil, nil, "Hibernation supported")
-	cli.BoolFlag(baremetal, nil, nil, "Bare Metal instance types (.metal instances)")
-	cli.BoolFlag(fpgaSupport, cli.StringMe("f"), nil, "FPGA instance types")
-	cli.BoolFlag(burstSupport, cli.StringMe("b"), nil, "Burstable instance types")
-	cli.StringOptionsFlag(hypervisor, nil, nil, "Hypervisor: [xen or nitro]", []string{"xen", "nitro"})
-	cli.StringSliceFlag(availabilityZones, cli.StringMe("z"), nil, "Availability zones or zone ids to check EC2 capacity offered in specific AZs")
-	cli.BoolFlag(currentGeneration, nil, nil, "Current generation instance types (explicitly set this to false to not return current generation instance types)")
-	cli.Int32MinMaxRangeFlags(networkInterfaces, nil, nil, "Number of network interfaces (ENIs) that can be attached to the instance")
-	cli.IntMinMaxRangeFlags(networkPerformance, nil, nil, "Bandwidth in Gib/s of network performance (Example: 100)")
-	cli.BoolFlag(networkEncryption, nil, nil, "Instance Types that support automatic network encryption in-transit")
-	cli.BoolFlag(ipv6, nil, nil, "Instance Types that support IPv6")
-	cli.RegexFlag(allowList, nil, nil, "List of allowed instance types to select from w/ regex syntax (Example: m[3-5]\\.*)")
-	cli.RegexFlag(denyList, nil, nil, "List of instance types which should be excluded w/ regex syntax (Example: m[1-2]\\.*)")
-	cli.StringOptionsFlag(virtualizationType, nil, nil, "Virtualization Type supported: [hvm or pv]", []string{"hvm", "paravirtual", "pv"})
-	cli.Float64MinMaxRangeFlags(pricePerHour, nil, nil, "Price/hour in USD (Example: 0.09)")
-	cli.ByteQuantityMinMaxRangeFlags(instanceStorage, nil, nil, "Amount of local instance storage (Example: 4 GiB)")
-	cli.StringOptionsFlag(diskType, nil, nil, "Disk Type: [hdd or ssd]", []string{"hdd", "ssd"})
-	cli.BoolFlag(nvme, nil, nil, "EBS or local instance storage where NVME is supported or required")
-	cli.BoolFlag(diskEncryption, nil, nil, "EBS or local instance storage where encryption is supported or required")
-	cli.BoolFlag(ebsOptimized, nil, nil, "EBS Optimized is supported or default")
-	cli.ByteQuantityMinMaxRangeFlags(ebsOptimizedBaselineBandwidth, nil, nil, "EBS Optimized baseline bandwidth (Example: 4 GiB)")
-	cli.ByteQuantityMinMaxRangeFlags(ebsOptimizedBaselineThroughput, nil, nil, "EBS Optimized baseline throughput per second (Example: 4 GiB)")
-	cli.IntMinMaxRangeFlags(ebsOptimizedBaselineIOPS, nil, nil, "EBS Optimized baseline IOPS per second (Example: 10000)")
-	cli.BoolFlag(freeTier, nil, nil, "Free Tier supported")
-	cli.BoolFlag(autoRecovery, nil, nil, "EC2 Auto-Recovery supported")
-	cli.BoolFlag(dedicatedHosts, nil, nil, "Dedicated Hosts supported")
-	cli.IntMinMaxRangeFlags(generation, nil, nil, "Generation of the instance type (i.e. c7i.xlarge is 7)")
-	cli.StringSliceFlag(instanceTypes, nil, nil, "Instance Type names (must be exact, use allow-list for regex)")
+	// Filter Flags and Suite Flags
+	registerFilterFlags(&cli)
 
-	// Suite Flags - higher level aggregate filters that return opinionated result
-
-	cli.SuiteStringFlag(instanceTypeBase, nil, nil, "Instance Type used to retrieve similarly spec'd instance types", nil)
-	cli.SuiteBoolFlag(flexible, nil, nil, "Retrieves a group of instance types spanning multiple generations based on opinionated defaults and user overridden resource filters")
-	cli.SuiteStringFlag(service, nil, nil, "Filter instance types based on service support (Example: emr-5.20.0)", nil)
+	cli.SuiteStringArrayFlag(anyOf, nil, nil, fmt.Sprintf("Filter group where instance types matching any --%s group are selected, repeatable (Example: \"gpus-min=1 gpu-memory-total-min=24gib\")", anyOf))
+	cli.SuiteStringArrayFlag(noneOf, nil, nil, fmt.Sprintf("Filter group where instance types matching any --%s group are excluded, repeatable (Example: \"cpu-manufacturer=intel\")", noneOf))
 
 	// Configuration Flags - These will be grouped at the bottom of the help flags
 
@@ -226,11 +282,24 @@ Full docs can be found at github.com/aws/amazon-` + binName
 	cli.ConfigIntFlag(cacheTTL, nil, env.WithDefaultInt("EC2_INSTANCE_SELECTOR_CACHE_TTL", 0), "Cache TTLs in hours for pricing and instance type caches. Setting the cache to 0 will turn off caching and cleanup any on-disk caches.")
 	cli.ConfigPathFlag(cacheDir, nil, env.WithDefaultString("EC2_INSTANCE_SELECTOR_CACHE_DIR", "~/.ec2-instance-selector/"), "Directory to save the pricing and instance type caches")
 	cli.ConfigBoolFlag(verbose, cli.StringMe("v"), nil, "Verbose - will print out full instance specs")
+	cli.ConfigBoolFlag(explain, nil, nil, "Explain - prints how many and which instance types each filter eliminated instead of the matching instance types")
+	cli.ConfigBoolFlag(dryRun, nil, nil, "Dry Run - prints the effective filters after the aggregate filter transforms and which transform set each filter without selecting instance types")
 	cli.ConfigBoolFlag("debug", nil, nil, "Debug - prints debug log messages")
 	cli.ConfigBoolFlag(help, cli.StringMe("h"), nil, "Help")
 	cli.ConfigBoolFlag(version, nil, nil, "Prints CLI version")
 	cli.ConfigStringOptionsFlag(sortDirection, nil, cli.StringMe(sorter.SortAscending), fmt.Sprintf("Specify the direction to sort in (%s)", strings.Join(cliSortDirections, ", ")), cliSortDirections)
 	cli.ConfigStringFlag(sortBy, nil, cli.StringMe(instanceNamePath), "Specify the field to sort by. Quantity flags present in this CLI (memory, gpus, etc.) or a JSON path to the appropriate instance type field (Ex: \".MemoryInfo.SizeInMiB\") is acceptable.", nil)
+	cli.ConfigStringSliceFlag(score, nil, nil, fmt.Sprintf("Rank instance types by a composite score of weighted attributes instead of sorting, shown with each attribute's contribution (Example: on-demand-price=2,vcpus=1) (attributes: %s)", strings.Join(selector.ScoreAttributes(), ", ")))
+	cli.ConfigPathFlag(scoreFile, nil, nil, fmt.Sprintf("JSON file of --%s weights keyed by attribute (Example: {\"on-demand-price\": 2, \"vcpus\": 1}), where --%s weights take precedence", score, score))
+	cli.ConfigPathFlag(flexibleFile, nil, nil, fmt.Sprintf("JSON file of named --%s profiles, where a profile named \"%s\" replaces the built-in default profile", flexible, selector.DefaultFlexibleProfile))
+	cli.ConfigPathFlag(filtersFile, nil, nil, fmt.Sprintf("YAML or JSON file of filters, or %s to read from stdin, where filter flags take precedence over the file (schema: schema/filters.schema.json)", selector.FiltersFileStdin))
+
+	// Subcommands - These accept all of the flags above
+
+	var whyNotInstanceType *string
+	cli.SubCommand(whyNot+" <instance-type>", "Reports every filter which excludes the instance type from the results", cobra.ExactArgs(1), func(cmd *cobra.Command, args []string) {
+		whyNotInstanceType = &args[0]
+	})
 
 	// Parses the user input with the registered flags and runs type specific validation on the user input
 	flags, err := cli.ParseAndValidateFlags()
@@ -252,6 +321,60 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		log.Println("--service eks is deprecated. EKS generally supports all instance types")
 	}
 
+	anyOfFilters, err := parseFilterGroups(cli.StringSliceMe(flags[anyOf]))
+	if err != nil {
+		log.Printf("There was an error while parsing --%s: %v", anyOf, err)
+		os.Exit(1)
+	}
+	noneOfFilters, err := parseFilterGroups(cli.StringSliceMe(flags[noneOf]))
+	if err != nil {
+		log.Printf("There was an error while parsing --%s: %v", noneOf, err)
+		os.Exit(1)
+	}
+	scoreWeights, err := getScoreWeights(&cli, flags)
+	if err != nil {
+		log.Printf("There was an error while parsing the --%s weights: %v", score, err)
+		os.Exit(1)
+	}
+	filters := getFilters(&cli, flags)
+	filters.MaxResults = cli.IntMe(flags[maxResults])
+	if filtersFilePath := cli.StringMe(flags[filtersFile]); filtersFilePath != nil {
+		fileFilters, err := selector.LoadFilters(*filtersFilePath)
+		if err != nil {
+			log.Printf("There was an error while loading --%s: %v", filtersFile, err)
+			os.Exit(1)
+		}
+		// --max-results always has a value, so the file's max results are only overridden when the flag is passed
+		if fileFilters.MaxResults != nil && !cli.Command.Flags().Changed(maxResults) {
+			filters.MaxResults = nil
+		}
+		filters = selector.MergeFilters(filters, fileFilters)
+		if flags[region] == nil && filters.Region != nil {
+			flags[region] = *filters.Region
+		}
+	}
+	isOnDemandPriceFiltered, isSpotPriceFiltered := priceFilterCaches(filters, append(anyOfFilters, noneOfFilters...))
+	isSpotVolatilityFiltered := false
+	isSpotDiscountFiltered := false
+	expressions := []*selector.Expression{}
+	for _, groupFilters := range append([]selector.Filters{filters}, append(anyOfFilters, noneOfFilters...)...) {
+		if groupFilters.SpotVolatility != nil {
+			isSpotVolatilityFiltered = true
+		}
+		if groupFilters.SpotDiscount != nil {
+			isSpotDiscountFiltered = true
+		}
+		expressions = append(expressions, groupFilters.Expression)
+	}
+	// the cli defaults to the last spot price rather than the selector's default days of spot price history,
+	// unless the spot price volatility is filtered or sorted on since it is calculated from the spot price history
+	if filters.SpotDays == nil {
+		filters.SpotDays = aws.Int(spotPricingDaysBack)
+		if isSpotVolatilityFiltered || strings.Contains(strings.ToLower(aws.ToString(cli.StringMe(flags[sortBy]))), "volatility") {
+			filters.SpotDays = aws.Int(ec2pricing.DefaultSpotDaysBack)
+		}
+	}
+
 	ctx := context.Background()
 	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithSharedConfigProfile(
@@ -271,6 +394,7 @@ Full docs can be found at github.com/aws/amazon-` + binName
 	}
 
 	flags[region] = cfg.Region
+	filters.Region = cli.StringMe(flags[region])
 
 	cacheTTLDuration := time.Hour * time.Duration(*cli.IntMe(flags[cacheTTL]))
 	instanceSelector, err := selector.NewWithCache(ctx, cfg, cacheTTLDuration, *cli.StringMe(flags[cacheDir]))
@@ -282,6 +406,18 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		debugLogger := log.New(os.Stdout, time.Now().UTC().Format(time.RFC3339)+" DEBUG ", 0)
 		instanceSelector.SetLogger(debugLogger)
 	}
+	if err := cli.RegisterCustomFilters(&instanceSelector.FilterRegistry); err != nil {
+		fmt.Printf("An error occurred when registering the custom filters: %v\n", err)
+		os.Exit(1)
+	}
+	if flexibleProfilesPath := cli.StringMe(flags[flexibleFile]); flexibleProfilesPath != nil {
+		flexibleProfiles, err := selector.LoadFlexibleProfiles(*flexibleProfilesPath)
+		if err != nil {
+			fmt.Printf("An error occurred when loading the flexible profiles: %v\n", err)
+			os.Exit(1)
+		}
+		instanceSelector.FlexibleProfiles = flexibleProfiles
+	}
 	shutdown := func() {
 		if err := instanceSelector.Save(); err != nil {
 			log.Printf("There was an error saving pricing caches: %v", err)
@@ -289,6 +425,73 @@ Full docs can be found at github.com/aws/amazon-` + binName
 	}
 	registerShutdown(shutdown)
 
+	// filters which cannot select any instance types are reported before instance types and prices are retrieved
+	if err := validateFilters(ctx, instanceSelector, filters, anyOfFilters, noneOfFilters); err != nil {
+		fmt.Printf("An error occurred while validating the filters: %v\n", err)
+		os.Exit(1)
+	}
+
+	// a dry run only resolves the filters, so instance types and prices are not retrieved
+	if flags[dryRun] != nil {
+		resolvedFilters, err := instanceSelector.ResolveFilters(ctx, filters)
+		if err != nil {
+			fmt.Printf("An error occurred while transforming the aggregate filters: %v", err)
+			os.Exit(1)
+		}
+		lines, err := dryRunOutput(resolvedFilters)
+		if err != nil {
+			fmt.Printf("An error occurred when printing filters due to --%s being specified: %v", dryRun, err)
+			os.Exit(1)
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return
+	}
+
+	// pricing caches are refreshed for each region instance types are selected from
+	pricingSelectors := []*selector.Selector{instanceSelector}
+	if filters.Regions != nil && len(*filters.Regions) > 0 {
+		pricingSelectors = []*selector.Selector{}
+		for _, r := range *filters.Regions {
+			regionalSelector, err := instanceSelector.RegionalSelectors.Get(ctx, r)
+			if err != nil {
+				fmt.Printf("An error occurred when initializing the ec2 selector: %v", err)
+				os.Exit(1)
+			}
+			pricingSelectors = append(pricingSelectors, regionalSelector)
+		}
+	}
+	pricingOptions := filters.PricingOptions()
+	spotDaysBack := filters.SpotDaysBack()
+	refreshOnDemandCaches := func() {
+		for _, pricingSelector := range pricingSelectors {
+			if pricingSelector.EC2Pricing.OnDemandCacheCount(pricingOptions) == 0 {
+				if err := pricingSelector.EC2Pricing.RefreshOnDemandCache(ctx, pricingOptions); err != nil {
+					log.Printf("There was a problem refreshing the on-demand pricing cache: %v", err)
+				}
+			}
+		}
+	}
+	refreshSpotCaches := func() {
+		for _, pricingSelector := range pricingSelectors {
+			if pricingSelector.EC2Pricing.SpotCacheCount(pricingOptions, spotDaysBack) == 0 {
+				if err := pricingSelector.EC2Pricing.RefreshSpotCache(ctx, pricingOptions, spotDaysBack); err != nil {
+					log.Printf("There was a problem refreshing the spot pricing cache: %v", err)
+				}
+			}
+		}
+	}
+
+	// reserved instance prices are retrieved with the on-demand prices
+	refreshPricingModelCaches := func() {
+		if filters.PricingModel != nil && *filters.PricingModel == ec2pricing.PricingModelSpot {
+			refreshSpotCaches()
+		} else {
+			refreshOnDemandCaches()
+		}
+	}
+
 	sortField := cli.StringMe(flags[sortBy])
 	lowercaseSortField := strings.ToLower(*sortField)
 	outputFlag := cli.StringMe(flags[output])
@@ -296,45 +499,388 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		// If output type is `table-wide`, simply print both prices for better comparison,
 		//   even if the actual filter is applied on any one of those based on usage class
 		// Save time by hydrating all caches in parallel
-		if err := hydrateCaches(ctx, *instanceSelector); err != nil {
-			log.Printf("%v", err)
+		for _, pricingSelector := range pricingSelectors {
+			if err := hydrateCaches(ctx, *pricingSelector, pricingOptions, spotDaysBack); err != nil {
+				log.Printf("%v", err)
+			}
 		}
 	} else {
 		// Else, if price filters are applied, only hydrate the respective cache as we don't have to print the prices
-		if flags[pricePerHour] != nil {
-			if flags[usageClass] == nil || *cli.StringMe(flags[usageClass]) == "on-demand" {
-				if instanceSelector.EC2Pricing.OnDemandCacheCount() == 0 {
-					if err := instanceSelector.EC2Pricing.RefreshOnDemandCache(ctx); err != nil {
-						log.Printf("There was a problem refreshing the on-demand pricing cache: %v", err)
-					}
+		if isOnDemandPriceFiltered {
+			refreshOnDemandCaches()
+		}
+		if isSpotPriceFiltered {
+			refreshSpotCaches()
+		}
+		if isSpotVolatilityFiltered {
+			refreshSpotCaches()
+		}
+		// the spot discount is derived from both the on-demand and spot prices
+		if isSpotDiscountFiltered {
+			refreshOnDemandCaches()
+			refreshSpotCaches()
+		}
+
+		// refresh appropriate caches if an expression references spot, on demand, or pricing model prices or the fields derived from them
+		for _, expression := range expressions {
+			if expression == nil {
+				continue
+			}
+			for _, field := range expression.Fields() {
+				switch {
+				case strings.HasPrefix(field, "SpotPrice"), strings.HasPrefix(field, "SpotVolatility"),
+					strings.HasPrefix(field, "CheapestSpotAvailabilityZone"):
+					refreshSpotCaches()
+				case strings.HasPrefix(field, "OndemandPrice"), strings.HasPrefix(field, "ReservedPrices"):
+					refreshOnDemandCaches()
+				case strings.HasPrefix(field, "SpotDiscount"):
+					refreshOnDemandCaches()
+					refreshSpotCaches()
+				case strings.HasPrefix(field, "PricingModelPrice"):
+					refreshPricingModelCaches()
 				}
+			}
+		}
+
+		// refresh appropriate caches if scoring on either spot or on demand pricing
+		if scoreWeights != nil {
+			if _, ok := scoreWeights[selector.ScoreOnDemandPrice]; ok {
+				refreshOnDemandCaches()
+			}
+			if _, ok := scoreWeights[selector.ScoreSpotPrice]; ok {
+				refreshSpotCaches()
+			}
+		}
+
+		// refresh appropriate caches if sorting by either spot or on demand pricing
+		if scoreWeights == nil && strings.Contains(lowercaseSortField, "volatility") {
+			refreshSpotCaches()
+		} else if scoreWeights == nil && strings.Contains(lowercaseSortField, "discount") {
+			refreshOnDemandCaches()
+			refreshSpotCaches()
+		} else if scoreWeights == nil && strings.Contains(lowercaseSortField, "price") {
+			if strings.Contains(lowercaseSortField, "pricing-model") || strings.Contains(lowercaseSortField, "pricingmodel") {
+				refreshPricingModelCaches()
+			} else if strings.Contains(lowercaseSortField, "spot") {
+				refreshSpotCaches()
 			} else {
-				if instanceSelector.EC2Pricing.SpotCacheCount() == 0 {
-					if err := instanceSelector.EC2Pricing.RefreshSpotCache(ctx, spotPricingDaysBack); err != nil {
-						log.Printf("There was a problem refreshing the spot pricing cache: %v", err)
-					}
+				refreshOnDemandCaches()
+			}
+		}
+	}
+
+	if filters.Regions != nil && outputFlag == nil && flags[verbose] == nil {
+		resultsOutputFn = outputs.TableOutputRegions
+	}
+	isSimilaritySearch := (filters.InstanceTypeBase != nil || filters.InstanceTypeBases != nil) && filters.InstanceTypeBaseSimilarity != nil && *filters.InstanceTypeBaseSimilarity
+	if isSimilaritySearch && outputFlag == nil && flags[verbose] == nil {
+		resultsOutputFn = outputs.TableOutputSimilarity
+	}
+	if scoreWeights != nil && outputFlag == nil && flags[verbose] == nil {
+		resultsOutputFn = outputs.TableOutputScores
+	}
+
+	// instance types are ranked by the composite score if score weights are specified, kept in order of distance to the
+	// base instance type for similarity searches, and otherwise sorted
+	rankInstanceTypes := func(instanceTypesDetails []*instancetypes.Details) ([]*instancetypes.Details, error) {
+		if scoreWeights != nil {
+			return selector.Score(instanceTypesDetails, scoreWeights)
+		}
+		if isSimilaritySearch {
+			return instanceTypesDetails, nil
+		}
+		return sorter.Sort(instanceTypesDetails, *sortField, *cli.StringMe(flags[sortDirection]))
+	}
+
+	if flags[verbose] != nil {
+		resultsOutputFn = outputs.VerboseInstanceTypeOutput
+		transformedFilters, err := instanceSelector.AggregateFilterTransform(ctx, filters)
+		if err != nil {
+			fmt.Printf("An error occurred while transforming the aggregate filters")
+			os.Exit(1)
+		}
+		filtersJSON, err := filters.MarshalIndent("", "    ")
+		if err != nil {
+			fmt.Printf("An error occurred when printing filters due to --verbose being specified: %v", err)
+			os.Exit(1)
+		}
+		transformedFiltersJSON, err := transformedFilters.MarshalIndent("", "    ")
+		if err != nil {
+			fmt.Printf("An error occurred when printing aggregate filters due to --verbose being specified: %v", err)
+			os.Exit(1)
+		}
+		log.Println("\n\n\"Filters\":", string(filtersJSON))
+		if string(transformedFiltersJSON) != string(filtersJSON) {
+			log.Println("\n\n\"Transformed Filters\":", string(transformedFiltersJSON))
+		} else {
+			log.Println("There were no transformations on the filters to display")
+		}
+	}
+
+	if flags[explain] != nil {
+		if len(anyOfFilters) != 0 || len(noneOfFilters) != 0 {
+			log.Printf("--%s cannot be used with --%s or --%s", explain, anyOf, noneOf)
+			os.Exit(1)
+		}
+		explanation, err := instanceSelector.Explain(ctx, filters)
+		if err != nil {
+			fmt.Printf("An error occurred when explaining the filters: %v", err)
+			os.Exit(1)
+		}
+		for _, line := range explanationOutput(explanation) {
+			fmt.Println(line)
+		}
+		shutdown()
+		return
+	}
+
+	if whyNotInstanceType != nil {
+		if len(anyOfFilters) != 0 || len(noneOfFilters) != 0 {
+			log.Printf("%s cannot be used with --%s or --%s", whyNot, anyOf, noneOf)
+			os.Exit(1)
+		}
+		// max results truncation is determined after sorting so that --sort-by is respected
+		whyNotFilters := filters
+		whyNotFilters.MaxResults = nil
+		report, err := instanceSelector.WhyNot(ctx, whyNotFilters, *whyNotInstanceType)
+		if err != nil {
+			fmt.Printf("An error occurred when explaining why %s was not selected: %v", *whyNotInstanceType, err)
+			os.Exit(1)
+		}
+		if report.Selected && filters.MaxResults != nil {
+			instanceTypesDetails, err := instanceSelector.FilterVerbose(ctx, whyNotFilters)
+			if err != nil {
+				fmt.Printf("An error occurred when filtering instance types: %v", err)
+				os.Exit(1)
+			}
+			instanceTypesDetails, err = rankInstanceTypes(instanceTypesDetails)
+			if err != nil {
+				fmt.Printf("Sorting error: %v", err)
+				os.Exit(1)
+			}
+			for i, instanceTypeInfo := range instanceTypesDetails {
+				if string(instanceTypeInfo.InstanceType) == report.InstanceType {
+					report.Rank = i + 1
+					break
 				}
 			}
+			if report.Rank > *filters.MaxResults {
+				report.Selected = false
+				report.ExcludedByMaxResults = true
+			}
 		}
+		for _, line := range whyNotOutput(report, filters.MaxResults) {
+			fmt.Println(line)
+		}
+		shutdown()
+		return
+	}
 
-		// refresh appropriate caches if sorting by either spot or on demand pricing
-		if strings.Contains(lowercaseSortField, "price") {
-			if strings.Contains(lowercaseSortField, "spot") {
-				if instanceSelector.EC2Pricing.SpotCacheCount() == 0 {
-					if err := instanceSelector.EC2Pricing.RefreshSpotCache(ctx, spotPricingDaysBack); err != nil {
-						log.Printf("There was a problem refreshing the spot pricing cache: %v", err)
-					}
+	if filters.InstanceTypeBases != nil {
+		if len(anyOfFilters) != 0 || len(noneOfFilters) != 0 {
+			log.Printf("multiple --%s values cannot be used with --%s or --%s", instanceTypeBase, anyOf, noneOf)
+			os.Exit(1)
+		}
+		if outputFlag != nil && *outputFlag == bubbleTeaOutput {
+			log.Printf("multiple --%s values are not supported by the %s output", instanceTypeBase, bubbleTeaOutput)
+			os.Exit(1)
+		}
+		// fetch the candidates for each base without truncating results so that --sort-by is respected
+		baseFilters := filters
+		baseFilters.MaxResults = nil
+		baseResults, err := instanceSelector.FilterInstanceTypeBases(ctx, baseFilters)
+		if err != nil {
+			fmt.Printf("An error occurred when filtering instance types: %v", err)
+			os.Exit(1)
+		}
+		for i := range baseResults {
+			baseResults[i].InstanceTypes, err = rankInstanceTypes(baseResults[i].InstanceTypes)
+			if err != nil {
+				fmt.Printf("Sorting error: %v", err)
+				os.Exit(1)
+			}
+			baseResults[i].InstanceTypes, baseResults[i].NumOfItemsTruncated = truncateResults(filters.MaxResults, baseResults[i].InstanceTypes)
+		}
+		selector.AnnotateInstanceTypeBases(baseResults)
+		var lines []string
+		if outputFlag == nil && flags[verbose] == nil {
+			lines = instanceTypeBasesOutput(baseResults)
+		} else {
+			outputFn := getOutputFn(outputFlag, selector.InstanceTypesOutputFn(resultsOutputFn))
+			for _, baseResult := range baseResults {
+				lines = append(lines, fmt.Sprintf("%s:", baseResult.InstanceTypeBase))
+				lines = append(lines, outputFn(baseResult.InstanceTypes)...)
+			}
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		for _, baseResult := range baseResults {
+			if baseResult.NumOfItemsTruncated > 0 {
+				log.Printf("%d entries were truncated for %s, increase --%s to see more", baseResult.NumOfItemsTruncated, baseResult.InstanceTypeBase, maxResults)
+			}
+		}
+		shutdown()
+		return
+	}
+
+	// fetch instance types without truncating results
+	prevMaxResults := filters.MaxResults
+	filters.MaxResults = nil
+	var instanceTypesDetails []*instancetypes.Details
+	if len(anyOfFilters) == 0 && len(noneOfFilters) == 0 {
+		instanceTypesDetails, err = instanceSelector.FilterVerbose(ctx, filters)
+	} else {
+		var query selector.AnyFilters
+		query, err = getAnyFilters(filters, anyOfFilters, noneOfFilters)
+		if err == nil {
+			instanceTypesDetails, err = instanceSelector.FilterAnyVerbose(ctx, query)
+		}
+	}
+	if err != nil {
+		fmt.Printf("An error occurred when filtering instance types: %v", err)
+		os.Exit(1)
+	}
+
+	// sort or score instance types
+	instanceTypesDetails, err = rankInstanceTypes(instanceTypesDetails)
+	if err != nil {
+		fmt.Printf("Sorting error: %v", err)
+		os.Exit(1)
+	}
+
+	// handle output format
+	var itemsTruncated int
+	var instanceTypes []string
+	if outputFlag != nil && *outputFlag == bubbleTeaOutput {
+		p := tea.NewProgram(outputs.NewBubbleTeaModel(instanceTypesDetails), tea.WithMouseCellMotion())
+		if _, err := p.Run(); err != nil {
+			fmt.Printf("An error occurred when starting bubble tea: %v", err)
+			os.Exit(1)
+		}
+
+		shutdown()
+		return
+	} else {
+		// handle regular output modes
+
+		// truncate instance types based on user passed in maxResults
+		instanceTypesDetails, itemsTruncated = truncateResults(prevMaxResults, instanceTypesDetails)
+		if len(instanceTypesDetails) == 0 {
+			log.Println("The criteria was too narrow and returned no valid instance types. Consider broadening your criteria so that more instance types are returned.")
+			if len(anyOfFilters) == 0 && len(noneOfFilters) == 0 && filters.Regions == nil {
+				suggestions, err := instanceSelector.SuggestRelaxations(ctx, filters)
+				if err != nil {
+					log.Printf("There was a problem suggesting broader criteria: %v", err)
 				}
-			} else {
-				if instanceSelector.EC2Pricing.OnDemandCacheCount() == 0 {
-					if err := instanceSelector.EC2Pricing.RefreshOnDemandCache(ctx); err != nil {
-						log.Printf("There was a problem refreshing the on-demand pricing cache: %v", err)
-					}
+				for _, line := range relaxationOutput(suggestions) {
+					log.Println(line)
 				}
 			}
+			os.Exit(1)
 		}
+
+		// format instance types for output
+		outputFn := getOutputFn(outputFlag, selector.InstanceTypesOutputFn(resultsOutputFn))
+		instanceTypes = outputFn(instanceTypesDetails)
+	}
+
+	for _, instanceType := range instanceTypes {
+		fmt.Println(instanceType)
+	}
+
+	if itemsTruncated > 0 {
+		log.Printf("%d entries were truncated, increase --%s to see more", itemsTruncated, maxResults)
 	}
+	shutdown()
+}
+
+// registerFilterFlags registers the filter and suite flags which are used to build a selector.Filters struct.
+func registerFilterFlags(cli *commandline.CommandLineInterface) {
+	// Filter Flags - These will be grouped at the top of the help flags
+
+	cli.Int32MinMaxRangeFlags(vcpus, cli.StringMe("c"), nil, "Number of vcpus available to the instance type.")
+	cli.ByteQuantityMinMaxRangeFlags(memory, cli.StringMe("m"), nil, "Amount of Memory available (Example: 4 GiB)")
+	cli.RatioMinMaxRangeFlags(vcpusToMemoryRatio, nil, nil, "Ratio of vcpus to GiBs of memory. (Example: 1:2)")
+	cli.StringOptionsFlag(cpuArchitecture, cli.StringMe("a"), nil, "CPU architecture [x86_64, amd64, x86_64_mac, i386, arm64, or arm64_mac]", []string{"x86_64", "x86_64_mac", "amd64", "i386", "arm64", "arm64_mac"})
+	cli.StringOptionsFlag(cpuManufacturer, nil, nil, "CPU manufacturer [amd, intel, aws, apple]", []string{"amd", "intel", "aws", "apple"})
+	cli.Int32MinMaxRangeFlags(gpus, cli.StringMe("g"), nil, "Total Number of GPUs (Example: 4)")
+	cli.ByteQuantityMinMaxRangeFlags(gpuMemoryTotal, nil, nil, "Number of GPUs' total memory (Example: 4 GiB)")
+	cli.StringFlag(gpuManufacturer, nil, nil, "GPU Manufacturer name (Example: NVIDIA)", nil)
+	cli.StringFlag(gpuModel, nil, nil, "GPU Model name (Example: K520)", nil)
+	cli.IntMinMaxRangeFlags(inferenceAccelerators, nil, nil, "Total Number of inference accelerators (Example: 4)")
+	cli.StringFlag(inferenceAcceleratorManufacturer, nil, nil, "Inference Accelerator Manufacturer name (Example: AWS)", nil)
+	cli.StringFlag(inferenceAcceleratorModel, nil, nil, "Inference Accelerator Model name (Example: Inferentia)", nil)
+	cli.StringOptionsFlag(placementGroupStrategy, nil, nil, "Placement group strategy: [cluster, partition, spread]", []string{"cluster", "partition", "spread"})
+	cli.StringOptionsFlag(usageClass, cli.StringMe("u"), nil, "Usage class: [spot or on-demand]", []string{"spot", "on-demand"})
+	cli.StringOptionsFlag(rootDeviceType, nil, nil, "Supported root device types: [ebs or instance-store]", []string{"ebs", "instance-store"})
+	cli.BoolFlag(enaSupport, cli.StringMe("e"), nil, "Instance types where ENA is supported or required")
+	cli.BoolFlag(efaSupport, nil, nil, "Instance types that support Elastic Fabric Adapters (EFA)")
+	cli.BoolFlag(hibernationSupport, nil, nil, "Hibernation supported")
+	cli.BoolFlag(baremetal, nil, nil, "Bare Metal instance types (.metal instances)")
+	cli.BoolFlag(fpgaSupport, cli.StringMe("f"), nil, "FPGA instance types")
+	cli.BoolFlag(burstSupport, cli.StringMe("b"), nil, "Burstable instance types")
+	cli.StringOptionsFlag(hypervisor, nil, nil, "Hypervisor: [xen or nitro]", []string{"xen", "nitro"})
+	cli.StringSliceFlag(availabilityZones, cli.StringMe("z"), nil, "Availability zones or zone ids to check EC2 capacity offered in specific AZs")
+	cli.StringSliceFlag(regions, nil, nil, fmt.Sprintf("Regions to select instance types from in a single query, annotating each instance type with its regional availability (see --%s)", regionMode))
+	cli.StringOptionsFlag(regionMode, nil, nil, fmt.Sprintf("Select instance types available in all --%s or any of the --%s [all or any] (default all)", regions, regions), []string{string(selector.RegionModeAll), string(selector.RegionModeAny)})
+	cli.BoolFlag(currentGeneration, nil, nil, "Current generation instance types (explicitly set this to false to not return current generation instance types)")
+	cli.Int32MinMaxRangeFlags(networkInterfaces, nil, nil, "Number of network interfaces (ENIs) that can be attached to the instance")
+	cli.IntMinMaxRangeFlags(networkPerformance, nil, nil, "Bandwidth in Gib/s of network performance (Example: 100)")
+	cli.BoolFlag(networkEncryption, nil, nil, "Instance Types that support automatic network encryption in-transit")
+	cli.BoolFlag(ipv6, nil, nil, "Instance Types that support IPv6")
+	cli.RegexFlag(allowList, nil, nil, "List of allowed instance types to select from w/ regex syntax (Example: m[3-5]\\.*)")
+	cli.RegexFlag(denyList, nil, nil, "List of instance types which should be excluded w/ regex syntax (Example: m[1-2]\\.*)")
+	cli.StringOptionsFlag(virtualizationType, nil, nil, "Virtualization Type supported: [hvm or pv]", []string{"hvm", "paravirtual", "pv"})
+	cli.Float64MinMaxRangeFlags(pricePerHour, nil, nil, "Price/hour in USD (Example: 0.09)")
+	cli.Float64MinMaxRangeFlags(pricePerVCPU, nil, nil, "Price/hour in USD per vCPU (Example: 0.02)")
+	cli.Float64MinMaxRangeFlags(pricePerGiBMemory, nil, nil, "Price/hour in USD per GiB of memory (Example: 0.005)")
+	cli.Float64MinMaxRangeFlags(pricePerGPU, nil, nil, "Price/hour in USD per GPU (Example: 0.5)")
+	cli.Float64MinMaxRangeFlags(pricePerGiBGPUMemory, nil, nil, "Price/hour in USD per GiB of GPU memory (Example: 0.05)")
+	cli.Float64MinMaxRangeFlags(pricePerGbpsNetwork, nil, nil, "Price/hour in USD per Gbps of network bandwidth (Example: 0.01)")
+	cli.StringOptionsFlag(operatingSystem, nil, nil, "Operating system instance types are priced for: [linux, windows, rhel, suse, windows-sql-web, windows-sql-standard, or windows-sql-enterprise] (default: linux)", []string{"linux", "windows", "rhel", "suse", "windows-sql-web", "windows-sql-standard", "windows-sql-enterprise"})
+	cli.StringOptionsFlag(tenancy, nil, nil, "Tenancy instance types are priced for: [shared or dedicated] (default: shared)", []string{"shared", "dedicated"})
+	cli.StringOptionsFlag(licenseModel, nil, nil, "License model instance types are priced for: [no-license-required or byol] (default: no-license-required)", []string{"no-license-required", "byol"})
+	cli.StringOptionsFlag(pricingModel, nil, nil, "Pricing model of the price filters and the pricing-model-price sort shorthand: "+
+		"[on-demand, spot, or reserved-<1yr|3yr>-<standard|convertible>-<no|partial|all>-upfront] (Example: reserved-1yr-standard-no-upfront)", pricingModels())
+	cli.IntFlag(spotDays, nil, nil, "Days of spot price history the spot prices and their statistics are calculated from, up to 90. "+
+		"Increasing this results in a lot more API calls to EC2 (default: 0, the last price, or 30 if the spot price volatility is filtered or sorted on)")
+	cli.Float64MinMaxRangeFlags(spotVolatility, nil, nil, "Spot price volatility, the standard deviation of the spot price over the spot-days relative to its mean, "+
+		"of the most volatile availability zone (Example: 0.1)")
+	cli.StringOptionsFlag(spotAZStrategy, nil, nil, "Spot price of the availability zones the price filters use for spot instances and the spot-discount filter uses: "+
+		"[average, min, or max] (default: the spot price)", []string{"average", "min", "max"})
+	cli.Float64MinMaxRangeFlags(spotDiscount, nil, nil, "Percentage the spot price is cheaper than the on-demand price (Example: 60)")
+	cli.ByteQuantityMinMaxRangeFlags(instanceStorage, nil, nil, "Amount of local instance storage (Example: 4 GiB)")
+	cli.StringOptionsFlag(diskType, nil, nil, "Disk Type: [hdd or ssd]", []string{"hdd", "ssd"})
+	cli.BoolFlag(nvme, nil, nil, "EBS or local instance storage where NVME is supported or required")
+	cli.BoolFlag(diskEncryption, nil, nil, "EBS or local instance storage where encryption is supported or required")
+	cli.BoolFlag(ebsOptimized, nil, nil, "EBS Optimized is supported or default")
+	cli.ByteQuantityMinMaxRangeFlags(ebsOptimizedBaselineBandwidth, nil, nil, "EBS Optimized baseline bandwidth (Example: 4 GiB)")
+	cli.ByteQuantityMinMaxRangeFlags(ebsOptimizedBaselineThroughput, nil, nil, "EBS Optimized baseline throughput per second (Example: 4 GiB)")
+	cli.IntMinMaxRangeFlags(ebsOptimizedBaselineIOPS, nil, nil, "EBS Optimized baseline IOPS per second (Example: 10000)")
+	cli.BoolFlag(freeTier, nil, nil, "Free Tier supported")
+	cli.BoolFlag(autoRecovery, nil, nil, "EC2 Auto-Recovery supported")
+	cli.BoolFlag(dedicatedHosts, nil, nil, "Dedicated Hosts supported")
+	cli.IntMinMaxRangeFlags(generation, nil, nil, "Generation of the instance type (i.e. c7i.xlarge is 7)")
+	cli.StringSliceFlag(family, nil, nil, "Instance type families or series parsed from the instance type name (Example: c,m matches every c and m instance type while c7gn only matches c7gn)")
+	cli.StringSliceFlag(nameAttributes, nil, nil, "Processor and capability suffixes which must all be in the instance type name: a (AMD), g (Graviton), i (Intel), b (EBS), d (instance store), e (extra memory or storage), n (network), z (high frequency) or flex (Example: d,n)")
+	cli.InstanceSizeRangeFlag(size, nil, nil, "Size of the instance type")
+	cli.StringSliceFlag(instanceTypes, nil, nil, "Instance Type names (must be exact, use allow-list for regex)")
+	cli.ExpressionFlag(where, nil, nil, "Expression evaluated against instance type details, prices, and derived fields TotalGpus, TotalGpuMemoryMiB, and TotalInferenceAccelerators (Example: \"MemoryInfo.SizeInMiB / VCpuInfo.DefaultVCpus >= 6144 && SpotPrice < 0.5\")")
 
+	// Suite Flags - higher level aggregate filters that return opinionated result
+
+	cli.SuiteStringSliceFlag(instanceTypeBase, nil, nil, "Instance Type used to retrieve similarly spec'd instance types, where multiple instance types group the results by base instance type (Example: m5.2xlarge,c5.4xlarge)")
+	cli.SuiteFloat64Flag(baseLowPercentile, nil, nil, fmt.Sprintf("Fraction of the --%s vcpus and memory used as the lower bound of their ranges (default %v)", instanceTypeBase, selector.AggregateLowPercentile))
+	cli.SuiteFloat64Flag(baseHighPercentile, nil, nil, fmt.Sprintf("Fraction of the --%s vcpus and memory used as the upper bound of their ranges (default %v)", instanceTypeBase, selector.AggregateHighPercentile))
+	cli.SuiteBoolFlag(similarity, nil, nil, fmt.Sprintf("Rank instance types by their distance to the --%s instead of filtering on ranges of its resources, returning the closest --%s", instanceTypeBase, maxResults))
+	cli.SuiteStringSliceFlag(similarityAttributes, nil, nil, fmt.Sprintf("Attributes compared by --%s (default %s)", similarity, strings.Join(selector.SimilarityAttributes(), ",")))
+	cli.SuiteOptionalStringFlag(flexible, nil, nil, selector.DefaultFlexibleProfile, fmt.Sprintf("Retrieves a group of instance types spanning multiple generations based on the opinionated defaults of a flexible profile and user overridden resource filters (Example: --%s or --%s=<profile>)", flexible, flexible), nil)
+	cli.SuiteStringFlag(service, nil, nil, "Filter instance types based on service support (Example: emr-5.20.0)", nil)
+}
+
+// getFilters builds a selector.Filters struct from the parsed filter and suite flags.
+func getFilters(cli *commandline.CommandLineInterface, flags map[string]interface{}) selector.Filters {
 	var cpuArchitectureFilterValue *ec2types.ArchitectureType
 
 	if arch, ok := flags[cpuArchitecture].(*string); ok && arch != nil {
@@ -370,6 +916,41 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		usageClassFilterValue = &value
 	}
 
+	var operatingSystemFilterValue *ec2pricing.OperatingSystem
+
+	if opSys, ok := flags[operatingSystem].(*string); ok && opSys != nil {
+		value := ec2pricing.OperatingSystem(*opSys)
+		operatingSystemFilterValue = &value
+	}
+
+	var tenancyFilterValue *ec2pricing.Tenancy
+
+	if ten, ok := flags[tenancy].(*string); ok && ten != nil {
+		value := ec2pricing.Tenancy(*ten)
+		tenancyFilterValue = &value
+	}
+
+	var licenseModelFilterValue *ec2pricing.LicenseModel
+
+	if license, ok := flags[licenseModel].(*string); ok && license != nil {
+		value := ec2pricing.LicenseModel(*license)
+		licenseModelFilterValue = &value
+	}
+
+	var pricingModelFilterValue *ec2pricing.PricingModel
+
+	if model, ok := flags[pricingModel].(*string); ok && model != nil {
+		value := ec2pricing.PricingModel(*model)
+		pricingModelFilterValue = &value
+	}
+
+	var spotAZStrategyFilterValue *selector.SpotAZStrategy
+
+	if strategy, ok := flags[spotAZStrategy].(*string); ok && strategy != nil {
+		value := selector.SpotAZStrategy(*strategy)
+		spotAZStrategyFilterValue = &value
+	}
+
 	var hypervisorFilterValue *ec2types.InstanceTypeHypervisor
 
 	if hype, ok := flags[hypervisor].(*string); ok && hype != nil {
@@ -377,10 +958,56 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		hypervisorFilterValue = &value
 	}
 
-	filters := selector.Filters{
+	var regionsFilterValue *[]string
+	if regionsList := cli.StringSliceMe(flags[regions]); regionsList != nil && len(*regionsList) > 0 {
+		regionsFilterValue = regionsList
+	}
+
+	// a single base instance type is transformed as before while multiple base instance types are selected per base
+	var instanceTypeBaseFilterValue *string
+	var instanceTypeBasesFilterValue *[]string
+	if basesList := cli.StringSliceMe(flags[instanceTypeBase]); basesList != nil && len(*basesList) == 1 {
+		instanceTypeBaseFilterValue = &(*basesList)[0]
+	} else if basesList != nil && len(*basesList) > 1 {
+		instanceTypeBasesFilterValue = basesList
+	}
+
+	var similarityAttributesFilterValue *[]string
+	if attributesList := cli.StringSliceMe(flags[similarityAttributes]); attributesList != nil && len(*attributesList) > 0 {
+		similarityAttributesFilterValue = attributesList
+	}
+
+	// --flexible=true and --flexible=false are still accepted from when --flexible was a boolean flag
+	var flexibleFilterValue *bool
+	var flexibleProfileFilterValue *string
+	if profile := cli.StringMe(flags[flexible]); profile != nil && *profile != "false" {
+		flexibleFilterValue = cli.BoolMe(true)
+		if *profile != "true" {
+			flexibleProfileFilterValue = profile
+		}
+	}
+
+	// a single ratio is matched exactly as before while a ratio range selects a range of GiBs of memory per vcpu
+	var vcpusToMemoryRatioFilterValue *float64
+	var vcpusToMemoryRatioRangeFilterValue *selector.Range[float64]
+	switch ratio := flags[vcpusToMemoryRatio].(type) {
+	case *float64:
+		vcpusToMemoryRatioFilterValue = ratio
+	case *selector.Range[float64]:
+		vcpusToMemoryRatioRangeFilterValue = ratio
+	}
+
+	var regionModeFilterValue *selector.RegionMode
+	if mode, ok := flags[regionMode].(*string); ok && mode != nil {
+		value := selector.RegionMode(*mode)
+		regionModeFilterValue = &value
+	}
+
+	return selector.Filters{
 		VCpusRange:                       cli.Int32RangeMe(flags[vcpus]),
 		MemoryRange:                      cli.ByteQuantityRangeMe(flags[memory]),
-		VCpusToMemoryRatio:               cli.Float64Me(flags[vcpusToMemoryRatio]),
+		VCpusToMemoryRatio:               vcpusToMemoryRatioFilterValue,
+		VCpusToMemoryRatioRange:          vcpusToMemoryRatioRangeFilterValue,
 		CPUArchitecture:                  cpuArchitectureFilterValue,
 		CPUManufacturer:                  cpuManufacturerFilterValue,
 		GpusRange:                        cli.Int32RangeMe(flags[gpus]),
@@ -392,6 +1019,10 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		InferenceAcceleratorModel:        cli.StringMe(flags[inferenceAcceleratorModel]),
 		PlacementGroupStrategy:           cli.StringMe(flags[placementGroupStrategy]),
 		UsageClass:                       usageClassFilterValue,
+		OperatingSystem:                  operatingSystemFilterValue,
+		Tenancy:                          tenancyFilterValue,
+		LicenseModel:                     licenseModelFilterValue,
+		PricingModel:                     pricingModelFilterValue,
 		RootDeviceType:                   deviceTypeFilterValue,
 		EnaSupport:                       cli.BoolMe(flags[enaSupport]),
 		EfaSupport:                       cli.BoolMe(flags[efaSupport]),
@@ -400,21 +1031,37 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		BareMetal:                        cli.BoolMe(flags[baremetal]),
 		Fpga:                             cli.BoolMe(flags[fpgaSupport]),
 		Burstable:                        cli.BoolMe(flags[burstSupport]),
-		Region:                           cli.StringMe(flags[region]),
 		AvailabilityZones:                cli.StringSliceMe(flags[availabilityZones]),
+		Regions:                          regionsFilterValue,
+		RegionMode:                       regionModeFilterValue,
 		CurrentGeneration:                cli.BoolMe(flags[currentGeneration]),
-		MaxResults:                       cli.IntMe(flags[maxResults]),
 		NetworkInterfaces:                cli.Int32RangeMe(flags[networkInterfaces]),
 		NetworkPerformance:               cli.IntRangeMe(flags[networkPerformance]),
 		NetworkEncryption:                cli.BoolMe(flags[networkEncryption]),
 		IPv6:                             cli.BoolMe(flags[ipv6]),
 		AllowList:                        cli.RegexMe(flags[allowList]),
 		DenyList:                         cli.RegexMe(flags[denyList]),
-		InstanceTypeBase:                 cli.StringMe(flags[instanceTypeBase]),
-		Flexible:                         cli.BoolMe(flags[flexible]),
+		InstanceTypeBase:                 instanceTypeBaseFilterValue,
+		InstanceTypeBases:                instanceTypeBasesFilterValue,
+		InstanceTypeBaseLowPercentile:    cli.Float64Me(flags[baseLowPercentile]),
+		InstanceTypeBaseHighPercentile:   cli.Float64Me(flags[baseHighPercentile]),
+		InstanceTypeBaseSimilarity:       cli.BoolMe(flags[similarity]),
+		SimilarityAttributes:             similarityAttributesFilterValue,
+		Flexible:                         flexibleFilterValue,
+		FlexibleProfile:                  flexibleProfileFilterValue,
+		CustomFilters:                    cli.CustomFiltersMe(flags),
 		Service:                          cli.StringMe(flags[service]),
 		VirtualizationType:               virtualizationTypeFilterValue,
 		PricePerHour:                     cli.Float64RangeMe(flags[pricePerHour]),
+		PricePerVCPU:                     cli.Float64RangeMe(flags[pricePerVCPU]),
+		PricePerGiBMemory:                cli.Float64RangeMe(flags[pricePerGiBMemory]),
+		PricePerGPU:                      cli.Float64RangeMe(flags[pricePerGPU]),
+		PricePerGiBGPUMemory:             cli.Float64RangeMe(flags[pricePerGiBGPUMemory]),
+		PricePerGbpsNetwork:              cli.Float64RangeMe(flags[pricePerGbpsNetwork]),
+		SpotDays:                         cli.IntMe(flags[spotDays]),
+		SpotDiscount:                     cli.Float64RangeMe(flags[spotDiscount]),
+		SpotAZStrategy:                   spotAZStrategyFilterValue,
+		SpotVolatility:                   cli.Float64RangeMe(flags[spotVolatility]),
 		InstanceStorageRange:             cli.ByteQuantityRangeMe(flags[instanceStorage]),
 		DiskType:                         cli.StringMe(flags[diskType]),
 		DiskEncryption:                   cli.BoolMe(flags[diskEncryption]),
@@ -427,95 +1074,426 @@ Full docs can be found at github.com/aws/amazon-` + binName
 		AutoRecovery:                     cli.BoolMe(flags[autoRecovery]),
 		DedicatedHosts:                   cli.BoolMe(flags[dedicatedHosts]),
 		Generation:                       cli.IntRangeMe(flags[generation]),
+		Families:                         cli.StringSliceMe(flags[family]),
+		NameAttributes:                   cli.StringSliceMe(flags[nameAttributes]),
+		SizeRange:                        cli.Float64RangeMe(flags[size]),
 		InstanceTypes:                    cli.StringSliceMe(flags[instanceTypes]),
+		Expression:                       cli.ExpressionMe(flags[where]),
 	}
+}
 
-	if flags[verbose] != nil {
-		resultsOutputFn = outputs.VerboseInstanceTypeOutput
-		transformedFilters, err := instanceSelector.AggregateFilterTransform(ctx, filters)
+// instanceTypeBasesOutput returns a table of the candidates grouped by base instance type,
+// flagging candidates for more than one base instance type.
+func instanceTypeBasesOutput(baseResults []selector.InstanceTypeBaseResults) []string {
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Base Instance Type\tInstance Type\tAlso Candidate For\n")
+	fmt.Fprintf(w, "------------------\t-------------\t------------------\n")
+	for _, baseResult := range baseResults {
+		if len(baseResult.InstanceTypes) == 0 {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", baseResult.InstanceTypeBase, "none", "-")
+			continue
+		}
+		for _, instanceTypeInfo := range baseResult.InstanceTypes {
+			otherBases := []string{}
+			for _, base := range instanceTypeInfo.InstanceTypeBases {
+				if base != baseResult.InstanceTypeBase {
+					otherBases = append(otherBases, base)
+				}
+			}
+			alsoCandidateFor := "-"
+			if len(otherBases) > 0 {
+				alsoCandidateFor = strings.Join(otherBases, ", ")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", baseResult.InstanceTypeBase, instanceTypeInfo.InstanceType, alsoCandidateFor)
+		}
+	}
+	w.Flush()
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+// getScoreWeights merges the weights in the --score-file with the --score weights and returns nil if neither is specified.
+func getScoreWeights(cli *commandline.CommandLineInterface, flags map[string]interface{}) (selector.ScoreWeights, error) {
+	scoreWeights := selector.ScoreWeights{}
+	if scoreFilePath := cli.StringMe(flags[scoreFile]); scoreFilePath != nil {
+		scoreFileContents, err := os.ReadFile(*scoreFilePath)
 		if err != nil {
-			fmt.Printf("An error occurred while transforming the aggregate filters")
-			os.Exit(1)
+			return nil, fmt.Errorf("unable to read %s: %w", *scoreFilePath, err)
 		}
-		filtersJSON, err := filters.MarshalIndent("", "    ")
+		if err := json.Unmarshal(scoreFileContents, &scoreWeights); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", *scoreFilePath, err)
+		}
+	}
+	if weights := cli.StringSliceMe(flags[score]); weights != nil && len(*weights) > 0 {
+		flagWeights, err := selector.ParseScoreWeights(*weights)
 		if err != nil {
-			fmt.Printf("An error occurred when printing filters due to --verbose being specified: %v", err)
-			os.Exit(1)
+			return nil, err
 		}
-		transformedFiltersJSON, err := transformedFilters.MarshalIndent("", "    ")
+		for attribute, weight := range flagWeights {
+			scoreWeights[attribute] = weight
+		}
+	}
+	if len(scoreWeights) == 0 {
+		return nil, nil
+	}
+	return scoreWeights, scoreWeights.Validate()
+}
+
+// parseFilterGroups parses --any-of and --none-of filter groups into selector.Filters structs.
+func parseFilterGroups(groups *[]string) ([]selector.Filters, error) {
+	if groups == nil {
+		return nil, nil
+	}
+	filtersList := []selector.Filters{}
+	for _, group := range *groups {
+		groupFilters, err := parseFilterGroup(group)
 		if err != nil {
-			fmt.Printf("An error occurred when printing aggregate filters due to --verbose being specified: %v", err)
-			os.Exit(1)
+			return nil, fmt.Errorf("invalid filter group \"%s\": %w", group, err)
 		}
-		log.Println("\n\n\"Filters\":", string(filtersJSON))
-		if string(transformedFiltersJSON) != string(filtersJSON) {
-			log.Println("\n\n\"Transformed Filters\":", string(transformedFiltersJSON))
+		filtersList = append(filtersList, groupFilters)
+	}
+	return filtersList, nil
+}
+
+// parseFilterGroup parses a space separated list of filter flags with or without the leading dashes
+// (Example: "gpus-min=1 gpu-memory-total-min=24gib") into a selector.Filters struct.
+func parseFilterGroup(group string) (selector.Filters, error) {
+	groupCLI := commandline.New(binName, "", "", "", func(cmd *cobra.Command, args []string) {})
+	groupCLI.Command.SilenceErrors = true
+	groupCLI.Command.SilenceUsage = true
+	registerFilterFlags(&groupCLI)
+	args := []string{}
+	for _, token := range strings.Fields(group) {
+		// flag names are accepted without leading dashes while all other tokens are passed through as flag values
+		if _, ok := groupCLI.Flags[strings.Split(token, "=")[0]]; ok {
+			token = "--" + token
+		}
+		args = append(args, token)
+	}
+	if len(args) == 0 {
+		return selector.Filters{}, fmt.Errorf("filter group cannot be empty")
+	}
+	groupFlags, err := groupCLI.ParseAndValidateFlagsFromArgs(args)
+	if err != nil {
+		return selector.Filters{}, err
+	}
+	if unknownArgs := groupCLI.Command.Flags().Args(); len(unknownArgs) != 0 {
+		return selector.Filters{}, fmt.Errorf("unknown filter flags: %s", strings.Join(unknownArgs, ", "))
+	}
+	return getFilters(&groupCLI, groupFlags), nil
+}
+
+// priceFilterCaches returns whether the price filters of the top-level filters and the filter groups filter on on-demand or spot prices.
+// Filter groups are combined with the top-level filters, so a group uses the top-level price filters, pricing model and usage class unless it sets its own.
+func priceFilterCaches(filters selector.Filters, filterGroups []selector.Filters) (onDemand bool, spot bool) {
+	for _, groupFilters := range append([]selector.Filters{filters}, filterGroups...) {
+		if !hasPriceFilter(groupFilters) && !hasPriceFilter(filters) {
+			continue
+		}
+		pricingModel := groupFilters.PricingModel
+		if pricingModel == nil {
+			pricingModel = filters.PricingModel
+		}
+		usageClass := groupFilters.UsageClass
+		if usageClass == nil {
+			usageClass = filters.UsageClass
+		}
+		// reserved instance prices are retrieved with the on-demand prices
+		if pricingModel != nil {
+			if *pricingModel == ec2pricing.PricingModelSpot {
+				spot = true
+			} else {
+				onDemand = true
+			}
+		} else if usageClass == nil || *usageClass == ec2types.UsageClassTypeOnDemand {
+			onDemand = true
 		} else {
-			log.Println("There were no transformations on the filters to display")
+			spot = true
 		}
 	}
+	return onDemand, spot
+}
 
-	// fetch instance types without truncating results
-	prevMaxResults := filters.MaxResults
-	filters.MaxResults = nil
-	instanceTypesDetails, err := instanceSelector.FilterVerbose(ctx, filters)
-	if err != nil {
-		fmt.Printf("An error occurred when filtering instance types: %v", err)
-		os.Exit(1)
+// hasPriceFilter returns true if the filters filter on the price of the instance types
+func hasPriceFilter(filters selector.Filters) bool {
+	return filters.PricePerHour != nil || filters.PricePerVCPU != nil || filters.PricePerGiBMemory != nil ||
+		filters.PricePerGPU != nil || filters.PricePerGiBGPUMemory != nil || filters.PricePerGbpsNetwork != nil
+}
+
+// getAnyFilters builds a selector.AnyFilters query where the top-level filters apply to every filter group.
+// Filters set within a group take precedence over the top-level filters.
+func getAnyFilters(filters selector.Filters, anyOfFilters []selector.Filters, noneOfFilters []selector.Filters) (selector.AnyFilters, error) {
+	query := selector.AnyFilters{MaxResults: filters.MaxResults}
+	if len(anyOfFilters) == 0 {
+		anyOfFilters = []selector.Filters{{}}
+	}
+	for _, groupFilters := range anyOfFilters {
+		mergedFilters, err := mergeFilterGroup(groupFilters, filters)
+		if err != nil {
+			return query, err
+		}
+		query.Any = append(query.Any, mergedFilters)
+	}
+	for _, groupFilters := range noneOfFilters {
+		mergedFilters, err := mergeFilterGroup(groupFilters, filters)
+		if err != nil {
+			return query, err
+		}
+		query.Not = append(query.Not, mergedFilters)
+	}
+	return query, nil
+}
+
+func mergeFilterGroup(groupFilters selector.Filters, filters selector.Filters) (selector.Filters, error) {
+	// Unset list flags are empty lists rather than nil, so treat them as unset to allow the top-level values through
+	if groupFilters.AvailabilityZones != nil && len(*groupFilters.AvailabilityZones) == 0 {
+		groupFilters.AvailabilityZones = nil
+	}
+	if groupFilters.InstanceTypes != nil && len(*groupFilters.InstanceTypes) == 0 {
+		groupFilters.InstanceTypes = nil
+	}
+	if err := mergo.Merge(&groupFilters, filters); err != nil {
+		return groupFilters, err
 	}
+	groupFilters.MaxResults = nil
+	return groupFilters, nil
+}
+
+// explanationOutput formats an explanation as a table of the instance types eliminated by each filter
+// followed by the names of the eliminated instance types.
+func explanationOutput(explanation *selector.Explanation) []string {
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Evaluated %d instance types, %d matched all filters\n\n", explanation.TotalInstanceTypes, len(explanation.MatchedInstanceTypes))
+	fmt.Fprintf(w, "Filter\tEliminated\tOnly Failed Filter\t\n")
+	fmt.Fprintf(w, "------\t----------\t------------------\t\n")
+	for _, rejection := range explanation.Rejections {
+		fmt.Fprintf(w, "%s\t%d\t%d\t\n", rejection.Filter, len(rejection.InstanceTypes), rejection.SoleRejections)
+	}
+	w.Flush()
 
-	// sort instance types
-	sortDirection := cli.StringMe(flags[sortDirection])
-	instanceTypesDetails, err = sorter.Sort(instanceTypesDetails, *sortField, *sortDirection)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for _, rejection := range explanation.Rejections {
+		lines = append(lines, "", fmt.Sprintf("%s eliminated: %s", rejection.Filter, strings.Join(rejection.InstanceTypes, ", ")))
+	}
+	return lines
+}
+
+// dryRunOutput formats the resolved filters as json followed by a table of the transform which set each filter.
+func dryRunOutput(resolvedFilters *selector.ResolvedFilters) ([]string, error) {
+	filtersJSON, err := resolvedFilters.Filters.MarshalIndent("", "    ")
 	if err != nil {
-		fmt.Printf("Sorting error: %v", err)
-		os.Exit(1)
+		return nil, err
 	}
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
 
-	// handle output format
-	var itemsTruncated int
-	var instanceTypes []string
-	if outputFlag != nil && *outputFlag == bubbleTeaOutput {
-		p := tea.NewProgram(outputs.NewBubbleTeaModel(instanceTypesDetails), tea.WithMouseCellMotion())
-		if _, err := p.Run(); err != nil {
-			fmt.Printf("An error occurred when starting bubble tea: %v", err)
-			os.Exit(1)
+	fmt.Fprintf(w, "Filter\tSet By\t\n")
+	fmt.Fprintf(w, "------\t------\t\n")
+	for _, field := range resolvedFilters.Fields() {
+		fmt.Fprintf(w, "%s\t%s\t\n", field, resolvedFilters.SetBy[field])
+	}
+	w.Flush()
+
+	lines := []string{"\"Filters\": " + string(filtersJSON), ""}
+	return append(lines, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")...), nil
+}
+
+// whyNotOutput formats a why-not report as a table of the failed filters followed by the other reasons the instance type was excluded.
+func whyNotOutput(report *selector.WhyNotReport, resultsLimit *int) []string {
+	if report.Selected {
+		return []string{fmt.Sprintf("%s satisfies every filter and is selected", report.InstanceType)}
+	}
+	if report.ExcludedByMaxResults {
+		return []string{fmt.Sprintf("%s satisfies every filter but is ranked %d and was truncated by --%s %d", report.InstanceType, report.Rank, maxResults, *resultsLimit)}
+	}
+
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "%s does not satisfy the following filters\n\n", report.InstanceType)
+	fmt.Fprintf(w, "Filter\tFilter Value\tInstance Value\tSet By Service\t\n")
+	fmt.Fprintf(w, "------\t------------\t--------------\t--------------\t\n")
+	for _, failedFilter := range report.FailedFilters {
+		instanceSpec := formatFilterValue(failedFilter.InstanceSpec)
+		if failedFilter.Err != nil {
+			instanceSpec = fmt.Sprintf("error: %v", failedFilter.Err)
 		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t\n", failedFilter.Filter, formatFilterValue(failedFilter.FilterValue), instanceSpec, failedFilter.SetByService)
+	}
+	w.Flush()
 
-		shutdown()
-		return
-	} else {
-		// handle regular output modes
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	reasons := []string{}
+	if report.ExcludedByLocation {
+		reasons = append(reasons, fmt.Sprintf("%s is not offered in the availability zones or region", report.InstanceType))
+	}
+	if report.ExcludedByDenyList {
+		reasons = append(reasons, fmt.Sprintf("%s matches --%s", report.InstanceType, denyList))
+	}
+	if report.ExcludedByAllowList {
+		reasons = append(reasons, fmt.Sprintf("%s does not match --%s", report.InstanceType, allowList))
+	}
+	if report.ExcludedByService {
+		reasons = append(reasons, fmt.Sprintf("%s does not satisfy filters set by --%s", report.InstanceType, service))
+	}
+	if len(reasons) > 0 {
+		lines = append(append(lines, ""), reasons...)
+	}
+	return lines
+}
 
-		// truncate instance types based on user passed in maxResults
-		instanceTypesDetails, itemsTruncated = truncateResults(prevMaxResults, instanceTypesDetails)
-		if len(instanceTypesDetails) == 0 {
-			log.Println("The criteria was too narrow and returned no valid instance types. Consider broadening your criteria so that more instance types are returned.")
-			os.Exit(1)
+// validateFilters prints the validation warnings of the filters and the --any-of and --none-of filter groups,
+// and returns an error listing the validation errors.
+func validateFilters(ctx context.Context, instanceSelector *selector.Selector, filters selector.Filters, anyOfFilters []selector.Filters, noneOfFilters []selector.Filters) error {
+	validationIssues, err := instanceSelector.ValidateFilters(ctx, filters)
+	if err != nil {
+		return err
+	}
+	labels := []string{""}
+	issuesByLabel := []selector.ValidationIssues{validationIssues}
+	for _, group := range []struct {
+		flagName string
+		filters  []selector.Filters
+	}{{anyOf, anyOfFilters}, {noneOf, noneOfFilters}} {
+		for i, groupFilters := range group.filters {
+			labels = append(labels, fmt.Sprintf("--%s group %d: ", group.flagName, i+1))
+			issuesByLabel = append(issuesByLabel, groupFilters.Validate())
+		}
+	}
+	validationErrors := []string{}
+	for i, issues := range issuesByLabel {
+		for _, warning := range issues.Warnings() {
+			log.Printf("%s%s", labels[i], warning)
 		}
+		for _, validationError := range issues.Errors() {
+			validationErrors = append(validationErrors, labels[i]+validationError.String())
+		}
+	}
+	if len(validationErrors) > 0 {
+		return fmt.Errorf("the filters cannot select any instance types:\n  %s", strings.Join(validationErrors, "\n  "))
+	}
+	return nil
+}
 
-		// format instance types for output
-		outputFn := getOutputFn(outputFlag, selector.InstanceTypesOutputFn(resultsOutputFn))
-		instanceTypes = outputFn(instanceTypesDetails)
+// relaxationOutput formats the relaxation suggestions as a ranked list of flag changes with the number of results each would return.
+func relaxationOutput(suggestions []selector.RelaxationSuggestion) []string {
+	if len(suggestions) == 0 {
+		return nil
 	}
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
 
-	for _, instanceType := range instanceTypes {
-		fmt.Println(instanceType)
+	fmt.Fprintf(w, "Each of the following changes would return instance types:\n")
+	for i, suggestion := range suggestions {
+		flagName := strings.TrimPrefix(suggestion.Filter, selector.CustomFilterKeyPrefix)
+		if name, ok := filterKeyFlags[suggestion.Filter]; ok {
+			flagName = name
+		}
+		var change string
+		switch suggestion.Action {
+		case selector.RelaxationLowerBound:
+			change = fmt.Sprintf("widen --%s-min to %s", flagName, formatFilterValue(reflect.ValueOf(suggestion.FilterValue).Elem().FieldByName("LowerBound").Interface()))
+		case selector.RelaxationUpperBound:
+			change = fmt.Sprintf("widen --%s-max to %s", flagName, formatFilterValue(reflect.ValueOf(suggestion.FilterValue).Elem().FieldByName("UpperBound").Interface()))
+		case selector.RelaxationRemoveAvailabilityZone:
+			change = fmt.Sprintf("remove %s from --%s", suggestion.AvailabilityZone, flagName)
+		default:
+			change = fmt.Sprintf("drop --%s", flagName)
+		}
+		fmt.Fprintf(w, "  %d. %s\t(%d instance types)\t\n", i+1, change, suggestion.Results)
 	}
+	w.Flush()
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
 
-	if itemsTruncated > 0 {
-		log.Printf("%d entries were truncated, increase --%s to see more", itemsTruncated, maxResults)
+// formatFilterValue formats a filter value or instance spec value for display
+// Pointers are dereferenced, ranges are displayed as "lower - upper", and lists are comma separated.
+func formatFilterValue(value interface{}) string {
+	if value == nil {
+		return "none"
 	}
-	shutdown()
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	switch v := value.(type) {
+	case *instancetypes.Details:
+		return string(v.InstanceType)
+	case bytequantity.ByteQuantity:
+		if v.Quantity%1024 == 0 {
+			return fmt.Sprintf("%.0f GiB", v.GiB())
+		}
+		return v.StringMiB()
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Ptr:
+		if reflectValue.IsNil() {
+			return "none"
+		}
+		return formatFilterValue(reflectValue.Elem().Interface())
+	case reflect.Slice:
+		values := []string{}
+		for i := 0; i < reflectValue.Len(); i++ {
+			values = append(values, formatFilterValue(reflectValue.Index(i).Interface()))
+		}
+		return strings.Join(values, ", ")
+	case reflect.Struct:
+		lowerBound := reflectValue.FieldByName("LowerBound")
+		upperBound := reflectValue.FieldByName("UpperBound")
+		if lowerBound.IsValid() && upperBound.IsValid() {
+			return formatRange(lowerBound, upperBound, reflectValue.FieldByName("LowerBoundExclusive"), reflectValue.FieldByName("UpperBoundExclusive"))
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// formatRange formats range bounds as "lower - upper", or as a comparison such as ">= lower" when a bound is not set or is exclusive
+func formatRange(lowerBound reflect.Value, upperBound reflect.Value, lowerBoundExclusive reflect.Value, upperBoundExclusive reflect.Value) string {
+	isExclusive := func(exclusive reflect.Value) bool { return exclusive.IsValid() && exclusive.Bool() }
+	isSet := func(bound reflect.Value) bool { return bound.Kind() != reflect.Ptr || !bound.IsNil() }
+	lowerOperator, upperOperator := ">=", "<="
+	if isExclusive(lowerBoundExclusive) {
+		lowerOperator = ">"
+	}
+	if isExclusive(upperBoundExclusive) {
+		upperOperator = "<"
+	}
+	switch {
+	case isSet(lowerBound) && isSet(upperBound) && !isExclusive(lowerBoundExclusive) && !isExclusive(upperBoundExclusive):
+		return fmt.Sprintf("%s - %s", formatFilterValue(lowerBound.Interface()), formatFilterValue(upperBound.Interface()))
+	case isSet(lowerBound) && isSet(upperBound):
+		return fmt.Sprintf("%s %s and %s %s", lowerOperator, formatFilterValue(lowerBound.Interface()), upperOperator, formatFilterValue(upperBound.Interface()))
+	case isSet(lowerBound):
+		return fmt.Sprintf("%s %s", lowerOperator, formatFilterValue(lowerBound.Interface()))
+	case isSet(upperBound):
+		return fmt.Sprintf("%s %s", upperOperator, formatFilterValue(upperBound.Interface()))
+	}
+	return "any"
+}
+
+// pricingModels returns the values of the pricing model flag
+func pricingModels() []string {
+	models := []string{}
+	for _, model := range ec2pricing.PricingModel("").Values() {
+		models = append(models, string(model))
+	}
+	return models
 }
 
-func hydrateCaches(ctx context.Context, instanceSelector selector.Selector) (errs error) {
+func hydrateCaches(ctx context.Context, instanceSelector selector.Selector, pricingOptions ec2pricing.PricingOptions, spotDaysBack int) (errs error) {
 	wg := &sync.WaitGroup{}
 	hydrateTasks := []func(*sync.WaitGroup) error{
 		func(waitGroup *sync.WaitGroup) error {
 			defer waitGroup.Done()
-			if instanceSelector.EC2Pricing.OnDemandCacheCount() == 0 {
-				if err := instanceSelector.EC2Pricing.RefreshOnDemandCache(ctx); err != nil {
+			if instanceSelector.EC2Pricing.OnDemandCacheCount(pricingOptions) == 0 {
+				if err := instanceSelector.EC2Pricing.RefreshOnDemandCache(ctx, pricingOptions); err != nil {
 					return multierr.Append(errs, fmt.Errorf("there was a problem refreshing the on-demand pricing cache: %w", err))
 				}
 			}
@@ -523,8 +1501,8 @@ func hydrateCaches(ctx context.Context, instanceSelector selector.Selector) (err
 		},
 		func(waitGroup *sync.WaitGroup) error {
 			defer waitGroup.Done()
-			if instanceSelector.EC2Pricing.SpotCacheCount() == 0 {
-				if err := instanceSelector.EC2Pricing.RefreshSpotCache(ctx, spotPricingDaysBack); err != nil {
+			if instanceSelector.EC2Pricing.SpotCacheCount(pricingOptions, spotDaysBack) == 0 {
+				if err := instanceSelector.EC2Pricing.RefreshSpotCache(ctx, pricingOptions, spotDaysBack); err != nil {
 					return multierr.Append(errs, fmt.Errorf("there was a problem refreshing the spot pricing cache: %w", err))
 				}
 			}
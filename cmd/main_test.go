@@ -0,0 +1,50 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+func TestPriceFilterCaches(t *testing.T) {
+	for _, tc := range []struct {
+		filters  string
+		groups   []string
+		onDemand bool
+		spot     bool
+	}{
+		{filters: "vcpus=2", groups: []string{"usage-class=spot"}},
+		{filters: "price-per-hour-max=0.1", onDemand: true},
+		{filters: "price-per-hour-max=0.1 usage-class=spot", spot: true},
+		{filters: "vcpus=2", groups: []string{"usage-class=spot price-per-hour-max=0.1"}, spot: true},
+		{filters: "usage-class=spot", groups: []string{"price-per-hour-max=0.1"}, spot: true},
+		{filters: "price-per-hour-max=0.1", groups: []string{"usage-class=spot", "vcpus=4"}, onDemand: true, spot: true},
+		{filters: "price-per-hour-max=0.1 pricing-model=spot", spot: true},
+		{filters: "price-per-hour-max=0.1 usage-class=spot", groups: []string{"pricing-model=reserved-1yr-standard-no-upfront"}, onDemand: true, spot: true},
+	} {
+		filters, err := parseFilterGroup(tc.filters)
+		h.Ok(t, err)
+		groups := []selector.Filters{}
+		for _, group := range tc.groups {
+			groupFilters, err := parseFilterGroup(group)
+			h.Ok(t, err)
+			groups = append(groups, groupFilters)
+		}
+		onDemand, spot := priceFilterCaches(filters, groups)
+		h.Assert(t, onDemand == tc.onDemand, "%s %v should refresh the on-demand cache: %t", tc.filters, tc.groups, tc.onDemand)
+		h.Assert(t, spot == tc.spot, "%s %v should refresh the spot cache: %t", tc.filters, tc.groups, tc.spot)
+	}
+}
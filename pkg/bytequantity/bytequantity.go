@@ -13,6 +13,7 @@
 package bytequantity
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"regexp"
@@ -136,6 +137,38 @@ func (bq ByteQuantity) StringTiB() string {
 	return fmt.Sprintf("%.3f %s", bq.TiB(), tib)
 }
 
+// MarshalJSON returns the json representation of a byte quantity as a string in the largest unit which represents it exactly, i.e. "8 GiB".
+func (bq ByteQuantity) MarshalJSON() ([]byte, error) {
+	switch {
+	case bq.Quantity != 0 && bq.Quantity%tbConvert == 0:
+		return json.Marshal(fmt.Sprintf("%d %s", bq.Quantity/tbConvert, tib))
+	case bq.Quantity != 0 && bq.Quantity%gbConvert == 0:
+		return json.Marshal(fmt.Sprintf("%d %s", bq.Quantity/gbConvert, gib))
+	}
+	return json.Marshal(bq.StringMiB())
+}
+
+// UnmarshalJSON parses a byte quantity from a string accepted by ParseToByteQuantity, i.e. "8 GiB".
+// The object representation of a ByteQuantity with the quantity in mebibytes is also accepted.
+func (bq *ByteQuantity) UnmarshalJSON(data []byte) error {
+	var byteQuantityStr string
+	if err := json.Unmarshal(data, &byteQuantityStr); err == nil {
+		parsed, err := ParseToByteQuantity(byteQuantityStr)
+		if err != nil {
+			return err
+		}
+		*bq = parsed
+		return nil
+	}
+	type Alias ByteQuantity
+	aux := Alias{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return fmt.Errorf("%s is not a valid byte quantity, must be a string such as \"8 GiB\"", string(data))
+	}
+	*bq = ByteQuantity(aux)
+	return nil
+}
+
 // MiB returns a byte quantity in mebibytes.
 func (bq ByteQuantity) MiB() float64 {
 	return float64(bq.Quantity)
@@ -14,6 +14,7 @@
 package bytequantity_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -128,3 +129,45 @@ func TestFromTiB(t *testing.T) {
 	bq := bytequantity.FromTiB(testVal)
 	h.Assert(t, bq.TiB() == expectedVal, "%d TiB should equal %d, instead got %s", expectedVal, expectedVal, bq.StringTiB())
 }
+
+func TestMarshalJSON(t *testing.T) {
+	for expected, bq := range map[string]bytequantity.ByteQuantity{
+		`"0 MiB"`:    {Quantity: 0},
+		`"1536 MiB"`: bytequantity.FromMiB(1536),
+		`"8 GiB"`:    bytequantity.FromGiB(8),
+		`"2 TiB"`:    bytequantity.FromTiB(2),
+	} {
+		out, err := json.Marshal(bq)
+		h.Ok(t, err)
+		h.Equals(t, expected, string(out))
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for input, expected := range map[string]bytequantity.ByteQuantity{
+		`"1536 MiB"`:       bytequantity.FromMiB(1536),
+		`"8 GiB"`:          bytequantity.FromGiB(8),
+		`"8"`:              bytequantity.FromGiB(8),
+		`"2 TiB"`:          bytequantity.FromTiB(2),
+		`{"Quantity":512}`: bytequantity.FromMiB(512),
+	} {
+		bq := bytequantity.ByteQuantity{}
+		h.Ok(t, json.Unmarshal([]byte(input), &bq))
+		h.Equals(t, expected, bq)
+	}
+
+	bq := bytequantity.ByteQuantity{}
+	h.Nok(t, json.Unmarshal([]byte(`"8 PiB"`), &bq))
+	h.Nok(t, json.Unmarshal([]byte(`true`), &bq))
+}
+
+func TestMarshalJSON_RoundTrip(t *testing.T) {
+	for _, quantity := range []uint64{1, 1023, 1024, 1025, 1048576, 1049600} {
+		bq := bytequantity.FromMiB(quantity)
+		out, err := json.Marshal(bq)
+		h.Ok(t, err)
+		parsed := bytequantity.ByteQuantity{}
+		h.Ok(t, json.Unmarshal(out, &parsed))
+		h.Equals(t, bq, parsed)
+	}
+}
@@ -39,28 +39,54 @@ func New(binaryName string, shortUsage string, longUsage, examples string, run r
 		Run:     run,
 	}
 	return CommandLineInterface{
-		Command:     cmd,
-		Flags:       map[string]interface{}{},
-		nilDefaults: map[string]bool{},
-		rangeFlags:  map[string]bool{},
-		validators:  map[string]validator{},
-		processors:  map[string]processor{},
-		suiteFlags:  pflag.NewFlagSet("suite", pflag.ExitOnError),
+		Command:         cmd,
+		Flags:           map[string]interface{}{},
+		nilDefaults:     map[string]bool{},
+		rangeFlags:      map[string]bool{},
+		exactRangeFlags: map[string]bool{},
+		validators:      map[string]validator{},
+		processors:      map[string]processor{},
+		suiteFlags:      pflag.NewFlagSet("suite", pflag.ExitOnError),
 	}
 }
 
+// SubCommand registers a subcommand which accepts the same filter, suite, and config flags as the root command
+// The run func is executed with the positional args instead of the root command's run func when the subcommand is specified.
+func (cl *CommandLineInterface) SubCommand(use string, shortUsage string, args cobra.PositionalArgs, run runFunc) {
+	cl.Command.CompletionOptions.DisableDefaultCmd = true
+	cl.Command.AddCommand(&cobra.Command{
+		Use:   use,
+		Short: shortUsage,
+		Args:  args,
+		Run:   run,
+	})
+}
+
 // ParseFlags will parse flags registered in this instance of CLI from os.Args.
 func (cl *CommandLineInterface) ParseFlags() (map[string]interface{}, error) {
+	if len(os.Args) == 0 {
+		return cl.ParseFlagsFromArgs([]string{})
+	}
+	return cl.ParseFlagsFromArgs(os.Args[1:])
+}
+
+// ParseFlagsFromArgs will parse flags registered in this instance of CLI from the passed in args
+// The args should not include the binary name.
+func (cl *CommandLineInterface) ParseFlagsFromArgs(args []string) (map[string]interface{}, error) {
 	cl.setUsageTemplate()
+	// Filter flags are local to the root command, so they are shared with subcommands to be accepted after the subcommand name
+	for _, subCommand := range cl.Command.Commands() {
+		subCommand.Flags().AddFlagSet(cl.Command.LocalNonPersistentFlags())
+	}
 	// Remove Suite Flags so that args only include Config and Filter Flags
-	cl.Command.SetArgs(removeIntersectingArgs(cl.suiteFlags))
+	cl.Command.SetArgs(removeIntersectingArgs(cl.suiteFlags, args))
 	// This parses Config and Filter flags only
 	if err := cl.Command.Execute(); err != nil {
 		return nil, err
 	}
 
 	// Remove Config and Filter flags so that only suite flags are parsed
-	if err := cl.suiteFlags.Parse(removeIntersectingArgs(cl.Command.Flags())); err != nil {
+	if err := cl.suiteFlags.Parse(removeIntersectingArgs(cl.Command.Flags(), args)); err != nil {
 		return nil, err
 	}
 
@@ -90,6 +116,19 @@ func (cl *CommandLineInterface) ParseAndValidateFlags() (map[string]interface{},
 	return flags, nil
 }
 
+// ParseAndValidateFlagsFromArgs will parse flags registered in this instance of CLI from the passed in args
+// and then perform validation.
+func (cl *CommandLineInterface) ParseAndValidateFlagsFromArgs(args []string) (map[string]interface{}, error) {
+	flags, err := cl.ParseFlagsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := cl.ValidateFlags(); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
 // ProcessFlags iterates through any registered processors and executes them
 // Processors are executed before validators.
 func (cl *CommandLineInterface) ProcessFlags() error {
@@ -121,23 +160,29 @@ func (cl *CommandLineInterface) ValidateFlags() error {
 	return nil
 }
 
-func removeIntersectingArgs(flagSet *pflag.FlagSet) []string {
+func removeIntersectingArgs(flagSet *pflag.FlagSet, args []string) []string {
 	newArgs := []string{}
 	skipNext := false
-	for i, arg := range os.Args {
+	for i, arg := range args {
 		if skipNext {
 			skipNext = false
 			continue
 		}
 		arg = strings.Split(arg, "=")[0]
-		longFlag := strings.Replace(arg, "--", "", 1)
-		if flagSet.Lookup(longFlag) != nil || shorthandLookup(flagSet, arg) != nil {
-			if len(os.Args) > i+1 && os.Args[i+1][0] != '-' {
+		// positional args and flag values (i.e. --any-of "vcpus=2") should not be mistaken for flags
+		flag := shorthandLookup(flagSet, arg)
+		if strings.HasPrefix(arg, "--") {
+			flag = flagSet.Lookup(strings.Replace(arg, "--", "", 1))
+		}
+		if flag != nil {
+			// flags with an optional value (i.e. --flexible) only accept a value with an equal sign
+			hasOptionalValue := flag.NoOptDefVal != "" && flag.Value.Type() != "bool"
+			if !hasOptionalValue && !strings.Contains(args[i], "=") && len(args) > i+1 && (len(args[i+1]) == 0 || args[i+1][0] != '-') {
 				skipNext = true
 			}
 			continue
 		}
-		newArgs = append(newArgs, os.Args[i])
+		newArgs = append(newArgs, args[i])
 	}
 	return newArgs
 }
@@ -163,6 +208,9 @@ func (cl *CommandLineInterface) setUsageTemplate() {
 	cl.Command.SetUsageTemplate(transformedUsage)
 	cl.suiteFlags.Usage = func() {}
 	cl.Command.Flags().Usage = func() {}
+	for _, subCommand := range cl.Command.Commands() {
+		subCommand.Flags().Usage = func() {}
+	}
 }
 
 // SetUntouchedFlagValuesToNil iterates through all flags and sets their value to nil if they were not specifically set by the user
@@ -219,6 +267,7 @@ func (cl *CommandLineInterface) SetUntouchedFlagValuesToNil() error {
 }
 
 // ProcessRangeFilterFlags sets min and max to the appropriate 0 or max bounds based on the 3-tuple that a user specifies for base flag, min, and/or max.
+// The range filter of the base flag has no upper bound when only the min flag is set, and a range expression passed to the base flag is used as is.
 func (cl *CommandLineInterface) ProcessRangeFilterFlags() error {
 	for flagName := range cl.rangeFlags {
 		rangeHelperMin := fmt.Sprintf("%s-%s", flagName, "min")
@@ -227,12 +276,16 @@ func (cl *CommandLineInterface) ProcessRangeFilterFlags() error {
 			if cl.Flags[rangeHelperMin] != nil || cl.Flags[rangeHelperMax] != nil {
 				return fmt.Errorf("error: --%s and --%s cannot be set when using --%s", rangeHelperMin, rangeHelperMax, flagName)
 			}
+			if isRangeFilter(cl.Flags[flagName]) || cl.exactRangeFlags[flagName] {
+				continue
+			}
 			cl.Flags[rangeHelperMin] = cl.Flags[flagName]
 			cl.Flags[rangeHelperMax] = cl.Flags[flagName]
 		}
 		if cl.Flags[rangeHelperMin] == nil && cl.Flags[rangeHelperMax] == nil {
 			continue
 		}
+		hasUpperBound := cl.Flags[rangeHelperMax] != nil
 
 		if cl.Flags[rangeHelperMin] == nil {
 			switch cl.Flags[rangeHelperMax].(type) {
@@ -264,26 +317,31 @@ func (cl *CommandLineInterface) ProcessRangeFilterFlags() error {
 
 		switch cl.Flags[rangeHelperMin].(type) {
 		case *int:
-			cl.Flags[flagName] = &selector.IntRangeFilter{
-				LowerBound: *cl.IntMe(cl.Flags[rangeHelperMin]),
-				UpperBound: *cl.IntMe(cl.Flags[rangeHelperMax]),
-			}
+			cl.Flags[flagName] = rangeFromFlags(*cl.IntMe(cl.Flags[rangeHelperMin]), *cl.IntMe(cl.Flags[rangeHelperMax]), hasUpperBound)
 		case *int32:
-			cl.Flags[flagName] = &selector.Int32RangeFilter{
-				LowerBound: *cl.Int32Me(cl.Flags[rangeHelperMin]),
-				UpperBound: *cl.Int32Me(cl.Flags[rangeHelperMax]),
-			}
+			cl.Flags[flagName] = rangeFromFlags(*cl.Int32Me(cl.Flags[rangeHelperMin]), *cl.Int32Me(cl.Flags[rangeHelperMax]), hasUpperBound)
 		case *bytequantity.ByteQuantity:
-			cl.Flags[flagName] = &selector.ByteQuantityRangeFilter{
-				LowerBound: *cl.ByteQuantityMe(cl.Flags[rangeHelperMin]),
-				UpperBound: *cl.ByteQuantityMe(cl.Flags[rangeHelperMax]),
-			}
+			cl.Flags[flagName] = rangeFromFlags(*cl.ByteQuantityMe(cl.Flags[rangeHelperMin]), *cl.ByteQuantityMe(cl.Flags[rangeHelperMax]), hasUpperBound)
 		case *float64:
-			cl.Flags[flagName] = &selector.Float64RangeFilter{
-				LowerBound: *cl.Float64Me(cl.Flags[rangeHelperMin]),
-				UpperBound: *cl.Float64Me(cl.Flags[rangeHelperMax]),
-			}
+			cl.Flags[flagName] = rangeFromFlags(*cl.Float64Me(cl.Flags[rangeHelperMin]), *cl.Float64Me(cl.Flags[rangeHelperMax]), hasUpperBound)
 		}
 	}
 	return nil
 }
+
+// isRangeFilter returns true if the flag value is a range filter parsed from a range expression
+func isRangeFilter(flagValue interface{}) bool {
+	switch flagValue.(type) {
+	case *selector.Range[int], *selector.Range[int32], *selector.Range[bytequantity.ByteQuantity], *selector.Range[float64]:
+		return true
+	}
+	return false
+}
+
+// rangeFromFlags returns the range of a min and max flag pair, which has no upper bound when the max flag was not set
+func rangeFromFlags[T selector.RangeValue](lowerBound T, upperBound T, hasUpperBound bool) interface{} {
+	if !hasUpperBound {
+		return selector.RangeAtLeast(lowerBound)
+	}
+	return selector.NewRange(lowerBound, upperBound)
+}
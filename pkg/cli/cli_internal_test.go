@@ -27,7 +27,7 @@ func TestRemoveIntersectingArgs(t *testing.T) {
 	flagSet := pflag.NewFlagSet("test-flag-set", pflag.ContinueOnError)
 	flagSet.Bool("test-bool", false, "test usage")
 	os.Args = []string{"ec2-instance-selector", "--test-bool", "--this-should-stay"}
-	newArgs := removeIntersectingArgs(flagSet)
+	newArgs := removeIntersectingArgs(flagSet, os.Args)
 	h.Assert(t, len(newArgs) == 2, "NewArgs should only include the bin name and one argument after removing intersections")
 }
 
@@ -35,7 +35,7 @@ func TestRemoveIntersectingArgs_NextArg(t *testing.T) {
 	flagSet := pflag.NewFlagSet("test-flag-set", pflag.ContinueOnError)
 	flagSet.String("test-str", "", "test usage")
 	os.Args = []string{"ec2-instance-selector", "--test-str", "somevalue", "--this-should-stay", "valuetostay"}
-	newArgs := removeIntersectingArgs(flagSet)
+	newArgs := removeIntersectingArgs(flagSet, os.Args)
 	h.Assert(t, len(newArgs) == 3, "NewArgs should only include the bin name and a flag + input after removing intersections")
 }
 
@@ -43,6 +43,23 @@ func TestRemoveIntersectingArgs_ShorthandArg(t *testing.T) {
 	flagSet := pflag.NewFlagSet("test-flag-set", pflag.ContinueOnError)
 	flagSet.StringP("test-str", "t", "", "test usage")
 	os.Args = []string{"ec2-instance-selector", "--test-str", "somevalue", "--this-should-stay", "valuetostay", "-t", "test"}
-	newArgs := removeIntersectingArgs(flagSet)
+	newArgs := removeIntersectingArgs(flagSet, os.Args)
 	h.Assert(t, len(newArgs) == 3, "NewArgs should only include the bin name and a flag + input after removing intersections")
 }
+
+func TestRemoveIntersectingArgs_FlagNameAsValue(t *testing.T) {
+	flagSet := pflag.NewFlagSet("test-flag-set", pflag.ContinueOnError)
+	flagSet.String("test-str", "", "test usage")
+	args := []string{"--other", "test-str=somevalue", "--test-str=value", "--this-should-stay"}
+	newArgs := removeIntersectingArgs(flagSet, args)
+	h.Equals(t, []string{"--other", "test-str=somevalue", "--this-should-stay"}, newArgs)
+}
+
+func TestRemoveIntersectingArgs_OptionalValue(t *testing.T) {
+	flagSet := pflag.NewFlagSet("test-flag-set", pflag.ContinueOnError)
+	flagSet.String("test-str", "", "test usage")
+	flagSet.Lookup("test-str").NoOptDefVal = "default"
+	args := []string{"--test-str", "positional", "--test-str=value", "--this-should-stay"}
+	newArgs := removeIntersectingArgs(flagSet, args)
+	h.Equals(t, []string{"positional", "--this-should-stay"}, newArgs)
+}
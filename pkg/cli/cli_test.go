@@ -17,6 +17,7 @@ import (
 	"math"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -140,6 +141,7 @@ func TestParseFlags_IntRange(t *testing.T) {
 	flagMinOutput = flags[flagMinArg].(*int)
 	flagMaxOutput = flags[flagMaxArg].(*int)
 	h.Assert(t, *flagMinOutput == 5 && *flagMaxOutput == maxInt, "Flag %s min should have been parsed from cmdline and max set to maxInt", flagArg)
+	h.Equals(t, selector.RangeAtLeast(5), flags[flagName])
 
 	// Max is set to a val and min is set to 0
 	cli = getTestCLI()
@@ -172,6 +174,146 @@ func TestParseFlags_IntRangeErr(t *testing.T) {
 	h.Nok(t, err)
 }
 
+func TestParseFlags_RangeExpression(t *testing.T) {
+	flagName := "test-flag"
+	flagArg := fmt.Sprintf("--%s", flagName)
+
+	cli := getTestCLI()
+	cli.IntMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "4-16"}
+	flags, err := cli.ParseFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange(4, 16), flags[flagName])
+	h.Assert(t, flags[flagName+"-min"] == nil && flags[flagName+"-max"] == nil, "Flag %s min and max should not be set by a range expression", flagArg)
+
+	cli = getTestCLI()
+	cli.Int32MinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg + "=8+"}
+	flags, err = cli.ParseFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.RangeAtLeast[int32](8), flags[flagName])
+
+	cli = getTestCLI()
+	cli.Float64MinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "<0.5"}
+	flags, err = cli.ParseFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.RangeLessThan(0.5), flags[flagName])
+
+	cli = getTestCLI()
+	cli.ByteQuantityMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "16GiB..64GiB"}
+	flags, err = cli.ParseFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(16), bytequantity.FromGiB(64)), flags[flagName])
+}
+
+func TestParseFlags_RangeExpressionErr(t *testing.T) {
+	flagName := "test-flag"
+	flagArg := fmt.Sprintf("--%s", flagName)
+
+	cli := getTestCLI()
+	cli.Int32MinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "4-1x"}
+	_, err := cli.ParseFlags()
+	h.Nok(t, err)
+	h.Assert(t, strings.Contains(err.Error(), flagArg) && strings.Contains(err.Error(), `"1x" at position 3`), "Error should point at the offending token: %v", err)
+
+	cli = getTestCLI()
+	cli.Int32MinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, ">=4", flagArg + "-max", "8"}
+	_, err = cli.ParseFlags()
+	h.Nok(t, err)
+}
+
+func TestParseFlags_RatioRange(t *testing.T) {
+	flagName := "test-flag"
+	flagArg := fmt.Sprintf("--%s", flagName)
+
+	// a single ratio is kept as an exact ratio
+	cli := getTestCLI()
+	cli.RatioMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "1:2"}
+	flags, err := cli.ParseAndValidateFlags()
+	h.Ok(t, err)
+	h.Equals(t, cli.Float64Me(2.0), flags[flagName])
+	h.Assert(t, flags[flagName+"-min"] == nil && flags[flagName+"-max"] == nil, "Flag %s min and max should not be set by a single ratio", flagArg)
+
+	cli = getTestCLI()
+	cli.RatioMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "1:4-1:8"}
+	flags, err = cli.ParseAndValidateFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange(4.0, 8.0), flags[flagName])
+
+	cli = getTestCLI()
+	cli.RatioMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "2:1+"}
+	flags, err = cli.ParseAndValidateFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.RangeAtLeast(0.5), flags[flagName])
+
+	cli = getTestCLI()
+	cli.RatioMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg + "-min", "1:4", flagArg + "-max", "1:8"}
+	flags, err = cli.ParseAndValidateFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange(4.0, 8.0), flags[flagName])
+
+	cli = getTestCLI()
+	cli.RatioMinMaxRangeFlags(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg + "-min", "1:4"}
+	flags, err = cli.ParseAndValidateFlags()
+	h.Ok(t, err)
+	h.Equals(t, selector.RangeAtLeast(4.0), flags[flagName])
+}
+
+func TestParseFlags_RatioRangeErr(t *testing.T) {
+	flagName := "test-flag"
+	flagArg := fmt.Sprintf("--%s", flagName)
+
+	for _, args := range [][]string{
+		{flagArg, "1:4-x"},
+		{flagArg, "4"},
+		{flagArg + "-min", "1:x"},
+		{flagArg + "-min", "1:8", flagArg + "-max", "1:4"},
+		{flagArg, "1:4", flagArg + "-max", "1:8"},
+	} {
+		cli := getTestCLI()
+		cli.RatioMinMaxRangeFlags(flagName, nil, nil, "Test")
+		os.Args = append([]string{"ec2-instance-selector"}, args...)
+		_, err := cli.ParseAndValidateFlags()
+		h.Nok(t, err)
+	}
+}
+
+func TestParseFlags_InstanceSizeRange(t *testing.T) {
+	flagName := "test-flag"
+	flagArg := fmt.Sprintf("--%s", flagName)
+
+	for size, expected := range map[string]*selector.Range[float64]{
+		"xlarge":         selector.NewRange(1.0, 1.0),
+		"large..4xlarge": selector.NewRange(0.5, 4.0),
+		"large-2xlarge":  selector.NewRange(0.5, 2.0),
+		"8xlarge+":       selector.RangeAtLeast(8.0),
+		"<xlarge":        selector.RangeLessThan(1.0),
+	} {
+		cli := getTestCLI()
+		cli.InstanceSizeRangeFlag(flagName, nil, nil, "Test")
+		os.Args = []string{"ec2-instance-selector", flagArg, size}
+		flags, err := cli.ParseFlags()
+		h.Ok(t, err)
+		h.Equals(t, expected, flags[flagName])
+	}
+
+	cli := getTestCLI()
+	cli.InstanceSizeRangeFlag(flagName, nil, nil, "Test")
+	os.Args = []string{"ec2-instance-selector", flagArg, "large..huge"}
+	_, err := cli.ParseFlags()
+	h.Nok(t, err)
+	h.Assert(t, strings.Contains(err.Error(), `"huge"`), "Error should point at the offending size: %v", err)
+}
+
 func TestParseFlags_ByteQuantityRange(t *testing.T) {
 	flagName := "test-flag"
 	flagMinArg := fmt.Sprintf("%s-%s", flagName, "min")
@@ -218,7 +360,7 @@ func TestParseFlags_ByteQuantityRange(t *testing.T) {
 	flagMaxOutput = flags[flagMaxArg].(*bytequantity.ByteQuantity)
 	h.Assert(t, flagMinOutput.GiB() == 10.0 && flagMaxOutput.GiB() == 500.0, "Flag %s max and min should have been parsed from cmdline", flagArg)
 	flagType := reflect.TypeOf(flags[flagName])
-	bqRangeFilterType := reflect.TypeOf(&selector.ByteQuantityRangeFilter{})
+	bqRangeFilterType := reflect.TypeOf(&selector.Range[bytequantity.ByteQuantity]{})
 	h.Assert(t, flagType == bqRangeFilterType, "%s should be of type %v, instead got %v", flagArg, bqRangeFilterType, flagType)
 }
 
@@ -338,6 +480,63 @@ func TestParseFlags_SuiteFlags(t *testing.T) {
 	h.Assert(t, *flagOutput == true, "Suite Flag %s should have been parsed", flagArg)
 }
 
+func TestParseFlags_SuiteStringArrayFlag(t *testing.T) {
+	cli := getTestCLI()
+	flagName := "test-array"
+	cli.SuiteStringArrayFlag(flagName, nil, nil, "Test Suite Flag")
+	cli.IntFlag("vcpus", nil, nil, "Test Flag")
+	os.Args = []string{"ec2-instance-selector", "--vcpus", "2", "--test-array", "vcpus=4,gpus=1", "--test-array=memory=4"}
+	flags, err := cli.ParseFlags()
+	h.Ok(t, err)
+	h.Equals(t, []string{"vcpus=4,gpus=1", "memory=4"}, *flags[flagName].(*[]string))
+	h.Equals(t, 2, *flags["vcpus"].(*int))
+}
+
+func TestParseFlagsFromArgs(t *testing.T) {
+	cli := getTestCLI()
+	cli.IntFlag("test-int", nil, nil, "Test Flag")
+	cli.SuiteBoolFlag("test-suite", nil, nil, "Test Suite Flag")
+	os.Args = []string{"ec2-instance-selector", "--test-int", "1"}
+	flags, err := cli.ParseFlagsFromArgs([]string{"--test-int", "5", "--test-suite"})
+	h.Ok(t, err)
+	h.Equals(t, 5, *flags["test-int"].(*int))
+	h.Assert(t, *flags["test-suite"].(*bool), "Suite flag should have been parsed from args")
+}
+
+func TestParseFlagsFromArgs_SubCommand(t *testing.T) {
+	cli := getTestCLI()
+	cli.IntFlag("test-int", nil, nil, "Test Flag")
+	cli.SuiteBoolFlag("test-suite", nil, nil, "Test Suite Flag")
+	cli.ConfigBoolFlag("test-config", nil, nil, "Test Config Flag")
+	var subCommandArgs []string
+	cli.SubCommand("test-sub <arg>", "Test Sub Command", cobra.ExactArgs(1), func(cmd *cobra.Command, args []string) {
+		subCommandArgs = args
+	})
+	flags, err := cli.ParseFlagsFromArgs([]string{"--test-int", "5", "test-sub", "arg1", "--test-suite", "--test-config"})
+	h.Ok(t, err)
+	h.Equals(t, []string{"arg1"}, subCommandArgs)
+	h.Equals(t, 5, *flags["test-int"].(*int))
+	h.Assert(t, *flags["test-suite"].(*bool), "Suite flag should have been parsed from args")
+	h.Assert(t, *flags["test-config"].(*bool), "Config flag should have been parsed from args")
+}
+
+func TestParseFlagsFromArgs_SubCommandErr(t *testing.T) {
+	cli := getTestCLI()
+	cli.Command.SilenceErrors = true
+	cli.Command.SilenceUsage = true
+	cli.IntFlag("test-int", nil, nil, "Test Flag")
+	cli.SubCommand("test-sub <arg>", "Test Sub Command", cobra.ExactArgs(1), func(cmd *cobra.Command, args []string) {})
+	_, err := cli.ParseFlagsFromArgs([]string{"test-sub", "--test-int", "5"})
+	h.Nok(t, err)
+}
+
+func TestParseAndValidateFlagsFromArgs_Err(t *testing.T) {
+	cli := getTestCLI()
+	cli.StringOptionsFlag("test-opts", nil, nil, "Test Flag", []string{"a", "b"})
+	_, err := cli.ParseAndValidateFlagsFromArgs([]string{"--test-opts", "c"})
+	h.Nok(t, err)
+}
+
 func TestParseFlags_ConfigFlags(t *testing.T) {
 	cli := getTestCLI()
 	flagName := "test-flag"
@@ -444,7 +643,7 @@ func TestParseAndValidateFlags(t *testing.T) {
 	flags, err := cli.ParseAndValidateFlags()
 	h.Ok(t, err)
 	flagType := reflect.TypeOf(flags[flagName])
-	intRangeFilterType := reflect.TypeOf(&selector.IntRangeFilter{})
+	intRangeFilterType := reflect.TypeOf(&selector.Range[int]{})
 	h.Assert(t, flagType == intRangeFilterType, "%s should be of type %v, instead got %v", flagArg, intRangeFilterType, flagType)
 }
 
@@ -468,6 +667,25 @@ func TestParseAndValidateRegexFlag(t *testing.T) {
 	h.Nok(t, err)
 }
 
+func TestParseAndValidateExpressionFlag(t *testing.T) {
+	flagName := "test-expression-flag"
+	flagArg := fmt.Sprintf("--%s", flagName)
+
+	cli := getTestCLI()
+	cli.ExpressionFlag(flagName, nil, nil, "Test with validation")
+	os.Args = []string{"ec2-instance-selector", flagArg, "VCpuInfo.DefaultVCpus >= 4 && TotalGpus == 0"}
+	flags, err := cli.ParseAndValidateFlags()
+	h.Ok(t, err)
+	h.Assert(t, len(flags) == 1, "1 flag should have been processed")
+	h.Equals(t, "VCpuInfo.DefaultVCpus >= 4 && TotalGpus == 0", cli.ExpressionMe(flags[flagName]).String())
+
+	cli = getTestCLI()
+	cli.ExpressionFlag(flagName, nil, nil, "Test with validation")
+	os.Args = []string{"ec2-instance-selector", flagArg, "VCpuInfo.Unknown >= 4"}
+	_, err = cli.ParseAndValidateFlags()
+	h.Nok(t, err)
+}
+
 func TestParseAndValidateByteQuantityFlag(t *testing.T) {
 	flagName := "test-bq-flag"
 	flagArg := fmt.Sprintf("--%s", flagName)
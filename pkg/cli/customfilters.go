@@ -0,0 +1,71 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+)
+
+// CustomFilterFlag is a custom selector filter with the definition of the flag which sets its value.
+type CustomFilterFlag struct {
+	// Name is the name of the flag and of the custom filter in the selector's FilterRegistry
+	Name string
+
+	// Flag registers the flag with the name on the CommandLineInterface
+	// Example: func(cl *cli.CommandLineInterface, name string) { cl.BoolFlag(name, nil, nil, "Description") }
+	Flag func(cl *CommandLineInterface, name string)
+
+	// Filter is evaluated against each instance type with the parsed flag value when the flag is set
+	Filter selector.CustomFilter
+}
+
+// CustomFilterFlag registers the flag of a custom filter so that it is parsed with the other flags.
+// The filter is registered on a selector's FilterRegistry with RegisterCustomFilters.
+func (cl *CommandLineInterface) CustomFilterFlag(customFilter CustomFilterFlag) error {
+	if customFilter.Name == "" || customFilter.Flag == nil || customFilter.Filter == nil {
+		return fmt.Errorf("a custom filter flag must have a name, flag, and filter")
+	}
+	if _, ok := cl.Flags[customFilter.Name]; ok {
+		return fmt.Errorf("flag %s is already registered", customFilter.Name)
+	}
+	customFilter.Flag(cl, customFilter.Name)
+	cl.customFilters = append(cl.customFilters, customFilter)
+	return nil
+}
+
+// RegisterCustomFilters registers the filters of the custom filter flags on the FilterRegistry.
+func (cl *CommandLineInterface) RegisterCustomFilters(registry *selector.FilterRegistry) error {
+	for _, customFilter := range cl.customFilters {
+		if err := registry.Register(customFilter.Name, customFilter.Filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CustomFiltersMe returns the Filters.CustomFilters value of the custom filter flags which are set
+// If no custom filter flags are set then nil is returned.
+func (cl *CommandLineInterface) CustomFiltersMe(flags map[string]interface{}) *map[string]interface{} {
+	customFilters := map[string]interface{}{}
+	for _, customFilter := range cl.customFilters {
+		if value, ok := flags[customFilter.Name]; ok && value != nil {
+			customFilters[customFilter.Name] = value
+		}
+	}
+	if len(customFilters) == 0 {
+		return nil
+	}
+	return &customFilters
+}
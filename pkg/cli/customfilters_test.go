@@ -0,0 +1,75 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/cli"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func getTestCustomFilterFlag() cli.CustomFilterFlag {
+	return cli.CustomFilterFlag{
+		Name: "test-custom-filter",
+		Flag: func(cl *cli.CommandLineInterface, name string) {
+			cl.BoolFlag(name, nil, nil, "Test Custom Filter")
+		},
+		Filter: selector.CustomFilterFn(func(instanceTypeInfo *instancetypes.Details, filterValue interface{}) (bool, error) {
+			return *filterValue.(*bool), nil
+		}),
+	}
+}
+
+// Tests
+
+func TestCustomFilterFlag(t *testing.T) {
+	cli := getTestCLI()
+	h.Ok(t, cli.CustomFilterFlag(getTestCustomFilterFlag()))
+	_, ok := cli.Flags["test-custom-filter"]
+	h.Assert(t, ok, "Should contain test-custom-filter flag")
+	h.Nok(t, cli.CustomFilterFlag(getTestCustomFilterFlag()))
+
+	invalidCustomFilter := getTestCustomFilterFlag()
+	invalidCustomFilter.Filter = nil
+	h.Nok(t, cli.CustomFilterFlag(invalidCustomFilter))
+}
+
+func TestRegisterCustomFilters(t *testing.T) {
+	cli := getTestCLI()
+	h.Ok(t, cli.CustomFilterFlag(getTestCustomFilterFlag()))
+	registry := selector.NewFilterRegistry()
+	h.Ok(t, cli.RegisterCustomFilters(&registry))
+	h.Equals(t, []string{"test-custom-filter"}, registry.Names())
+	h.Nok(t, cli.RegisterCustomFilters(&registry))
+}
+
+func TestCustomFiltersMe(t *testing.T) {
+	cli := getTestCLI()
+	h.Ok(t, cli.CustomFilterFlag(getTestCustomFilterFlag()))
+	flags, err := cli.ParseFlagsFromArgs([]string{})
+	h.Ok(t, err)
+	h.Assert(t, cli.CustomFiltersMe(flags) == nil, "Should be nil when no custom filter flags are set")
+
+	cli = getTestCLI()
+	h.Ok(t, cli.CustomFilterFlag(getTestCustomFilterFlag()))
+	flags, err = cli.ParseFlagsFromArgs([]string{"--test-custom-filter"})
+	h.Ok(t, err)
+	customFilters := cli.CustomFiltersMe(flags)
+	h.Assert(t, customFilters != nil, "Should not be nil when a custom filter flag is set")
+	h.Equals(t, true, *(*customFilters)["test-custom-filter"].(*bool))
+}
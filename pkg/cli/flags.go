@@ -24,6 +24,8 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
 )
 
 const (
@@ -84,6 +86,16 @@ func (cl *CommandLineInterface) Float64MinMaxRangeFlags(name string, shorthand *
 	cl.Float64MinMaxRangeFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
 }
 
+// RatioMinMaxRangeFlags creates and registers a min, max, and helper flag each accepting a vcpus to memory ratio like 1:4.
+func (cl *CommandLineInterface) RatioMinMaxRangeFlags(name string, shorthand *string, defaultValue *string, description string) {
+	cl.RatioMinMaxRangeFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
+}
+
+// InstanceSizeRangeFlag creates and registers a flag accepting an instance size like xlarge or a range of sizes like xlarge..4xlarge.
+func (cl *CommandLineInterface) InstanceSizeRangeFlag(name string, shorthand *string, defaultValue *string, description string) {
+	cl.InstanceSizeRangeFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
+}
+
 // ByteQuantityFlag creates and registers a flag accepting a byte quantity like 512mb.
 func (cl *CommandLineInterface) ByteQuantityFlag(name string, shorthand *string, defaultValue *bytequantity.ByteQuantity, description string) {
 	cl.ByteQuantityFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
@@ -110,6 +122,11 @@ func (cl *CommandLineInterface) RegexFlag(name string, shorthand *string, defaul
 	cl.RegexFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
 }
 
+// ExpressionFlag creates and registers a flag accepting a string and validates that it is a valid filter expression.
+func (cl *CommandLineInterface) ExpressionFlag(name string, shorthand *string, defaultValue *string, description string) {
+	cl.ExpressionFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
+}
+
 // PathFlag creates and registers a flag accepting a string representing a path and validates that it is a valid path.
 func (cl *CommandLineInterface) PathFlag(name string, shorthand *string, defaultValue *string, description string) {
 	cl.PathFlagOnFlagSet(cl.Command.Flags(), name, shorthand, defaultValue, description)
@@ -167,12 +184,26 @@ func (cl *CommandLineInterface) SuiteBoolFlag(name string, shorthand *string, de
 	cl.BoolFlagOnFlagSet(cl.suiteFlags, name, shorthand, defaultValue, description)
 }
 
+// SuiteFloat64Flag creates and registers a flag accepting a float64 for aggregate filters.
+// Suite flags will be grouped in the middle of the output --help.
+func (cl *CommandLineInterface) SuiteFloat64Flag(name string, shorthand *string, defaultValue *float64, description string) {
+	cl.Float64FlagOnFlagSet(cl.suiteFlags, name, shorthand, defaultValue, description)
+}
+
 // SuiteStringFlag creates and registers a flag accepting a string for aggreagate filters.
 // Suite flags will be grouped in the middle of the output --help.
 func (cl *CommandLineInterface) SuiteStringFlag(name string, shorthand *string, defaultValue *string, description string, validationFn validator) {
 	cl.StringFlagOnFlagSet(cl.suiteFlags, name, shorthand, defaultValue, description, nil, validationFn)
 }
 
+// SuiteOptionalStringFlag creates and registers a flag accepting an optional string for aggregate filters.
+// The noOptDefValue is used when the flag is passed without a value (i.e. --flag instead of --flag=value).
+// Suite flags will be grouped in the middle of the output --help.
+func (cl *CommandLineInterface) SuiteOptionalStringFlag(name string, shorthand *string, defaultValue *string, noOptDefValue string, description string, validationFn validator) {
+	cl.StringFlagOnFlagSet(cl.suiteFlags, name, shorthand, defaultValue, description, nil, validationFn)
+	cl.suiteFlags.Lookup(name).NoOptDefVal = noOptDefValue
+}
+
 // SuiteStringOptionsFlag creates and registers a flag accepting a string and valid options for use in validation.
 // Suite flags will be grouped in the middle of the output --help.
 func (cl *CommandLineInterface) SuiteStringOptionsFlag(name string, shorthand *string, defaultValue *string, description string, validOpts []string) {
@@ -185,6 +216,13 @@ func (cl *CommandLineInterface) SuiteStringSliceFlag(name string, shorthand *str
 	cl.StringSliceFlagOnFlagSet(cl.suiteFlags, name, shorthand, defaultValue, description)
 }
 
+// SuiteStringArrayFlag creates and registers a repeatable flag accepting a string per occurrence.
+// Unlike SuiteStringSliceFlag, values are not split on commas.
+// Suite flags will be grouped in the middle of the output --help.
+func (cl *CommandLineInterface) SuiteStringArrayFlag(name string, shorthand *string, defaultValue []string, description string) {
+	cl.StringArrayFlagOnFlagSet(cl.suiteFlags, name, shorthand, defaultValue, description)
+}
+
 // BoolFlagOnFlagSet creates and registers a flag accepting a boolean for configuration purposes.
 func (cl *CommandLineInterface) BoolFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *bool, description string) {
 	if defaultValue == nil {
@@ -200,7 +238,11 @@ func (cl *CommandLineInterface) BoolFlagOnFlagSet(flagSet *pflag.FlagSet, name s
 
 // IntMinMaxRangeFlagOnFlagSet creates and registers a min, max, and helper flag each accepting an int.
 func (cl *CommandLineInterface) IntMinMaxRangeFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *int, description string) {
-	cl.IntFlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (sets --%s-min and -max to the same value)", description, name))
+	var stringDefaultValue *string
+	if defaultValue != nil {
+		stringDefaultValue = cl.StringMe(strconv.Itoa(*defaultValue))
+	}
+	rangeExpressionFlagOnFlagSet(cl, flagSet, name, shorthand, stringDefaultValue, description, "4-16, >=8, <8 or 8+", parseIntRangeValue)
 	cl.IntFlagOnFlagSet(flagSet, name+"-min", nil, nil, fmt.Sprintf("Minimum %s If --%s-max is not specified, the upper bound will be infinity", description, name))
 	cl.IntFlagOnFlagSet(flagSet, name+"-max", nil, nil, fmt.Sprintf("Maximum %s If --%s-min is not specified, the lower bound will be 0", description, name))
 	cl.validators[name] = func(val interface{}) error {
@@ -221,7 +263,11 @@ func (cl *CommandLineInterface) IntMinMaxRangeFlagOnFlagSet(flagSet *pflag.FlagS
 
 // Int32MinMaxRangeFlagOnFlagSet creates and registers a min, max, and helper flag each accepting an int.
 func (cl *CommandLineInterface) Int32MinMaxRangeFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *int32, description string) {
-	cl.Int32FlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (sets --%s-min and -max to the same value)", description, name))
+	var stringDefaultValue *string
+	if defaultValue != nil {
+		stringDefaultValue = cl.StringMe(strconv.FormatInt(int64(*defaultValue), 10))
+	}
+	rangeExpressionFlagOnFlagSet(cl, flagSet, name, shorthand, stringDefaultValue, description, "4-16, >=8, <8 or 8+", parseInt32RangeValue)
 	cl.Int32FlagOnFlagSet(flagSet, name+"-min", nil, nil, fmt.Sprintf("Minimum %s If --%s-max is not specified, the upper bound will be infinity", description, name))
 	cl.Int32FlagOnFlagSet(flagSet, name+"-max", nil, nil, fmt.Sprintf("Maximum %s If --%s-min is not specified, the lower bound will be 0", description, name))
 	cl.validators[name] = func(val interface{}) error {
@@ -242,7 +288,11 @@ func (cl *CommandLineInterface) Int32MinMaxRangeFlagOnFlagSet(flagSet *pflag.Fla
 
 // Float64MinMaxRangeFlagOnFlagSet creates and registers a min, max, and helper flag each accepting a float64.
 func (cl *CommandLineInterface) Float64MinMaxRangeFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *float64, description string) {
-	cl.Float64FlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (sets --%s-min and -max to the same value)", description, name))
+	var stringDefaultValue *string
+	if defaultValue != nil {
+		stringDefaultValue = cl.StringMe(strconv.FormatFloat(*defaultValue, 'f', -1, 64))
+	}
+	rangeExpressionFlagOnFlagSet(cl, flagSet, name, shorthand, stringDefaultValue, description, "0.1-0.5, >=0.1, <0.5 or 0.1+", parseFloat64RangeValue)
 	cl.Float64FlagOnFlagSet(flagSet, name+"-min", nil, nil, fmt.Sprintf("Minimum %s If --%s-max is not specified, the upper bound will be infinity", description, name))
 	cl.Float64FlagOnFlagSet(flagSet, name+"-max", nil, nil, fmt.Sprintf("Maximum %s If --%s-min is not specified, the lower bound will be 0", description, name))
 	cl.validators[name] = func(val interface{}) error {
@@ -263,7 +313,11 @@ func (cl *CommandLineInterface) Float64MinMaxRangeFlagOnFlagSet(flagSet *pflag.F
 
 // ByteQuantityMinMaxRangeFlagOnFlagSet creates and registers a min, max, and helper flag each accepting a ByteQuantity like 5mb or 12gb.
 func (cl *CommandLineInterface) ByteQuantityMinMaxRangeFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *bytequantity.ByteQuantity, description string) {
-	cl.ByteQuantityFlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (sets --%s-min and -max to the same value)", description, name))
+	var stringDefaultValue *string
+	if defaultValue != nil {
+		stringDefaultValue = cl.StringMe(defaultValue.StringGiB())
+	}
+	rangeExpressionFlagOnFlagSet(cl, flagSet, name, shorthand, stringDefaultValue, description, "16GiB-64GiB, >=8GiB, <64GiB or 16GiB..64GiB", parseByteQuantityRangeValue)
 	cl.ByteQuantityFlagOnFlagSet(flagSet, name+"-min", nil, nil, fmt.Sprintf("Minimum %s If --%s-max is not specified, the upper bound will be infinity", description, name))
 	cl.ByteQuantityFlagOnFlagSet(flagSet, name+"-max", nil, nil, fmt.Sprintf("Maximum %s If --%s-min is not specified, the lower bound will be 0", description, name))
 	cl.validators[name] = func(val interface{}) error {
@@ -282,6 +336,97 @@ func (cl *CommandLineInterface) ByteQuantityMinMaxRangeFlagOnFlagSet(flagSet *pf
 	cl.rangeFlags[name] = true
 }
 
+// rangeExpressionFlagOnFlagSet creates and registers the helper flag of a min and max range flag pair.
+// The helper flag accepts a single value, which sets the min and max to the same value, or a range expression parsed by ParseRange.
+func rangeExpressionFlagOnFlagSet[T selector.RangeValue](cl *CommandLineInterface, flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *string, description string, examples string, parseValue func(string) (T, error)) {
+	cl.StringFlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (sets --%s-min and -max to the same value, or accepts a range like %s)", description, name, examples),
+		rangeExpressionProcessor(cl, name, parseValue), nil)
+}
+
+// rangeExpressionProcessor returns a processor which replaces the flag value with a *T for a single value or a *selector.Range[T] for a range expression.
+func rangeExpressionProcessor[T selector.RangeValue](cl *CommandLineInterface, name string, parseValue func(string) (T, error)) processor {
+	invalidInputMsg := fmt.Sprintf("Invalid input for --%s.", name)
+	return func(val interface{}) error {
+		input, ok := val.(*string)
+		if !ok {
+			return nil
+		}
+		expression := strings.TrimSpace(*input)
+		if value, err := parseValue(expression); err == nil {
+			cl.Flags[name] = &value
+			return nil
+		}
+		valueRange, err := ParseRange(expression, parseValue)
+		if err != nil {
+			return fmt.Errorf("%s %w", invalidInputMsg, err)
+		}
+		cl.Flags[name] = valueRange
+		return nil
+	}
+}
+
+// RatioMinMaxRangeFlagOnFlagSet creates and registers a min, max, and helper flag each accepting a vcpus to memory ratio like 1:4.
+// Unlike the other range flags, a single ratio passed to the helper flag is kept as an exact ratio like RatioFlag,
+// while a ratio range or the min and max flags select a range of GiBs of memory per vcpu.
+func (cl *CommandLineInterface) RatioMinMaxRangeFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *string, description string) {
+	cl.StringFlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (accepts a range like 1:2-1:8, >=1:4, <1:8 or 1:4+)", description),
+		rangeExpressionProcessor(cl, name, parseRatioRangeValue), nil)
+	for _, helper := range []struct{ name, description string }{
+		{name + "-min", fmt.Sprintf("Minimum %s If --%s-max is not specified, the upper bound will be infinity", description, name)},
+		{name + "-max", fmt.Sprintf("Maximum %s If --%s-min is not specified, the lower bound will be 0", description, name)},
+	} {
+		helperName := helper.name
+		cl.StringFlagOnFlagSet(flagSet, helperName, nil, nil, helper.description, func(val interface{}) error {
+			input, ok := val.(*string)
+			if !ok {
+				return nil
+			}
+			ratio, err := parseRatioRangeValue(strings.TrimSpace(*input))
+			if err != nil {
+				return fmt.Errorf("Invalid input for --%s. %w", helperName, err)
+			}
+			cl.Flags[helperName] = &ratio
+			return nil
+		}, nil)
+	}
+	cl.validators[name] = func(val interface{}) error {
+		if cl.Flags[name+"-min"] == nil || cl.Flags[name+"-max"] == nil {
+			return nil
+		}
+		minArg := name + "-min"
+		maxArg := name + "-max"
+		if *cl.Flags[minArg].(*float64) > *cl.Flags[maxArg].(*float64) {
+			return fmt.Errorf("Invalid input for --%s and --%s. %s must be less than or equal to %s", minArg, maxArg, minArg, maxArg)
+		}
+		return nil
+	}
+	cl.rangeFlags[name] = true
+	cl.exactRangeFlags[name] = true
+}
+
+// InstanceSizeRangeFlagOnFlagSet creates and registers a flag accepting an instance size like xlarge or a range of sizes like xlarge..4xlarge.
+// The flag value is processed to a *selector.Range[float64] of sizes in multiples of an xlarge as parsed by instancename.ParseSize.
+func (cl *CommandLineInterface) InstanceSizeRangeFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *string, description string) {
+	sizeProcessor := func(val interface{}) error {
+		input, ok := val.(*string)
+		if !ok {
+			return nil
+		}
+		expression := strings.TrimSpace(*input)
+		if size, err := instancename.ParseSize(expression); err == nil {
+			cl.Flags[name] = selector.NewRange(size, size)
+			return nil
+		}
+		sizeRange, err := ParseRange(expression, instancename.ParseSize)
+		if err != nil {
+			return fmt.Errorf("Invalid input for --%s. %w", name, err)
+		}
+		cl.Flags[name] = sizeRange
+		return nil
+	}
+	cl.StringFlagOnFlagSet(flagSet, name, shorthand, defaultValue, fmt.Sprintf("%s (accepts a size or a range like xlarge..4xlarge, large-2xlarge, >=8xlarge or 4xlarge+)", description), sizeProcessor, nil)
+}
+
 // ByteQuantityFlagOnFlagSet creates and registers a flag accepting a ByteQuantity.
 func (cl *CommandLineInterface) ByteQuantityFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *bytequantity.ByteQuantity, description string) {
 	invalidInputMsg := fmt.Sprintf("Invalid input for --%s. A valid example is 16gb.", name)
@@ -408,6 +553,54 @@ func (cl *CommandLineInterface) StringSliceFlagOnFlagSet(flagSet *pflag.FlagSet,
 	cl.Flags[name] = flagSet.StringSlice(name, defaultValue, description)
 }
 
+// StringArrayFlagOnFlagSet creates and registers a repeatable flag accepting a string array.
+func (cl *CommandLineInterface) StringArrayFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue []string, description string) {
+	if defaultValue == nil {
+		cl.nilDefaults[name] = true
+		defaultValue = []string{}
+	}
+	if shorthand != nil {
+		cl.Flags[name] = flagSet.StringArrayP(name, string(*shorthand), defaultValue, description)
+		return
+	}
+	cl.Flags[name] = flagSet.StringArray(name, defaultValue, description)
+}
+
+// ExpressionFlagOnFlagSet creates and registers a flag accepting a filter expression evaluated against instance type details.
+func (cl *CommandLineInterface) ExpressionFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *string, description string) {
+	invalidInputMsg := fmt.Sprintf("Invalid expression input for --%s.", name)
+	expressionProcessor := func(val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		switch v := val.(type) {
+		case *string:
+			expressionVal, err := selector.ParseExpression(*v)
+			if err != nil {
+				return fmt.Errorf("%s %w", invalidInputMsg, err)
+			}
+			cl.Flags[name] = expressionVal
+		case *selector.Expression:
+			return nil
+		default:
+			return fmt.Errorf("%s Input type is unsupported", invalidInputMsg)
+		}
+		return nil
+	}
+	expressionValidator := func(val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		switch val.(type) {
+		case *selector.Expression:
+			return nil
+		default:
+			return fmt.Errorf("%s Processing failed", invalidInputMsg)
+		}
+	}
+	cl.StringFlagOnFlagSet(flagSet, name, shorthand, defaultValue, description, expressionProcessor, expressionValidator)
+}
+
 // RegexFlagOnFlagSet creates and registers a flag accepting a string slice of regular expressions.
 func (cl *CommandLineInterface) RegexFlagOnFlagSet(flagSet *pflag.FlagSet, name string, shorthand *string, defaultValue *string, description string) {
 	invalidInputMsg := fmt.Sprintf("Invalid regex input for --%s.", name)
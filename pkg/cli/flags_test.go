@@ -119,6 +119,57 @@ func TestStringSliceFlag(t *testing.T) {
 	}
 }
 
+func TestSuiteStringArrayFlag(t *testing.T) {
+	cli := getTestCLI()
+	flagName := "test-string-array"
+	cli.SuiteStringArrayFlag(flagName, cli.StringMe("t"), nil, "Test String Array")
+	_, ok := cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag")
+	h.Assert(t, ok, "Should contain %s flag", flagName)
+
+	cli = getTestCLI()
+	cli.SuiteStringArrayFlag(flagName, nil, []string{"def1", "def2"}, "Test String Array")
+	_, ok = cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag w/ no shorthand")
+	h.Assert(t, ok, "Should contain %s flag w/ no shorthand", flagName)
+}
+
+func TestSuiteFloat64Flag(t *testing.T) {
+	cli := getTestCLI()
+	flagName := "test-float64"
+	cli.SuiteFloat64Flag(flagName, cli.StringMe("t"), nil, "Test Float64")
+	_, ok := cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag")
+	h.Assert(t, ok, "Should contain %s flag", flagName)
+
+	cli = getTestCLI()
+	cli.SuiteFloat64Flag(flagName, nil, cli.Float64Me(1.5), "Test Float64")
+	_, ok = cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag w/ no shorthand")
+	h.Assert(t, ok, "Should contain %s flag w/ no shorthand", flagName)
+}
+
+func TestSuiteOptionalStringFlag(t *testing.T) {
+	cli := getTestCLI()
+	flagName := "test-optional-string"
+	cli.SuiteOptionalStringFlag(flagName, cli.StringMe("t"), nil, "default", "Test Optional String", nil)
+	_, ok := cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag")
+	h.Assert(t, ok, "Should contain %s flag", flagName)
+
+	cli = getTestCLI()
+	cli.SuiteOptionalStringFlag(flagName, nil, nil, "default", "Test Optional String", nil)
+	flags, err := cli.ParseFlagsFromArgs([]string{"--" + flagName})
+	h.Ok(t, err)
+	h.Equals(t, "default", *cli.StringMe(flags[flagName]))
+
+	cli = getTestCLI()
+	cli.SuiteOptionalStringFlag(flagName, nil, nil, "default", "Test Optional String", nil)
+	flags, err = cli.ParseFlagsFromArgs([]string{"--" + flagName + "=custom"})
+	h.Ok(t, err)
+	h.Equals(t, "custom", *cli.StringMe(flags[flagName]))
+}
+
 func TestRatioFlag(t *testing.T) {
 	cli := getTestCLI()
 	flagName := "test-ratio"
@@ -201,6 +252,21 @@ func TestRegexFlag(t *testing.T) {
 	}
 }
 
+func TestExpressionFlag(t *testing.T) {
+	cli := getTestCLI()
+	flagName := "test-expression"
+	cli.ExpressionFlag(flagName, cli.StringMe("t"), nil, "Test Expression")
+	_, ok := cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag")
+	h.Assert(t, ok, "Should contain %s flag", flagName)
+
+	cli = getTestCLI()
+	cli.ExpressionFlag(flagName, nil, nil, "Test Expression")
+	_, ok = cli.Flags[flagName]
+	h.Assert(t, len(cli.Flags) == 1, "Should contain 1 flag w/ no shorthand")
+	h.Assert(t, ok, "Should contain %s flag w/ no shorthand", flagName)
+}
+
 func TestFloat64MinMaxRangeFlags(t *testing.T) {
 	cli := getTestCLI()
 	flagName := "test-float64-min-max-range"
@@ -0,0 +1,165 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+)
+
+// RangeSyntaxHelp describes the range expressions accepted by ParseRange
+const RangeSyntaxHelp = "a range like 4-16, >=8, <0.5, 8+ or 16GiB..64GiB"
+
+// ParseRange parses a range expression using parseValue to parse each bound:
+//   - "4-16" and "4..16" include both bounds
+//   - "8+", ">=8" and "8.." include 8 and have no upper bound
+//   - ">8" excludes 8 and has no upper bound
+//   - "<=8" and "..8" include 8 and have no lower bound
+//   - "<8" excludes 8 and has no lower bound
+//
+// Errors name the token which could not be parsed and its position in the expression.
+func ParseRange[T selector.RangeValue](expression string, parseValue func(string) (T, error)) (*selector.Range[T], error) {
+	parseBound := func(offset int, token string) (*T, error) {
+		trimmed := strings.TrimSpace(token)
+		if trimmed == "" {
+			return nil, fmt.Errorf("missing bound at position %d of range %q, expected %s", offset+1, expression, RangeSyntaxHelp)
+		}
+		value, err := parseValue(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %q at position %d of range %q: %w", trimmed, offset+strings.Index(token, trimmed)+1, expression, err)
+		}
+		return &value, nil
+	}
+	for _, operator := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(expression, operator) {
+			continue
+		}
+		bound, err := parseBound(len(operator), expression[len(operator):])
+		if err != nil {
+			return nil, err
+		}
+		switch operator {
+		case ">=":
+			return &selector.Range[T]{LowerBound: bound}, nil
+		case ">":
+			return &selector.Range[T]{LowerBound: bound, LowerBoundExclusive: true}, nil
+		case "<=":
+			return &selector.Range[T]{UpperBound: bound}, nil
+		default:
+			return &selector.Range[T]{UpperBound: bound, UpperBoundExclusive: true}, nil
+		}
+	}
+	if strings.HasSuffix(expression, "+") {
+		bound, err := parseBound(0, strings.TrimSuffix(expression, "+"))
+		if err != nil {
+			return nil, err
+		}
+		return &selector.Range[T]{LowerBound: bound}, nil
+	}
+
+	separator := ".."
+	separatorIndex := strings.Index(expression, separator)
+	openBoundsAllowed := true
+	if separatorIndex < 0 {
+		separator = "-"
+		separatorIndex = rangeDashIndex(expression)
+		openBoundsAllowed = false
+	}
+	if separatorIndex < 0 {
+		if _, err := parseBound(0, expression); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%q is not a range, expected %s", expression, RangeSyntaxHelp)
+	}
+	lowerToken := expression[:separatorIndex]
+	upperOffset := separatorIndex + len(separator)
+	upperToken := expression[upperOffset:]
+	valueRange := &selector.Range[T]{}
+	var err error
+	if !openBoundsAllowed || strings.TrimSpace(lowerToken) != "" {
+		if valueRange.LowerBound, err = parseBound(0, lowerToken); err != nil {
+			return nil, err
+		}
+	}
+	if !openBoundsAllowed || strings.TrimSpace(upperToken) != "" {
+		if valueRange.UpperBound, err = parseBound(upperOffset, upperToken); err != nil {
+			return nil, err
+		}
+	}
+	if valueRange.LowerBound == nil && valueRange.UpperBound == nil {
+		return nil, fmt.Errorf("range %q must have a lower or upper bound", expression)
+	}
+	if valueRange.IsEmpty() {
+		return nil, fmt.Errorf("lower bound %q must not be greater than the upper bound %q in range %q",
+			strings.TrimSpace(lowerToken), strings.TrimSpace(upperToken), expression)
+	}
+	return valueRange, nil
+}
+
+// rangeDashIndex returns the index of the dash separating the bounds of a range, ignoring a leading negative sign
+// and the sign of a float exponent like 1e-3, or -1 if there is no separating dash.
+func rangeDashIndex(expression string) int {
+	for i := 1; i < len(expression); i++ {
+		if expression[i] != '-' {
+			continue
+		}
+		if previous := expression[i-1]; (previous == 'e' || previous == 'E') && i >= 2 && expression[i-2] >= '0' && expression[i-2] <= '9' {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+func parseIntRangeValue(value string) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("not an integer")
+	}
+	return parsed, nil
+}
+
+func parseInt32RangeValue(value string) (int32, error) {
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("not a 32-bit integer")
+	}
+	return int32(parsed), nil
+}
+
+func parseFloat64RangeValue(value string) (float64, error) {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("not a number")
+	}
+	return parsed, nil
+}
+
+// parseRatioRangeValue parses a vcpus to memory ratio like 1:4 to the GiBs of memory per vcpu
+func parseRatioRangeValue(value string) (float64, error) {
+	vcpus, memory, found := strings.Cut(value, ":")
+	vcpusVal, err1 := strconv.Atoi(strings.TrimSpace(vcpus))
+	memoryVal, err2 := strconv.Atoi(strings.TrimSpace(memory))
+	if !found || err1 != nil || err2 != nil || vcpusVal <= 0 || memoryVal < 0 {
+		return 0, fmt.Errorf("not a ratio of vcpus to GiBs of memory like 1:4")
+	}
+	return float64(memoryVal) / float64(vcpusVal), nil
+}
+
+func parseByteQuantityRangeValue(value string) (bytequantity.ByteQuantity, error) {
+	return bytequantity.ParseToByteQuantity(value)
+}
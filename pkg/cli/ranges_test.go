@@ -0,0 +1,81 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/cli"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func parseFloat(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+// Tests
+
+func TestParseRange(t *testing.T) {
+	for expression, expected := range map[string]*selector.Range[float64]{
+		"4-16":     selector.NewRange(4.0, 16.0),
+		"0.5-1.5":  selector.NewRange(0.5, 1.5),
+		"4..16":    selector.NewRange(4.0, 16.0),
+		"-2-2":     selector.NewRange(-2.0, 2.0),
+		"8+":       selector.RangeAtLeast(8.0),
+		"8..":      selector.RangeAtLeast(8.0),
+		">=8":      selector.RangeAtLeast(8.0),
+		">8":       selector.RangeGreaterThan(8.0),
+		"<=0.5":    selector.RangeAtMost(0.5),
+		"..0.5":    selector.RangeAtMost(0.5),
+		"<0.5":     selector.RangeLessThan(0.5),
+		"< 1e-3":   selector.RangeLessThan(0.001),
+		"1e-3-0.5": selector.NewRange(0.001, 0.5),
+	} {
+		parsed, err := cli.ParseRange(expression, parseFloat)
+		h.Ok(t, err)
+		h.Equals(t, expected, parsed)
+	}
+}
+
+func TestParseRange_ByteQuantity(t *testing.T) {
+	parsed, err := cli.ParseRange("16GiB..64GiB", bytequantity.ParseToByteQuantity)
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(16), bytequantity.FromGiB(64)), parsed)
+
+	parsed, err = cli.ParseRange(">= 512 MiB", bytequantity.ParseToByteQuantity)
+	h.Ok(t, err)
+	h.Equals(t, selector.RangeAtLeast(bytequantity.FromMiB(512)), parsed)
+}
+
+func TestParseRange_Errors(t *testing.T) {
+	for expression, offendingToken := range map[string]string{
+		"4-x":      `"x" at position 3`,
+		"four+":    `"four" at position 1`,
+		">=8..":    `"8.." at position 3`,
+		"4-":       "position 3",
+		"..":       "must have a lower or upper bound",
+		"16-4":     `lower bound "16" must not be greater than the upper bound "4"`,
+		"x":        `"x" at position 1`,
+		"1e-3-0.x": `"0.x" at position 6`,
+	} {
+		_, err := cli.ParseRange(expression, parseFloat)
+		h.Nok(t, err)
+		h.Assert(t, strings.Contains(err.Error(), offendingToken), "error for %q should point at %s: %v", expression, offendingToken, err)
+	}
+}
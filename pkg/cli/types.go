@@ -43,7 +43,7 @@ Filter Flags:
 {{.LocalNonPersistentFlags.FlagUsages | trimTrailingWhitespaces}}
 %s
 Global Flags:
-{{.PersistentFlags.FlagUsages | trimTrailingWhitespaces}}
+{{if .HasParent}}{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{else}}{{.PersistentFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}
 
 {{end}}`
 )
@@ -60,9 +60,12 @@ type CommandLineInterface struct {
 	Flags       map[string]interface{}
 	nilDefaults map[string]bool
 	rangeFlags  map[string]bool
-	validators  map[string]validator
-	processors  map[string]processor
-	suiteFlags  *pflag.FlagSet
+	// exactRangeFlags are range flags whose single value is kept as an exact value instead of setting the min and max
+	exactRangeFlags map[string]bool
+	validators      map[string]validator
+	processors      map[string]processor
+	suiteFlags      *pflag.FlagSet
+	customFilters   []CustomFilterFlag
 }
 
 // Float64Me takes an interface and returns a pointer to a float64 value
@@ -128,68 +131,84 @@ func (*CommandLineInterface) Int32Me(i interface{}) *int32 {
 	}
 }
 
-// IntRangeMe takes an interface and returns a pointer to an IntRangeFilter value
-// If the underlying interface kind is not IntRangeFilter or *IntRangeFilter then nil is returned.
-func (*CommandLineInterface) IntRangeMe(i interface{}) *selector.IntRangeFilter {
+// IntRangeMe takes an interface and returns a pointer to a Range[int] value
+// If the underlying interface kind is not Range[int], IntRangeFilter or a pointer to either then nil is returned.
+func (*CommandLineInterface) IntRangeMe(i interface{}) *selector.Range[int] {
 	if i == nil {
 		return nil
 	}
 	switch v := i.(type) {
-	case *selector.IntRangeFilter:
+	case *selector.Range[int]:
 		return v
-	case selector.IntRangeFilter:
+	case selector.Range[int]:
 		return &v
+	case *selector.IntRangeFilter:
+		return v.ToRange()
+	case selector.IntRangeFilter:
+		return v.ToRange()
 	default:
 		log.Printf("%s cannot be converted to an IntRange", i)
 		return nil
 	}
 }
 
-// Int32RangeMe takes an interface and returns a pointer to an Int32RangeFilter value
-// If the underlying interface kind is not Int32RangeFilter or *Int32RangeFilter then nil is returned.
-func (*CommandLineInterface) Int32RangeMe(i interface{}) *selector.Int32RangeFilter {
+// Int32RangeMe takes an interface and returns a pointer to a Range[int32] value
+// If the underlying interface kind is not Range[int32], Int32RangeFilter or a pointer to either then nil is returned.
+func (*CommandLineInterface) Int32RangeMe(i interface{}) *selector.Range[int32] {
 	if i == nil {
 		return nil
 	}
 	switch v := i.(type) {
-	case *selector.Int32RangeFilter:
+	case *selector.Range[int32]:
 		return v
-	case selector.Int32RangeFilter:
+	case selector.Range[int32]:
 		return &v
+	case *selector.Int32RangeFilter:
+		return v.ToRange()
+	case selector.Int32RangeFilter:
+		return v.ToRange()
 	default:
 		log.Printf("%s cannot be converted to an Int32Range", i)
 		return nil
 	}
 }
 
-// ByteQuantityRangeMe takes an interface and returns a pointer to a ByteQuantityRangeFilter value
-// If the underlying interface kind is not ByteQuantityRangeFilter or *ByteQuantityRangeFilter then nil is returned.
-func (*CommandLineInterface) ByteQuantityRangeMe(i interface{}) *selector.ByteQuantityRangeFilter {
+// ByteQuantityRangeMe takes an interface and returns a pointer to a Range[bytequantity.ByteQuantity] value
+// If the underlying interface kind is not Range[bytequantity.ByteQuantity], ByteQuantityRangeFilter or a pointer to either then nil is returned.
+func (*CommandLineInterface) ByteQuantityRangeMe(i interface{}) *selector.Range[bytequantity.ByteQuantity] {
 	if i == nil {
 		return nil
 	}
 	switch v := i.(type) {
-	case *selector.ByteQuantityRangeFilter:
+	case *selector.Range[bytequantity.ByteQuantity]:
 		return v
-	case selector.ByteQuantityRangeFilter:
+	case selector.Range[bytequantity.ByteQuantity]:
 		return &v
+	case *selector.ByteQuantityRangeFilter:
+		return v.ToRange()
+	case selector.ByteQuantityRangeFilter:
+		return v.ToRange()
 	default:
 		log.Printf("%s cannot be converted to a ByteQuantityRange", i)
 		return nil
 	}
 }
 
-// Float64RangeMe takes an interface and returns a pointer to a Float64RangeFilter value
-// If the underlying interface kind is not Float64RangeFilter or *Float64RangeFilter then nil is returned.
-func (*CommandLineInterface) Float64RangeMe(i interface{}) *selector.Float64RangeFilter {
+// Float64RangeMe takes an interface and returns a pointer to a Range[float64] value
+// If the underlying interface kind is not Range[float64], Float64RangeFilter or a pointer to either then nil is returned.
+func (*CommandLineInterface) Float64RangeMe(i interface{}) *selector.Range[float64] {
 	if i == nil {
 		return nil
 	}
 	switch v := i.(type) {
-	case *selector.Float64RangeFilter:
+	case *selector.Range[float64]:
 		return v
-	case selector.Float64RangeFilter:
+	case selector.Range[float64]:
 		return &v
+	case *selector.Float64RangeFilter:
+		return v.ToRange()
+	case selector.Float64RangeFilter:
+		return v.ToRange()
 	default:
 		log.Printf("%s cannot be converted to a Float64Range", i)
 		return nil
@@ -264,6 +283,21 @@ func (*CommandLineInterface) RegexMe(i interface{}) *regexp.Regexp {
 	}
 }
 
+// ExpressionMe takes an interface and returns a pointer to a selector.Expression
+// If the underlying interface kind is not *selector.Expression then nil is returned.
+func (*CommandLineInterface) ExpressionMe(i interface{}) *selector.Expression {
+	if i == nil {
+		return nil
+	}
+	switch v := i.(type) {
+	case *selector.Expression:
+		return v
+	default:
+		log.Printf("%s cannot be converted to an expression", i)
+		return nil
+	}
+}
+
 // ByteQuantityMe takes an interface and returns a pointer to a ByteQuantity
 // If the underlying interface kind is not bytequantity.ByteQuantity or *bytequantity.ByteQuantity then nil is returned.
 func (*CommandLineInterface) ByteQuantityMe(i interface{}) *bytequantity.ByteQuantity {
@@ -96,11 +96,13 @@ func TestFloat64Me(t *testing.T) {
 
 func TestIntRangeMe(t *testing.T) {
 	cli := getTestCLI()
-	intRangeVal := selector.IntRangeFilter{LowerBound: 1, UpperBound: 2}
+	intRangeVal := *selector.NewRange(1, 2)
 	val := cli.IntRangeMe(intRangeVal)
 	h.Assert(t, *val == intRangeVal, "Should return %s from passed in int range value", intRangeVal)
 	val = cli.IntRangeMe(&intRangeVal)
 	h.Assert(t, *val == intRangeVal, "Should return %s from passed in range pointer", intRangeVal)
+	val = cli.IntRangeMe(selector.IntRangeFilter{LowerBound: 1, UpperBound: 2})
+	h.Equals(t, &intRangeVal, val)
 	val = cli.IntRangeMe(true)
 	h.Assert(t, val == nil, "Should return nil from other data type passed in")
 	val = cli.IntRangeMe(nil)
@@ -110,11 +112,13 @@ func TestIntRangeMe(t *testing.T) {
 func TestByteQuantityRangeMe(t *testing.T) {
 	cli := getTestCLI()
 	bq1 := bytequantity.ByteQuantity{Quantity: 1}
-	bqRangeVal := selector.ByteQuantityRangeFilter{LowerBound: bq1, UpperBound: bq1}
+	bqRangeVal := *selector.NewRange(bq1, bq1)
 	val := cli.ByteQuantityRangeMe(bqRangeVal)
 	h.Assert(t, *val == bqRangeVal, "Should return %s from passed in byte quantity range value", bqRangeVal)
 	val = cli.ByteQuantityRangeMe(&bqRangeVal)
 	h.Assert(t, *val == bqRangeVal, "Should return %s from passed in range pointer", bqRangeVal)
+	val = cli.ByteQuantityRangeMe(&selector.ByteQuantityRangeFilter{LowerBound: bq1, UpperBound: bq1})
+	h.Equals(t, &bqRangeVal, val)
 	val = cli.ByteQuantityRangeMe(true)
 	h.Assert(t, val == nil, "Should return nil from other data type passed in")
 	val = cli.ByteQuantityRangeMe(nil)
@@ -135,9 +139,21 @@ func TestRegexMe(t *testing.T) {
 	h.Assert(t, val == nil, "Should return nil if nil is passed in")
 }
 
+func TestExpressionMe(t *testing.T) {
+	cli := getTestCLI()
+	expressionVal, err := selector.ParseExpression("TotalGpus == 1")
+	h.Ok(t, err)
+	val := cli.ExpressionMe(expressionVal)
+	h.Assert(t, val == expressionVal, "Should return %s from passed in expression pointer", expressionVal)
+	val = cli.ExpressionMe(true)
+	h.Assert(t, val == nil, "Should return nil from other data type passed in")
+	val = cli.ExpressionMe(nil)
+	h.Assert(t, val == nil, "Should return nil if nil is passed in")
+}
+
 func TestFloat64RangeMe(t *testing.T) {
 	cli := getTestCLI()
-	float64RangeVal := selector.Float64RangeFilter{LowerBound: 1.0, UpperBound: 2.1}
+	float64RangeVal := *selector.RangeAtLeast(1.0)
 	val := cli.Float64RangeMe(float64RangeVal)
 	h.Assert(t, *val == float64RangeVal, "Should return %s from passed in float64 range value", float64RangeVal)
 	val = cli.Float64RangeMe(&float64RangeVal)
@@ -26,12 +26,14 @@ import (
 )
 
 const (
-	productDescription = "Linux/UNIX (Amazon VPC)"
-	serviceCode        = "AmazonEC2"
+	serviceCode = "AmazonEC2"
 )
 
 var DefaultSpotDaysBack = 30
 
+// MaxSpotDaysBack is the number of days of spot price history retained by EC2.
+const MaxSpotDaysBack = 90
+
 // EC2Pricing is the public struct to interface with AWS pricing APIs.
 type EC2Pricing struct {
 	ODPricing   *OnDemandPricing
@@ -40,13 +42,16 @@ type EC2Pricing struct {
 }
 
 // EC2PricingIface is the EC2Pricing interface mainly used to mock out ec2pricing during testing.
+// The zero value of PricingOptions prices linux instances with shared tenancy.
 type EC2PricingIface interface {
-	GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType) (float64, error)
-	GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanceType ec2types.InstanceType, availabilityZones []string, days int) (float64, error)
-	RefreshOnDemandCache(ctx context.Context) error
-	RefreshSpotCache(ctx context.Context, days int) error
-	OnDemandCacheCount() int
-	SpotCacheCount() int
+	GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (float64, error)
+	GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, availabilityZones []string, days int) (float64, error)
+	GetSpotInstanceTypeNDayStats(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, availabilityZones []string, days int) (map[string]SpotPriceStats, error)
+	GetReservedInstanceTypeCosts(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (ReservedPrices, error)
+	RefreshOnDemandCache(ctx context.Context, options PricingOptions) error
+	RefreshSpotCache(ctx context.Context, options PricingOptions, days int) error
+	OnDemandCacheCount(options PricingOptions) int
+	SpotCacheCount(options PricingOptions, days int) int
 	Save() error
 	SetLogger(*log.Logger)
 }
@@ -87,26 +92,26 @@ func (p *EC2Pricing) SetLogger(logger *log.Logger) {
 	p.SpotPricing.SetLogger(logger)
 }
 
-// OnDemandCacheCount returns the number of items in the OD cache.
-func (p *EC2Pricing) OnDemandCacheCount() int {
-	return p.ODPricing.Count()
+// OnDemandCacheCount returns the number of items in the OD cache priced with the options.
+func (p *EC2Pricing) OnDemandCacheCount(options PricingOptions) int {
+	return p.ODPricing.CountWith(options)
 }
 
-// SpotCacheCount returns the number of items in the spot cache.
-func (p *EC2Pricing) SpotCacheCount() int {
-	return p.SpotPricing.Count()
+// SpotCacheCount returns the number of items in the spot cache for the operating system of the options and the days of spot price history.
+func (p *EC2Pricing) SpotCacheCount(options PricingOptions, days int) int {
+	return p.SpotPricing.CountWith(options, days)
 }
 
 // GetSpotInstanceTypeNDayAvgCost retrieves the spot price history for a given AZ from the past N days and averages the price
 // Passing an empty list for availabilityZones will retrieve avg cost for all AZs in the current AWSSession's region.
-func (p *EC2Pricing) GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanceType ec2types.InstanceType, availabilityZones []string, days int) (float64, error) {
+func (p *EC2Pricing) GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, availabilityZones []string, days int) (float64, error) {
 	if len(availabilityZones) == 0 {
-		return p.SpotPricing.Get(ctx, instanceType, "", days)
+		return p.SpotPricing.Get(ctx, instanceType, options, "", days)
 	}
 	costs := []float64{}
 	var errs error
 	for _, zone := range availabilityZones {
-		cost, err := p.SpotPricing.Get(ctx, instanceType, zone, days)
+		cost, err := p.SpotPricing.Get(ctx, instanceType, options, zone, days)
 		if err != nil {
 			errs = multierr.Append(errs, err)
 		}
@@ -119,19 +124,51 @@ func (p *EC2Pricing) GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanc
 	return costs[0], nil
 }
 
-// GetOnDemandInstanceTypeCost retrieves the on-demand hourly cost for the specified instance type.
-func (p *EC2Pricing) GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType) (float64, error) {
-	return p.ODPricing.Get(ctx, instanceType)
+// GetSpotInstanceTypeNDayStats retrieves the spot price history for the given AZs from the past N days and returns its statistics by AZ.
+// Passing an empty list for availabilityZones will retrieve the statistics of all AZs in the current AWSSession's region.
+func (p *EC2Pricing) GetSpotInstanceTypeNDayStats(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, availabilityZones []string, days int) (map[string]SpotPriceStats, error) {
+	if len(availabilityZones) == 0 {
+		return p.SpotPricing.GetStats(ctx, instanceType, options, "", days)
+	}
+	stats := map[string]SpotPriceStats{}
+	var errs error
+	for _, zone := range availabilityZones {
+		zonalStats, err := p.SpotPricing.GetStats(ctx, instanceType, options, zone, days)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		}
+		for zone, zoneStats := range zonalStats {
+			stats[zone] = zoneStats
+		}
+	}
+
+	if len(multierr.Errors(errs)) == len(availabilityZones) {
+		return nil, errs
+	}
+	return stats, nil
+}
+
+// GetOnDemandInstanceTypeCost retrieves the on-demand hourly cost for the specified instance type priced with the options.
+func (p *EC2Pricing) GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (float64, error) {
+	return p.ODPricing.Get(ctx, instanceType, options)
+}
+
+// GetReservedInstanceTypeCosts retrieves the reserved instance prices by pricing model for the specified instance type priced with the options.
+// The reserved prices are stored in the on-demand cache since they are retrieved with the on-demand prices.
+func (p *EC2Pricing) GetReservedInstanceTypeCosts(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (ReservedPrices, error) {
+	return p.ODPricing.GetReserved(ctx, instanceType, options)
 }
 
-// RefreshOnDemandCache makes a bulk request to the pricing api to retrieve all instance type pricing and stores them in a local cache.
-func (p *EC2Pricing) RefreshOnDemandCache(ctx context.Context) error {
-	return p.ODPricing.Refresh(ctx)
+// RefreshOnDemandCache makes a bulk request to the pricing api to retrieve all instance type on-demand and reserved pricing with the options
+// and stores them in a local cache.
+func (p *EC2Pricing) RefreshOnDemandCache(ctx context.Context, options PricingOptions) error {
+	return p.ODPricing.Refresh(ctx, options)
 }
 
-// RefreshSpotCache makes a bulk request to the ec2 api to retrieve all spot instance type pricing and stores them in a local cache.
-func (p *EC2Pricing) RefreshSpotCache(ctx context.Context, days int) error {
-	return p.SpotPricing.Refresh(ctx, days)
+// RefreshSpotCache makes a bulk request to the ec2 api to retrieve all spot instance type pricing for the operating system of the options
+// and stores them in a local cache.
+func (p *EC2Pricing) RefreshSpotCache(ctx context.Context, options PricingOptions, days int) error {
+	return p.SpotPricing.Refresh(ctx, options, days)
 }
 
 func (p *EC2Pricing) Save() error {
@@ -15,9 +15,12 @@ package ec2pricing_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -40,9 +43,14 @@ type mockedPricing struct {
 	pricing.GetProductsAPIClient
 	GetProductsResp pricing.GetProductsOutput
 	GetProductsErr  error
+	// GetProductsInputs records the inputs of the GetProducts calls if it is not nil
+	GetProductsInputs *[]*pricing.GetProductsInput
 }
 
 func (m mockedPricing) GetProducts(_ context.Context, input *pricing.GetProductsInput, optFns ...func(*pricing.Options)) (*pricing.GetProductsOutput, error) {
+	if m.GetProductsInputs != nil {
+		*m.GetProductsInputs = append(*m.GetProductsInputs, input)
+	}
 	return &m.GetProductsResp, m.GetProductsErr
 }
 
@@ -50,9 +58,14 @@ type mockedSpotEC2 struct {
 	ec2.DescribeSpotPriceHistoryAPIClient
 	DescribeSpotPriceHistoryPagesResp ec2.DescribeSpotPriceHistoryOutput
 	DescribeSpotPriceHistoryPagesErr  error
+	// DescribeSpotPriceHistoryInputs records the inputs of the DescribeSpotPriceHistory calls if it is not nil
+	DescribeSpotPriceHistoryInputs *[]*ec2.DescribeSpotPriceHistoryInput
 }
 
 func (m mockedSpotEC2) DescribeSpotPriceHistory(_ context.Context, input *ec2.DescribeSpotPriceHistoryInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSpotPriceHistoryOutput, error) {
+	if m.DescribeSpotPriceHistoryInputs != nil {
+		*m.DescribeSpotPriceHistoryInputs = append(*m.DescribeSpotPriceHistoryInputs, input)
+	}
 	return &m.DescribeSpotPriceHistoryPagesResp, m.DescribeSpotPriceHistoryPagesErr
 }
 
@@ -101,7 +114,7 @@ func TestGetOndemandInstanceTypeCost_m5large(t *testing.T) {
 	ec2pricingClient := ec2pricing.EC2Pricing{
 		ODPricing: lo.Must(ec2pricing.LoadODCacheOrNew(ctx, pricingMock, "us-east-1", 0, "")),
 	}
-	price, err := ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large)
+	price, err := ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{})
 	h.Ok(t, err)
 	h.Equals(t, float64(0.096), price)
 }
@@ -112,10 +125,10 @@ func TestRefreshOnDemandCache(t *testing.T) {
 	ec2pricingClient := ec2pricing.EC2Pricing{
 		ODPricing: lo.Must(ec2pricing.LoadODCacheOrNew(ctx, pricingMock, "us-east-1", 0, "")),
 	}
-	err := ec2pricingClient.RefreshOnDemandCache(ctx)
+	err := ec2pricingClient.RefreshOnDemandCache(ctx, ec2pricing.PricingOptions{})
 	h.Ok(t, err)
 
-	price, err := ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large)
+	price, err := ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{})
 	h.Ok(t, err)
 	h.Equals(t, float64(0.096), price)
 }
@@ -126,7 +139,7 @@ func TestGetSpotInstanceTypeNDayAvgCost(t *testing.T) {
 	ec2pricingClient := ec2pricing.EC2Pricing{
 		SpotPricing: lo.Must(ec2pricing.LoadSpotCacheOrNew(ctx, ec2Mock, "us-east-1", 0, "", 30)),
 	}
-	price, err := ec2pricingClient.GetSpotInstanceTypeNDayAvgCost(ctx, ec2types.InstanceTypeM5Large, []string{"us-east-1a"}, 30)
+	price, err := ec2pricingClient.GetSpotInstanceTypeNDayAvgCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{}, []string{"us-east-1a"}, 30)
 	h.Ok(t, err)
 	h.Equals(t, float64(0.041486231229302666), price)
 }
@@ -137,10 +150,142 @@ func TestRefreshSpotCache(t *testing.T) {
 	ec2pricingClient := ec2pricing.EC2Pricing{
 		SpotPricing: lo.Must(ec2pricing.LoadSpotCacheOrNew(ctx, ec2Mock, "us-east-1", 0, "", 30)),
 	}
-	err := ec2pricingClient.RefreshSpotCache(ctx, 30)
+	err := ec2pricingClient.RefreshSpotCache(ctx, ec2pricing.PricingOptions{}, 30)
 	h.Ok(t, err)
 
-	price, err := ec2pricingClient.GetSpotInstanceTypeNDayAvgCost(ctx, ec2types.InstanceTypeM5Large, []string{"us-east-1a"}, 30)
+	price, err := ec2pricingClient.GetSpotInstanceTypeNDayAvgCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{}, []string{"us-east-1a"}, 30)
 	h.Ok(t, err)
 	h.Equals(t, float64(0.041486231229302666), price)
 }
+
+func TestGetSpotInstanceTypeNDayStats(t *testing.T) {
+	ec2Mock := setupEc2Mock(t, describeSpotPriceHistory, "m5_large.json")
+	ctx := context.Background()
+	ec2pricingClient := ec2pricing.EC2Pricing{
+		SpotPricing: lo.Must(ec2pricing.LoadSpotCacheOrNew(ctx, ec2Mock, "us-east-1", 0, "", 30)),
+	}
+	stats, err := ec2pricingClient.GetSpotInstanceTypeNDayStats(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{}, []string{"us-east-1a"}, 30)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(stats))
+	zoneStats, ok := stats["us-east-1a"]
+	h.Assert(t, ok, "Should return the statistics of us-east-1a")
+	h.Equals(t, float64(0.041486231229302666), zoneStats.Mean)
+	h.Assert(t, zoneStats.Min <= zoneStats.P50 && zoneStats.P50 <= zoneStats.P90 && zoneStats.P90 <= zoneStats.Max, "Percentiles should be between the min and max")
+	h.Assert(t, zoneStats.Min <= zoneStats.Current && zoneStats.Current <= zoneStats.Max, "Current price should be between the min and max")
+	h.Assert(t, zoneStats.StdDev >= 0, "Standard deviation should not be negative")
+	h.Assert(t, math.Abs(zoneStats.Volatility-zoneStats.StdDev/zoneStats.Mean) < 1e-12, "Volatility should be the standard deviation relative to the mean")
+
+	allStats, err := ec2pricingClient.GetSpotInstanceTypeNDayStats(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{}, nil, 30)
+	h.Ok(t, err)
+	h.Assert(t, len(allStats) >= len(stats), "Should return the statistics of every availability zone")
+	h.Equals(t, zoneStats, allStats["us-east-1a"])
+}
+
+func TestGetOndemandInstanceTypeCost_PricingOptions(t *testing.T) {
+	pricingMock := setupOdMock(t, getProducts, "m5_large.json")
+	pricingMock.GetProductsInputs = &[]*pricing.GetProductsInput{}
+	ctx := context.Background()
+	ec2pricingClient := ec2pricing.EC2Pricing{
+		ODPricing: lo.Must(ec2pricing.LoadODCacheOrNew(ctx, pricingMock, "us-east-1", 0, "")),
+	}
+	options := ec2pricing.PricingOptions{
+		OperatingSystem: ec2pricing.OperatingSystemWindowsSQLStandard,
+		Tenancy:         ec2pricing.TenancyDedicated,
+		LicenseModel:    ec2pricing.LicenseModelBYOL,
+	}
+	_, err := ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large, options)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(*pricingMock.GetProductsInputs))
+	productFilters := map[string]string{}
+	for _, filter := range (*pricingMock.GetProductsInputs)[0].Filters {
+		productFilters[*filter.Field] = *filter.Value
+	}
+	h.Equals(t, "Windows", productFilters["operatingSystem"])
+	h.Equals(t, "SQL Std", productFilters["preInstalledSw"])
+	h.Equals(t, "Dedicated", productFilters["tenancy"])
+	h.Equals(t, "Bring your own license", productFilters["licenseModel"])
+
+	// the cache is keyed by the pricing options
+	h.Equals(t, 1, ec2pricingClient.OnDemandCacheCount(options))
+	h.Equals(t, 0, ec2pricingClient.OnDemandCacheCount(ec2pricing.PricingOptions{}))
+
+	_, err = ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{OperatingSystem: "beos"})
+	h.Nok(t, err)
+}
+
+func TestGetSpotInstanceTypeNDayAvgCost_PricingOptions(t *testing.T) {
+	ec2Mock := setupEc2Mock(t, describeSpotPriceHistory, "m5_large.json")
+	ec2Mock.DescribeSpotPriceHistoryInputs = &[]*ec2.DescribeSpotPriceHistoryInput{}
+	ctx := context.Background()
+	ec2pricingClient := ec2pricing.EC2Pricing{
+		SpotPricing: lo.Must(ec2pricing.LoadSpotCacheOrNew(ctx, ec2Mock, "us-east-1", 0, "", 30)),
+	}
+	options := ec2pricing.PricingOptions{OperatingSystem: ec2pricing.OperatingSystemRHEL}
+	_, err := ec2pricingClient.GetSpotInstanceTypeNDayAvgCost(ctx, ec2types.InstanceTypeM5Large, options, []string{"us-east-1a"}, 30)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(*ec2Mock.DescribeSpotPriceHistoryInputs))
+	h.Equals(t, []string{"Red Hat Enterprise Linux (Amazon VPC)"}, (*ec2Mock.DescribeSpotPriceHistoryInputs)[0].ProductDescriptions)
+	h.Equals(t, 1, ec2pricingClient.SpotCacheCount(options, 30))
+	h.Equals(t, 0, ec2pricingClient.SpotCacheCount(options, 7))
+	h.Equals(t, 0, ec2pricingClient.SpotCacheCount(ec2pricing.PricingOptions{}, 30))
+
+	// SQL Server is not available on spot instances
+	_, err = ec2pricingClient.GetSpotInstanceTypeNDayAvgCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{OperatingSystem: ec2pricing.OperatingSystemWindowsSQLWeb}, nil, 30)
+	h.Nok(t, err)
+}
+
+func TestGetReservedInstanceTypeCosts_m5large(t *testing.T) {
+	pricingMock := setupOdMock(t, getProducts, "m5_large.json")
+	ctx := context.Background()
+	ec2pricingClient := ec2pricing.EC2Pricing{
+		ODPricing: lo.Must(ec2pricing.LoadODCacheOrNew(ctx, pricingMock, "us-east-1", 0, "")),
+	}
+	reservedPrices, err := ec2pricingClient.GetReservedInstanceTypeCosts(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{})
+	h.Ok(t, err)
+	h.Equals(t, ec2pricing.ReservedPrice{HourlyRate: 0.06, EffectiveHourlyRate: 0.06}, reservedPrices[ec2pricing.PricingModelReserved1YrStandardNoUpfront])
+	convertiblePartialUpfront := reservedPrices[ec2pricing.PricingModelReserved1YrConvertiblePartialUpfront]
+	h.Equals(t, 0.034, convertiblePartialUpfront.HourlyRate)
+	h.Equals(t, 294.0, convertiblePartialUpfront.UpfrontFee)
+	h.Assert(t, math.Abs(convertiblePartialUpfront.EffectiveHourlyRate-(0.034+294.0/8760)) < 1e-9, "the upfront fee should be spread over a year")
+	h.Assert(t, math.Abs(reservedPrices[ec2pricing.PricingModelReserved3YrStandardPartialUpfront].EffectiveHourlyRate-(0.019+505.0/(3*8760))) < 1e-9,
+		"the upfront fee should be spread over three years")
+
+	// the on-demand price is cached with the reserved prices
+	h.Equals(t, 1, ec2pricingClient.OnDemandCacheCount(ec2pricing.PricingOptions{}))
+}
+
+func TestGetReservedInstanceTypeCosts_MalformedReservedTerms(t *testing.T) {
+	pricingMock := setupOdMock(t, getProducts, "m5_large_malformed_reserved.json")
+	ctx := context.Background()
+	ec2pricingClient := ec2pricing.EC2Pricing{
+		ODPricing: lo.Must(ec2pricing.LoadODCacheOrNew(ctx, pricingMock, "us-east-1", 0, "")),
+	}
+	price, err := ec2pricingClient.GetOnDemandInstanceTypeCost(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{})
+	h.Ok(t, err)
+	h.Equals(t, float64(0.096), price)
+
+	reservedPrices, err := ec2pricingClient.GetReservedInstanceTypeCosts(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{})
+	h.Ok(t, err)
+	h.Equals(t, 10, len(reservedPrices))
+	h.Equals(t, ec2pricing.ReservedPrice{HourlyRate: 0.06, EffectiveHourlyRate: 0.06}, reservedPrices[ec2pricing.PricingModelReserved1YrStandardNoUpfront])
+	_, ok := reservedPrices[ec2pricing.PricingModelReserved1YrConvertibleNoUpfront]
+	h.Assert(t, !ok, "the reserved price which can not be parsed should be skipped")
+	_, ok = reservedPrices[ec2pricing.PricingModelReserved3YrConvertiblePartialUpfront]
+	h.Assert(t, !ok, "the reserved price without a price in USD should be skipped")
+}
+
+func TestGetReservedInstanceTypeCosts_CacheFile(t *testing.T) {
+	pricingMock := setupOdMock(t, getProducts, "m5_large.json")
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	odPricing := lo.Must(ec2pricing.LoadODCacheOrNew(ctx, pricingMock, "us-east-1", time.Hour, cacheDir))
+	h.Ok(t, odPricing.Refresh(ctx, ec2pricing.PricingOptions{}))
+
+	// reserved prices loaded from the cache file are decoded to their type
+	ec2pricingClient := ec2pricing.EC2Pricing{
+		ODPricing: lo.Must(ec2pricing.LoadODCacheOrNew(ctx, mockedPricing{GetProductsErr: errors.New("not cached")}, "us-east-1", time.Hour, cacheDir)),
+	}
+	reservedPrices, err := ec2pricingClient.GetReservedInstanceTypeCosts(ctx, ec2types.InstanceTypeM5Large, ec2pricing.PricingOptions{})
+	h.Ok(t, err)
+	h.Equals(t, 0.06, reservedPrices[ec2pricing.PricingModelReserved1YrStandardNoUpfront].HourlyRate)
+}
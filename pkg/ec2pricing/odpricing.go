@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,6 +45,8 @@ type OnDemandPricing struct {
 	cache          *cache.Cache
 	pricingClient  pricing.GetProductsAPIClient
 	logger         *log.Logger
+	// refreshedOptions are the pricing options which the periodic refresh job refreshes
+	refreshedOptions map[PricingOptions]bool
 	sync.RWMutex
 }
 
@@ -90,12 +93,13 @@ func LoadODCacheOrNew(ctx context.Context, pricingClient pricing.GetProductsAPIC
 		return nil, fmt.Errorf("unable to load on-demand pricing cache directory %s: %w", expandedDirPath, err)
 	}
 	odPricing := &OnDemandPricing{
-		Region:         region,
-		FullRefreshTTL: fullRefreshTTL,
-		DirectoryPath:  expandedDirPath,
-		pricingClient:  pricingClient,
-		cache:          cache.New(fullRefreshTTL, fullRefreshTTL),
-		logger:         log.New(io.Discard, "", 0),
+		Region:           region,
+		FullRefreshTTL:   fullRefreshTTL,
+		DirectoryPath:    expandedDirPath,
+		pricingClient:    pricingClient,
+		cache:            cache.New(fullRefreshTTL, fullRefreshTTL),
+		logger:           log.New(io.Discard, "", 0),
+		refreshedOptions: map[PricingOptions]bool{PricingOptions{}.WithDefaults(): true},
 	}
 	if fullRefreshTTL <= 0 {
 		if err := odPricing.Clear(); err != nil {
@@ -125,6 +129,22 @@ func loadODCacheFrom(itemTTL time.Duration, region string, expandedDirPath strin
 	if err := json.Unmarshal(cacheBytes, odCache); err != nil {
 		return nil, err
 	}
+	// reserved prices are decoded from json as generic maps
+	for key, item := range *odCache {
+		if !strings.HasPrefix(key, reservedCacheKeyPrefix) {
+			continue
+		}
+		reservedPricesBytes, err := json.Marshal(item.Object)
+		if err != nil {
+			return nil, err
+		}
+		reservedPrices := ReservedPrices{}
+		if err := json.Unmarshal(reservedPricesBytes, &reservedPrices); err != nil {
+			return nil, err
+		}
+		item.Object = reservedPrices
+		(*odCache)[key] = item
+	}
 	c := cache.NewFrom(itemTTL, itemTTL, *odCache)
 	c.DeleteExpired()
 	return c, nil
@@ -140,8 +160,16 @@ func (c *OnDemandPricing) odCacheRefreshJob(ctx context.Context) {
 	}
 	refreshTicker := time.NewTicker(c.FullRefreshTTL)
 	for range refreshTicker.C {
-		if err := c.Refresh(ctx); err != nil {
-			c.logger.Printf("Periodic OD Cache Refresh Error: %v", err)
+		c.RLock()
+		refreshedOptions := make([]PricingOptions, 0, len(c.refreshedOptions))
+		for options := range c.refreshedOptions {
+			refreshedOptions = append(refreshedOptions, options)
+		}
+		c.RUnlock()
+		for _, options := range refreshedOptions {
+			if err := c.Refresh(ctx, options); err != nil {
+				c.logger.Printf("Periodic OD Cache Refresh Error: %v", err)
+			}
 		}
 	}
 }
@@ -150,15 +178,24 @@ func (c *OnDemandPricing) SetLogger(logger *log.Logger) {
 	c.logger = logger
 }
 
-func (c *OnDemandPricing) Refresh(ctx context.Context) error {
+// Refresh fetches the on-demand and reserved pricing of all instance types priced with the options and stores them in the cache.
+func (c *OnDemandPricing) Refresh(ctx context.Context, options PricingOptions) error {
+	options = options.WithDefaults()
+	if err := options.Validate(); err != nil {
+		return err
+	}
 	c.Lock()
 	defer c.Unlock()
-	odInstanceTypeCosts, err := c.fetchOnDemandPricing(ctx, "")
+	c.refreshedOptions[options] = true
+	odInstanceTypeCosts, reservedInstanceTypeCosts, err := c.fetchOnDemandPricing(ctx, "", options)
 	if err != nil {
 		return fmt.Errorf("there was a problem refreshing the on-demand instance type pricing cache: %v", err)
 	}
 	for instanceType, cost := range odInstanceTypeCosts {
-		c.cache.SetDefault(instanceType, cost)
+		c.cache.SetDefault(options.onDemandCacheKey(instanceType), cost)
+	}
+	for instanceType, reservedPrices := range reservedInstanceTypeCosts {
+		c.cache.SetDefault(options.reservedCacheKey(instanceType), reservedPrices)
 	}
 	if err := c.Save(); err != nil {
 		return fmt.Errorf("unable to save the refreshed on-demand instance type pricing cache file: %v", err)
@@ -166,25 +203,70 @@ func (c *OnDemandPricing) Refresh(ctx context.Context) error {
 	return nil
 }
 
-func (c *OnDemandPricing) Get(ctx context.Context, instanceType ec2types.InstanceType) (float64, error) {
-	if cost, ok := c.cache.Get(string(instanceType)); ok {
+// Get returns the on-demand hourly price of the instance type priced with the options.
+func (c *OnDemandPricing) Get(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (float64, error) {
+	options = options.WithDefaults()
+	if err := options.Validate(); err != nil {
+		return 0, err
+	}
+	cacheKey := options.onDemandCacheKey(string(instanceType))
+	if cost, ok := c.cache.Get(cacheKey); ok {
 		return cost.(float64), nil
 	}
 	c.RLock()
 	defer c.RUnlock()
-	costs, err := c.fetchOnDemandPricing(ctx, instanceType)
+	costs, reservedCosts, err := c.fetchOnDemandPricing(ctx, instanceType, options)
 	if err != nil {
 		return 0, fmt.Errorf("there was a problem fetching on-demand instance type pricing for %s: %v", instanceType, err)
 	}
-	c.cache.SetDefault(string(instanceType), costs[string(instanceType)])
+	c.cache.SetDefault(cacheKey, costs[string(instanceType)])
+	c.cache.SetDefault(options.reservedCacheKey(string(instanceType)), reservedCosts[string(instanceType)])
 	return costs[string(instanceType)], nil
 }
 
+// GetReserved returns the reserved instance prices of the instance type priced with the options by pricing model.
+// Instance types which cannot be reserved have no reserved prices.
+func (c *OnDemandPricing) GetReserved(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (ReservedPrices, error) {
+	options = options.WithDefaults()
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	cacheKey := options.reservedCacheKey(string(instanceType))
+	if reservedPrices, ok := c.cache.Get(cacheKey); ok {
+		return reservedPrices.(ReservedPrices), nil
+	}
+	c.RLock()
+	defer c.RUnlock()
+	costs, reservedCosts, err := c.fetchOnDemandPricing(ctx, instanceType, options)
+	if err != nil {
+		return nil, fmt.Errorf("there was a problem fetching reserved instance type pricing for %s: %v", instanceType, err)
+	}
+	reservedPrices := reservedCosts[string(instanceType)]
+	if reservedPrices == nil {
+		reservedPrices = ReservedPrices{}
+	}
+	c.cache.SetDefault(options.onDemandCacheKey(string(instanceType)), costs[string(instanceType)])
+	c.cache.SetDefault(cacheKey, reservedPrices)
+	return reservedPrices, nil
+}
+
 // Count of items in the cache.
 func (c *OnDemandPricing) Count() int {
 	return c.cache.ItemCount()
 }
 
+// CountWith returns the count of items in the cache priced with the options.
+func (c *OnDemandPricing) CountWith(options PricingOptions) int {
+	prefix := options.onDemandCacheKey("")
+	count := 0
+	for key := range c.cache.Items() {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *OnDemandPricing) Save() error {
 	if c.FullRefreshTTL == 0 || c.Count() == 0 {
 		return nil
@@ -209,19 +291,20 @@ func (c *OnDemandPricing) Clear() error {
 	return nil
 }
 
-// fetchOnDemandPricing makes a bulk request to the pricing api to retrieve all instance type pricing if the instanceType is the empty string
+// fetchOnDemandPricing makes a bulk request to the pricing api to retrieve all instance type on-demand and reserved pricing if the instanceType is the empty string
 //
 //	or, if instanceType is specified, it can request a specific instance type pricing
-func (c *OnDemandPricing) fetchOnDemandPricing(ctx context.Context, instanceType ec2types.InstanceType) (map[string]float64, error) {
+func (c *OnDemandPricing) fetchOnDemandPricing(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions) (map[string]float64, map[string]ReservedPrices, error) {
 	start := time.Now()
 	calls := 0
 	defer func() {
 		c.logger.Printf("Took %s and %d calls to collect OD pricing", time.Since(start), calls)
 	}()
 	odPricing := map[string]float64{}
+	reservedPricing := map[string]ReservedPrices{}
 	productInput := pricing.GetProductsInput{
 		ServiceCode: c.StringMe(serviceCode),
-		Filters:     c.getProductsInputFilters(instanceType),
+		Filters:     c.getProductsInputFilters(instanceType, options),
 	}
 	var processingErr error
 
@@ -231,19 +314,20 @@ func (c *OnDemandPricing) fetchOnDemandPricing(ctx context.Context, instanceType
 		calls++
 		pricingOutput, err := p.NextPage(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get next OD pricing page, %w", err)
+			return nil, nil, fmt.Errorf("failed to get next OD pricing page, %w", err)
 		}
 
 		for _, priceDoc := range pricingOutput.PriceList {
-			instanceTypeName, price, errParse := c.parseOndemandUnitPrice(priceDoc)
+			instanceTypeName, price, reservedPrices, errParse := c.parseOndemandUnitPrice(priceDoc)
 			if errParse != nil {
 				processingErr = multierr.Append(processingErr, errParse)
 				continue
 			}
 			odPricing[instanceTypeName] = price
+			reservedPricing[instanceTypeName] = reservedPrices
 		}
 	}
-	return odPricing, processingErr
+	return odPricing, reservedPricing, processingErr
 }
 
 // StringMe takes an interface and returns a pointer to a string value
@@ -263,14 +347,17 @@ func (c *OnDemandPricing) StringMe(i interface{}) *string {
 	}
 }
 
-func (c *OnDemandPricing) getProductsInputFilters(instanceType ec2types.InstanceType) []pricingtypes.Filter {
+func (c *OnDemandPricing) getProductsInputFilters(instanceType ec2types.InstanceType, options PricingOptions) []pricingtypes.Filter {
+	options = options.WithDefaults()
+	operatingSystem := operatingSystems[options.OperatingSystem]
 	filters := []pricingtypes.Filter{
 		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("ServiceCode"), Value: c.StringMe(serviceCode)},
-		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("operatingSystem"), Value: c.StringMe("linux")},
+		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("operatingSystem"), Value: c.StringMe(operatingSystem.operatingSystem)},
 		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("regionCode"), Value: c.StringMe(c.Region)},
 		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("capacitystatus"), Value: c.StringMe("used")},
-		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("preInstalledSw"), Value: c.StringMe("NA")},
-		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("tenancy"), Value: c.StringMe("shared")},
+		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("preInstalledSw"), Value: c.StringMe(operatingSystem.preInstalledSw)},
+		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("tenancy"), Value: c.StringMe(tenancies[options.Tenancy])},
+		{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("licenseModel"), Value: c.StringMe(licenseModels[options.LicenseModel])},
 	}
 	if instanceType != "" {
 		filters = append(filters, pricingtypes.Filter{Type: pricingtypes.FilterTypeTermMatch, Field: c.StringMe("instanceType"), Value: c.StringMe(string(instanceType))})
@@ -278,12 +365,12 @@ func (c *OnDemandPricing) getProductsInputFilters(instanceType ec2types.Instance
 	return filters
 }
 
-// parseOndemandUnitPrice takes a priceList from the pricing API and parses its weirdness.
-func (c *OnDemandPricing) parseOndemandUnitPrice(priceList string) (string, float64, error) {
+// parseOndemandUnitPrice takes a priceList from the pricing API and parses its weirdness into the on-demand price and the reserved prices.
+func (c *OnDemandPricing) parseOndemandUnitPrice(priceList string) (string, float64, ReservedPrices, error) {
 	var productPriceList PricingList
 	err := json.Unmarshal([]byte(priceList), &productPriceList)
 	if err != nil {
-		return "", float64(-1.0), fmt.Errorf("unable to parse pricing doc: %w", err)
+		return "", float64(-1.0), nil, fmt.Errorf("unable to parse pricing doc: %w", err)
 	}
 	attributes := productPriceList.Product.ProductAttributes
 	instanceTypeName := attributes["instanceType"]
@@ -294,15 +381,15 @@ func (c *OnDemandPricing) parseOndemandUnitPrice(priceList string) (string, floa
 			pricePerUnit := dimension.PricePerUnit
 			pricePerUnitInUSDStr, ok := pricePerUnit["USD"]
 			if !ok {
-				return instanceTypeName, float64(-1.0), fmt.Errorf("unable to find on-demand price per unit in USD")
+				return instanceTypeName, float64(-1.0), nil, fmt.Errorf("unable to find on-demand price per unit in USD")
 			}
 			var err error
 			pricePerUnitInUSD, err := strconv.ParseFloat(pricePerUnitInUSDStr, 64)
 			if err != nil {
-				return instanceTypeName, float64(-1.0), fmt.Errorf("could not convert price per unit in USD to a float64")
+				return instanceTypeName, float64(-1.0), nil, fmt.Errorf("could not convert price per unit in USD to a float64")
 			}
-			return instanceTypeName, pricePerUnitInUSD, nil
+			return instanceTypeName, pricePerUnitInUSD, parseReservedPrices(instanceTypeName, productPriceList.Terms.Reserved, c.logger), nil
 		}
 	}
-	return instanceTypeName, float64(-1.0), fmt.Errorf("unable to parse pricing doc")
+	return instanceTypeName, float64(-1.0), nil, fmt.Errorf("unable to parse pricing doc")
 }
@@ -0,0 +1,183 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ec2pricing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OperatingSystem is the operating system and pre-installed software instance types are priced for.
+type OperatingSystem string
+
+// Enum values for OperatingSystem.
+const (
+	OperatingSystemLinux                OperatingSystem = "linux"
+	OperatingSystemWindows              OperatingSystem = "windows"
+	OperatingSystemRHEL                 OperatingSystem = "rhel"
+	OperatingSystemSUSE                 OperatingSystem = "suse"
+	OperatingSystemWindowsSQLWeb        OperatingSystem = "windows-sql-web"
+	OperatingSystemWindowsSQLStandard   OperatingSystem = "windows-sql-standard"
+	OperatingSystemWindowsSQLEnterprise OperatingSystem = "windows-sql-enterprise"
+)
+
+// Values returns all known values for OperatingSystem.
+func (OperatingSystem) Values() []OperatingSystem {
+	return []OperatingSystem{
+		OperatingSystemLinux,
+		OperatingSystemWindows,
+		OperatingSystemRHEL,
+		OperatingSystemSUSE,
+		OperatingSystemWindowsSQLWeb,
+		OperatingSystemWindowsSQLStandard,
+		OperatingSystemWindowsSQLEnterprise,
+	}
+}
+
+// Tenancy is the tenancy instance types are priced for.
+type Tenancy string
+
+// Enum values for Tenancy.
+const (
+	TenancyShared    Tenancy = "shared"
+	TenancyDedicated Tenancy = "dedicated"
+)
+
+// Values returns all known values for Tenancy.
+func (Tenancy) Values() []Tenancy {
+	return []Tenancy{
+		TenancyShared,
+		TenancyDedicated,
+	}
+}
+
+// LicenseModel is the license model instance types are priced for.
+type LicenseModel string
+
+// Enum values for LicenseModel.
+const (
+	// LicenseModelNoLicenseRequired includes the operating system license in the price
+	LicenseModelNoLicenseRequired LicenseModel = "no-license-required"
+	// LicenseModelBYOL excludes the operating system license from the price
+	LicenseModelBYOL LicenseModel = "byol"
+)
+
+// Values returns all known values for LicenseModel.
+func (LicenseModel) Values() []LicenseModel {
+	return []LicenseModel{
+		LicenseModelNoLicenseRequired,
+		LicenseModelBYOL,
+	}
+}
+
+// operatingSystemPricing holds the pricing api attributes and the spot product description of an OperatingSystem
+type operatingSystemPricing struct {
+	operatingSystem string
+	preInstalledSw  string
+	// productDescription is empty if the operating system cannot be run on spot instances
+	productDescription string
+}
+
+var (
+	operatingSystems = map[OperatingSystem]operatingSystemPricing{
+		OperatingSystemLinux:                {operatingSystem: "Linux", preInstalledSw: "NA", productDescription: "Linux/UNIX (Amazon VPC)"},
+		OperatingSystemWindows:              {operatingSystem: "Windows", preInstalledSw: "NA", productDescription: "Windows (Amazon VPC)"},
+		OperatingSystemRHEL:                 {operatingSystem: "RHEL", preInstalledSw: "NA", productDescription: "Red Hat Enterprise Linux (Amazon VPC)"},
+		OperatingSystemSUSE:                 {operatingSystem: "SUSE", preInstalledSw: "NA", productDescription: "SUSE Linux (Amazon VPC)"},
+		OperatingSystemWindowsSQLWeb:        {operatingSystem: "Windows", preInstalledSw: "SQL Web"},
+		OperatingSystemWindowsSQLStandard:   {operatingSystem: "Windows", preInstalledSw: "SQL Std"},
+		OperatingSystemWindowsSQLEnterprise: {operatingSystem: "Windows", preInstalledSw: "SQL Ent"},
+	}
+	tenancies = map[Tenancy]string{
+		TenancyShared:    "Shared",
+		TenancyDedicated: "Dedicated",
+	}
+	licenseModels = map[LicenseModel]string{
+		LicenseModelNoLicenseRequired: "No License required",
+		LicenseModelBYOL:              "Bring your own license",
+	}
+)
+
+// PricingOptions are the dimensions other than the instance type which determine the price of an instance.
+// The zero value prices linux instances with shared tenancy and no license required.
+type PricingOptions struct {
+	// OperatingSystem defaults to linux
+	OperatingSystem OperatingSystem
+	// Tenancy defaults to shared
+	Tenancy Tenancy
+	// LicenseModel defaults to no-license-required
+	LicenseModel LicenseModel
+}
+
+// WithDefaults returns the options with the unset dimensions set to their defaults.
+func (o PricingOptions) WithDefaults() PricingOptions {
+	if o.OperatingSystem == "" {
+		o.OperatingSystem = OperatingSystemLinux
+	}
+	if o.Tenancy == "" {
+		o.Tenancy = TenancyShared
+	}
+	if o.LicenseModel == "" {
+		o.LicenseModel = LicenseModelNoLicenseRequired
+	}
+	return o
+}
+
+// Validate returns an error if any of the dimensions is not a known value.
+func (o PricingOptions) Validate() error {
+	o = o.WithDefaults()
+	if _, ok := operatingSystems[o.OperatingSystem]; !ok {
+		return fmt.Errorf("%s is not a known operating system, must be one of %v", o.OperatingSystem, o.OperatingSystem.Values())
+	}
+	if _, ok := tenancies[o.Tenancy]; !ok {
+		return fmt.Errorf("%s is not a known tenancy, must be one of %v", o.Tenancy, o.Tenancy.Values())
+	}
+	if _, ok := licenseModels[o.LicenseModel]; !ok {
+		return fmt.Errorf("%s is not a known license model, must be one of %v", o.LicenseModel, o.LicenseModel.Values())
+	}
+	return nil
+}
+
+// String returns the dimensions separated by slashes, i.e. linux/shared/no-license-required
+func (o PricingOptions) String() string {
+	o = o.WithDefaults()
+	return strings.Join([]string{string(o.OperatingSystem), string(o.Tenancy), string(o.LicenseModel)}, "/")
+}
+
+// onDemandCacheKey returns the on-demand cache key of an instance type priced with the options
+func (o PricingOptions) onDemandCacheKey(instanceType string) string {
+	return o.String() + "/" + instanceType
+}
+
+// reservedCacheKey returns the on-demand cache key of the reserved prices of an instance type priced with the options
+func (o PricingOptions) reservedCacheKey(instanceType string) string {
+	return reservedCacheKeyPrefix + o.onDemandCacheKey(instanceType)
+}
+
+// productDescription returns the spot price history product description of the operating system
+func (o PricingOptions) productDescription() (string, error) {
+	o = o.WithDefaults()
+	if err := o.Validate(); err != nil {
+		return "", err
+	}
+	description := operatingSystems[o.OperatingSystem].productDescription
+	if description == "" {
+		return "", fmt.Errorf("spot pricing is not available for the %s operating system", o.OperatingSystem)
+	}
+	return description, nil
+}
+
+// spotCacheKey returns the spot cache key of the spot price history of the past days of an instance type priced with the product description
+func spotCacheKey(productDescription string, days int, instanceType string) string {
+	return fmt.Sprintf("%s/%dd/%s", productDescription, days, instanceType)
+}
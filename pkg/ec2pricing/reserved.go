@@ -0,0 +1,152 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ec2pricing
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// PricingModel is how instances are paid for: on-demand, spot, or a reserved instance term like reserved-1yr-standard-no-upfront.
+type PricingModel string
+
+// Enum values for PricingModel.
+const (
+	PricingModelOnDemand PricingModel = "on-demand"
+	PricingModelSpot     PricingModel = "spot"
+
+	PricingModelReserved1YrStandardNoUpfront         PricingModel = "reserved-1yr-standard-no-upfront"
+	PricingModelReserved1YrStandardPartialUpfront    PricingModel = "reserved-1yr-standard-partial-upfront"
+	PricingModelReserved1YrStandardAllUpfront        PricingModel = "reserved-1yr-standard-all-upfront"
+	PricingModelReserved1YrConvertibleNoUpfront      PricingModel = "reserved-1yr-convertible-no-upfront"
+	PricingModelReserved1YrConvertiblePartialUpfront PricingModel = "reserved-1yr-convertible-partial-upfront"
+	PricingModelReserved1YrConvertibleAllUpfront     PricingModel = "reserved-1yr-convertible-all-upfront"
+	PricingModelReserved3YrStandardNoUpfront         PricingModel = "reserved-3yr-standard-no-upfront"
+	PricingModelReserved3YrStandardPartialUpfront    PricingModel = "reserved-3yr-standard-partial-upfront"
+	PricingModelReserved3YrStandardAllUpfront        PricingModel = "reserved-3yr-standard-all-upfront"
+	PricingModelReserved3YrConvertibleNoUpfront      PricingModel = "reserved-3yr-convertible-no-upfront"
+	PricingModelReserved3YrConvertiblePartialUpfront PricingModel = "reserved-3yr-convertible-partial-upfront"
+	PricingModelReserved3YrConvertibleAllUpfront     PricingModel = "reserved-3yr-convertible-all-upfront"
+
+	reservedPricingModelPrefix = "reserved-"
+	hoursPerYear               = 24 * 365
+	// reservedCacheKeyPrefix prefixes the keys of the reserved prices in the on-demand cache
+	reservedCacheKeyPrefix = "reserved/"
+)
+
+// Values returns all known values for PricingModel.
+func (PricingModel) Values() []PricingModel {
+	return []PricingModel{
+		PricingModelOnDemand,
+		PricingModelSpot,
+		PricingModelReserved1YrStandardNoUpfront,
+		PricingModelReserved1YrStandardPartialUpfront,
+		PricingModelReserved1YrStandardAllUpfront,
+		PricingModelReserved1YrConvertibleNoUpfront,
+		PricingModelReserved1YrConvertiblePartialUpfront,
+		PricingModelReserved1YrConvertibleAllUpfront,
+		PricingModelReserved3YrStandardNoUpfront,
+		PricingModelReserved3YrStandardPartialUpfront,
+		PricingModelReserved3YrStandardAllUpfront,
+		PricingModelReserved3YrConvertibleNoUpfront,
+		PricingModelReserved3YrConvertiblePartialUpfront,
+		PricingModelReserved3YrConvertibleAllUpfront,
+	}
+}
+
+// IsReserved returns true if the pricing model is a reserved instance term.
+func (m PricingModel) IsReserved() bool {
+	return strings.HasPrefix(string(m), reservedPricingModelPrefix)
+}
+
+// ReservedPrice is the price of an instance type for a reserved instance term.
+type ReservedPrice struct {
+	// HourlyRate is the recurring hourly price in USD, which is 0 for all upfront terms
+	HourlyRate float64
+	// UpfrontFee is the one-time price in USD paid at the start of the term, which is 0 for no upfront terms
+	UpfrontFee float64
+	// EffectiveHourlyRate is the hourly rate with the upfront fee spread over every hour of the term
+	EffectiveHourlyRate float64
+}
+
+// ReservedPrices are the reserved instance prices of an instance type by pricing model.
+type ReservedPrices map[PricingModel]ReservedPrice
+
+// reservedPricingModel returns the pricing model of the lease contract length, offering class, and purchase option
+// term attributes of a reserved term, i.e. 1yr, standard, and No Upfront is reserved-1yr-standard-no-upfront.
+func reservedPricingModel(termAttributes map[string]string) (PricingModel, error) {
+	leaseContractLength := strings.ToLower(termAttributes["LeaseContractLength"])
+	offeringClass := strings.ToLower(termAttributes["OfferingClass"])
+	purchaseOption := strings.ReplaceAll(strings.ToLower(termAttributes["PurchaseOption"]), " ", "-")
+	pricingModel := PricingModel(reservedPricingModelPrefix + strings.Join([]string{leaseContractLength, offeringClass, purchaseOption}, "-"))
+	for _, knownPricingModel := range pricingModel.Values() {
+		if pricingModel == knownPricingModel {
+			return pricingModel, nil
+		}
+	}
+	return "", fmt.Errorf("unknown reserved term %s %s %s", termAttributes["LeaseContractLength"], termAttributes["OfferingClass"], termAttributes["PurchaseOption"])
+}
+
+// termHours returns the number of hours of the lease contract length of a reserved pricing model
+func (m PricingModel) termHours() float64 {
+	leaseContractLength, _, _ := strings.Cut(strings.TrimPrefix(string(m), reservedPricingModelPrefix), "-")
+	years, err := strconv.Atoi(strings.TrimSuffix(leaseContractLength, "yr"))
+	if err != nil {
+		return 0
+	}
+	return float64(years * hoursPerYear)
+}
+
+// parseReservedPrices parses the reserved terms of a price list, skipping terms which are not known pricing models.
+// Terms without a valid price in USD are logged and skipped so that they do not drop the other prices of the instance type.
+func parseReservedPrices(instanceTypeName string, reservedTerms map[string]ProductPricingInfo, logger *log.Logger) ReservedPrices {
+	reservedPrices := ReservedPrices{}
+	for _, term := range reservedTerms {
+		pricingModel, err := reservedPricingModel(term.TermAttributes)
+		if err != nil {
+			continue
+		}
+		reservedPrice, err := parseReservedPrice(pricingModel, term.PriceDimensions)
+		if err != nil {
+			logger.Printf("Skipping %s reserved price of %s: %v", pricingModel, instanceTypeName, err)
+			continue
+		}
+		reservedPrices[pricingModel] = reservedPrice
+	}
+	return reservedPrices
+}
+
+// parseReservedPrice parses the hourly rate and upfront fee of the price dimensions of a reserved term
+func parseReservedPrice(pricingModel PricingModel, priceDimensions map[string]PriceDimensionInfo) (ReservedPrice, error) {
+	reservedPrice := ReservedPrice{}
+	for _, dimension := range priceDimensions {
+		pricePerUnitInUSDStr, ok := dimension.PricePerUnit["USD"]
+		if !ok {
+			return ReservedPrice{}, fmt.Errorf("unable to find %s price per unit in USD", pricingModel)
+		}
+		pricePerUnitInUSD, err := strconv.ParseFloat(pricePerUnitInUSDStr, 64)
+		if err != nil {
+			return ReservedPrice{}, fmt.Errorf("could not convert %s price per unit in USD to a float64", pricingModel)
+		}
+		switch dimension.Unit {
+		case "Hrs":
+			reservedPrice.HourlyRate = pricePerUnitInUSD
+		case "Quantity":
+			reservedPrice.UpfrontFee = pricePerUnitInUSD
+		}
+	}
+	reservedPrice.EffectiveHourlyRate = reservedPrice.HourlyRate + reservedPrice.UpfrontFee/pricingModel.termHours()
+	return reservedPrice, nil
+}
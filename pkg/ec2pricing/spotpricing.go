@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,9 +46,17 @@ type SpotPricing struct {
 	cache          *cache.Cache
 	ec2Client      ec2.DescribeSpotPriceHistoryAPIClient
 	logger         *log.Logger
+	// refreshes are the pricing options and days of spot price history which the periodic refresh job refreshes
+	refreshes map[spotRefresh]bool
 	sync.RWMutex
 }
 
+// spotRefresh is the pricing options and days of spot price history of a spot cache refresh
+type spotRefresh struct {
+	options PricingOptions
+	days    int
+}
+
 type spotPricingEntry struct {
 	Timestamp time.Time
 	SpotPrice float64
@@ -66,6 +75,7 @@ func LoadSpotCacheOrNew(ctx context.Context, ec2Client ec2.DescribeSpotPriceHist
 		ec2Client:      ec2Client,
 		cache:          cache.New(fullRefreshTTL, fullRefreshTTL),
 		logger:         log.New(io.Discard, "", 0),
+		refreshes:      map[spotRefresh]bool{{options: PricingOptions{}.WithDefaults(), days: days}: true},
 	}
 	if fullRefreshTTL <= 0 {
 		if err := spotPricing.Clear(); err != nil {
@@ -75,7 +85,7 @@ func LoadSpotCacheOrNew(ctx context.Context, ec2Client ec2.DescribeSpotPriceHist
 	}
 	gob.Register([]*spotPricingEntry{})
 	// Start the cache refresh job
-	go spotPricing.spotCacheRefreshJob(ctx, days)
+	go spotPricing.spotCacheRefreshJob(ctx)
 	spotCache, err := loadSpotCacheFrom(fullRefreshTTL, region, expandedDirPath)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("a spot pricing cache file could not be loaded: %w", err)
@@ -106,14 +116,22 @@ func getSpotCacheFilePath(region string, directoryPath string) string {
 	return filepath.Join(directoryPath, fmt.Sprintf("%s-%s", region, SpotCacheFileName))
 }
 
-func (c *SpotPricing) spotCacheRefreshJob(ctx context.Context, days int) {
+func (c *SpotPricing) spotCacheRefreshJob(ctx context.Context) {
 	if c.FullRefreshTTL <= 0 {
 		return
 	}
 	refreshTicker := time.NewTicker(c.FullRefreshTTL)
 	for range refreshTicker.C {
-		if err := c.Refresh(ctx, days); err != nil {
-			c.logger.Printf("Periodic Spot Cache Refresh Error: %v", err)
+		c.RLock()
+		refreshes := make([]spotRefresh, 0, len(c.refreshes))
+		for refresh := range c.refreshes {
+			refreshes = append(refreshes, refresh)
+		}
+		c.RUnlock()
+		for _, refresh := range refreshes {
+			if err := c.Refresh(ctx, refresh.options, refresh.days); err != nil {
+				c.logger.Printf("Periodic Spot Cache Refresh Error: %v", err)
+			}
 		}
 	}
 }
@@ -122,15 +140,21 @@ func (c *SpotPricing) SetLogger(logger *log.Logger) {
 	c.logger = logger
 }
 
-func (c *SpotPricing) Refresh(ctx context.Context, days int) error {
+// Refresh fetches the spot price history of the past days of all instance types for the operating system of the options and stores them in the cache.
+func (c *SpotPricing) Refresh(ctx context.Context, options PricingOptions, days int) error {
+	productDescription, err := options.productDescription()
+	if err != nil {
+		return err
+	}
 	c.Lock()
 	defer c.Unlock()
-	spotInstanceTypeCosts, err := c.fetchSpotPricingTimeSeries(ctx, "", days)
+	c.refreshes[spotRefresh{options: options.WithDefaults(), days: days}] = true
+	spotInstanceTypeCosts, err := c.fetchSpotPricingTimeSeries(ctx, productDescription, "", days)
 	if err != nil {
 		return fmt.Errorf("there was a problem refreshing the spot instance type pricing cache: %v", err)
 	}
-	for instanceTypeAndZone, cost := range spotInstanceTypeCosts {
-		c.cache.SetDefault(instanceTypeAndZone, cost)
+	for instanceType, cost := range spotInstanceTypeCosts {
+		c.cache.SetDefault(spotCacheKey(productDescription, days, instanceType), cost)
 	}
 	if err := c.Save(); err != nil {
 		return fmt.Errorf("unable to save the refreshed spot instance type pricing cache file: %v", err)
@@ -138,8 +162,43 @@ func (c *SpotPricing) Refresh(ctx context.Context, days int) error {
 	return nil
 }
 
-func (c *SpotPricing) Get(ctx context.Context, instanceType ec2types.InstanceType, zone string, days int) (float64, error) {
-	entries, ok := c.cache.Get(string(instanceType))
+// Get returns the spot price of the instance type for the operating system of the options, averaged over the past days.
+func (c *SpotPricing) Get(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, zone string, days int) (float64, error) {
+	entries, err := c.getEntries(ctx, instanceType, options, zone, days)
+	if err != nil {
+		return -1, err
+	}
+	return c.calculateSpotAggregate(c.filterOn(zone, entries)), nil
+}
+
+// GetStats returns the statistics of the spot price history of the past days of the instance type for the operating system
+// of the options by availability zone. If zone is empty, the statistics of every zone with spot price history are returned.
+func (c *SpotPricing) GetStats(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, zone string, days int) (map[string]SpotPriceStats, error) {
+	entries, err := c.getEntries(ctx, instanceType, options, zone, days)
+	if err != nil {
+		return nil, err
+	}
+	zonalEntries := map[string][]*spotPricingEntry{}
+	for _, entry := range entries {
+		if zone == "" || entry.Zone == zone {
+			zonalEntries[entry.Zone] = append(zonalEntries[entry.Zone], entry)
+		}
+	}
+	stats := map[string]SpotPriceStats{}
+	for entryZone, entries := range zonalEntries {
+		stats[entryZone] = c.calculateSpotStats(entries)
+	}
+	return stats, nil
+}
+
+// getEntries returns the spot price history of the instance type from the cache, which is fetched if it is not cached or does not include the zone
+func (c *SpotPricing) getEntries(ctx context.Context, instanceType ec2types.InstanceType, options PricingOptions, zone string, days int) ([]*spotPricingEntry, error) {
+	productDescription, err := options.productDescription()
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := spotCacheKey(productDescription, days, string(instanceType))
+	entries, ok := c.cache.Get(cacheKey)
 	if zone != "" && ok {
 		if !c.contains(zone, entries.([]*spotPricingEntry)) {
 			ok = false
@@ -148,20 +207,20 @@ func (c *SpotPricing) Get(ctx context.Context, instanceType ec2types.InstanceTyp
 	if !ok {
 		c.RLock()
 		defer c.RUnlock()
-		zonalSpotPricing, err := c.fetchSpotPricingTimeSeries(ctx, instanceType, days)
+		zonalSpotPricing, err := c.fetchSpotPricingTimeSeries(ctx, productDescription, instanceType, days)
 		if err != nil {
-			return -1, fmt.Errorf("there was a problem fetching spot instance type pricing for %s: %v", instanceType, err)
+			return nil, fmt.Errorf("there was a problem fetching spot instance type pricing for %s: %v", instanceType, err)
 		}
 		for instanceType, costs := range zonalSpotPricing {
-			c.cache.SetDefault(instanceType, costs)
+			c.cache.SetDefault(spotCacheKey(productDescription, days, instanceType), costs)
 		}
 	}
 
-	entries, ok = c.cache.Get(string(instanceType))
+	entries, ok = c.cache.Get(cacheKey)
 	if !ok {
-		return -1, fmt.Errorf("unable to get spot pricing for %s in zone %s for %d days back", instanceType, zone, days)
+		return nil, fmt.Errorf("unable to get spot pricing for %s in zone %s for %d days back", instanceType, zone, days)
 	}
-	return c.calculateSpotAggregate(c.filterOn(zone, entries.([]*spotPricingEntry))), nil
+	return entries.([]*spotPricingEntry), nil
 }
 
 func (c *SpotPricing) contains(zone string, entries []*spotPricingEntry) bool {
@@ -216,6 +275,22 @@ func (c *SpotPricing) Count() int {
 	return c.cache.ItemCount()
 }
 
+// CountWith returns the count of items in the cache for the operating system of the options and the days of spot price history.
+func (c *SpotPricing) CountWith(options PricingOptions, days int) int {
+	productDescription, err := options.productDescription()
+	if err != nil {
+		return 0
+	}
+	prefix := spotCacheKey(productDescription, days, "")
+	count := 0
+	for key := range c.cache.Items() {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *SpotPricing) Save() error {
 	if c.FullRefreshTTL <= 0 || c.Count() == 0 {
 		return nil
@@ -243,8 +318,8 @@ func (c *SpotPricing) Clear() error {
 }
 
 // fetchSpotPricingTimeSeries makes a bulk request to the ec2 api to retrieve all spot instance type pricing for the past n days
-// If instanceType is empty, it will fetch for all instance types.
-func (c *SpotPricing) fetchSpotPricingTimeSeries(ctx context.Context, instanceType ec2types.InstanceType, days int) (map[string][]*spotPricingEntry, error) {
+// for the product description. If instanceType is empty, it will fetch for all instance types.
+func (c *SpotPricing) fetchSpotPricingTimeSeries(ctx context.Context, productDescription string, instanceType ec2types.InstanceType, days int) (map[string][]*spotPricingEntry, error) {
 	start := time.Now()
 	calls := 0
 	defer func() {
@@ -0,0 +1,83 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ec2pricing
+
+import (
+	"math"
+	"sort"
+)
+
+// SpotPriceStats are the statistics of the spot price history of an instance type in an availability zone.
+type SpotPriceStats struct {
+	// Current is the most recent spot price
+	Current float64
+	// Min is the lowest spot price
+	Min float64
+	// Max is the highest spot price
+	Max float64
+	// Mean is the spot price averaged over the time each price was in effect
+	Mean float64
+	// P50 is the median of the spot prices
+	P50 float64
+	// P90 is the 90th percentile of the spot prices
+	P90 float64
+	// StdDev is the standard deviation of the spot price from the mean, weighted by the time each price was in effect
+	StdDev float64
+	// Volatility is the standard deviation relative to the mean, so that it is comparable across instance types
+	Volatility float64
+}
+
+// calculateSpotStats returns the statistics of the spot price entries of a single availability zone
+func (c *SpotPricing) calculateSpotStats(spotPriceEntries []*spotPricingEntry) SpotPriceStats {
+	if len(spotPriceEntries) == 0 {
+		return SpotPriceStats{}
+	}
+	// calculateSpotAggregate sorts the entries by timestamp in descending order
+	mean := c.calculateSpotAggregate(spotPriceEntries)
+	prices := make([]float64, 0, len(spotPriceEntries))
+	for _, entry := range spotPriceEntries {
+		prices = append(prices, entry.SpotPrice)
+	}
+	sort.Float64s(prices)
+	stats := SpotPriceStats{
+		Current: spotPriceEntries[0].SpotPrice,
+		Min:     prices[0],
+		Max:     prices[len(prices)-1],
+		Mean:    mean,
+		P50:     percentile(prices, 50),
+		P90:     percentile(prices, 90),
+	}
+
+	totalDuration := spotPriceEntries[0].Timestamp.Sub(spotPriceEntries[len(spotPriceEntries)-1].Timestamp).Minutes()
+	if totalDuration > 0 {
+		varianceSum := float64(0)
+		for i, entry := range spotPriceEntries {
+			duration := spotPriceEntries[int(math.Max(float64(i-1), 0))].Timestamp.Sub(entry.Timestamp).Minutes()
+			varianceSum += duration * math.Pow(entry.SpotPrice-mean, 2)
+		}
+		stats.StdDev = math.Sqrt(varianceSum / totalDuration)
+	}
+	if mean > 0 {
+		stats.Volatility = stats.StdDev / mean
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank percentile p of sorted values
+func percentile(sortedValues []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sortedValues))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sortedValues[rank-1]
+}
@@ -0,0 +1,81 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ec2pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+func TestCalculateSpotStats(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	spotPriceEntry := func(minutesAgo int, price float64) *spotPricingEntry {
+		return &spotPricingEntry{Timestamp: now.Add(-time.Duration(minutesAgo) * time.Minute), SpotPrice: price, Zone: "us-east-1a"}
+	}
+	for _, tc := range []struct {
+		name     string
+		entries  []*spotPricingEntry
+		expected SpotPriceStats
+	}{
+		{
+			name:     "single price",
+			entries:  []*spotPricingEntry{spotPriceEntry(0, 0.3)},
+			expected: SpotPriceStats{Current: 0.3, Min: 0.3, Max: 0.3, Mean: 0.3, P50: 0.3, P90: 0.3},
+		},
+		{
+			name:     "constant price",
+			entries:  []*spotPricingEntry{spotPriceEntry(120, 0.2), spotPriceEntry(0, 0.2), spotPriceEntry(60, 0.2)},
+			expected: SpotPriceStats{Current: 0.2, Min: 0.2, Max: 0.2, Mean: 0.2, P50: 0.2, P90: 0.2},
+		},
+		{
+			// 0.3 was in effect for 120 minutes, 0.2 for 60 minutes, and 0.1 for 60 minutes before the current price of 0.4
+			// mean = (120*0.3 + 60*0.2 + 60*0.1) / 240 = 0.225
+			// variance = (120*0.075^2 + 60*0.025^2 + 60*0.125^2) / 240 = 0.006875
+			name:    "time weighted",
+			entries: []*spotPricingEntry{spotPriceEntry(240, 0.3), spotPriceEntry(0, 0.4), spotPriceEntry(120, 0.2), spotPriceEntry(60, 0.1)},
+			expected: SpotPriceStats{
+				Current:    0.4,
+				Min:        0.1,
+				Max:        0.4,
+				Mean:       0.225,
+				P50:        0.2,
+				P90:        0.4,
+				StdDev:     0.0829156197588850,
+				Volatility: 0.0829156197588850 / 0.225,
+			},
+		},
+	} {
+		stats := (&SpotPricing{}).calculateSpotStats(tc.entries)
+		h.Equals(t, tc.expected.Current, stats.Current)
+		h.Equals(t, tc.expected.Min, stats.Min)
+		h.Equals(t, tc.expected.Max, stats.Max)
+		h.Equals(t, tc.expected.P50, stats.P50)
+		h.Equals(t, tc.expected.P90, stats.P90)
+		h.Assert(t, math.Abs(tc.expected.Mean-stats.Mean) < 1e-9, "%s: mean should be %v, got %v", tc.name, tc.expected.Mean, stats.Mean)
+		h.Assert(t, math.Abs(tc.expected.StdDev-stats.StdDev) < 1e-9, "%s: standard deviation should be %v, got %v", tc.name, tc.expected.StdDev, stats.StdDev)
+		h.Assert(t, math.Abs(tc.expected.Volatility-stats.Volatility) < 1e-9, "%s: volatility should be %v, got %v", tc.name, tc.expected.Volatility, stats.Volatility)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sortedValues := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	h.Equals(t, 1.0, percentile(sortedValues, 0))
+	h.Equals(t, 5.0, percentile(sortedValues, 50))
+	h.Equals(t, 9.0, percentile(sortedValues, 90))
+	h.Equals(t, 10.0, percentile(sortedValues, 91))
+	h.Equals(t, 10.0, percentile(sortedValues, 100))
+	h.Equals(t, 3.0, percentile([]float64{1, 2, 3, 4, 5}, 50))
+}
@@ -0,0 +1,251 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package instancename parses EC2 instance type names like c7gn.xlarge into the attributes encoded in the name.
+package instancename
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Processor suffixes.
+
+	ProcessorAMD      = "a"
+	ProcessorGraviton = "g"
+	ProcessorIntel    = "i"
+
+	// Capability suffixes.
+
+	CapabilityEBS           = "b"
+	CapabilityInstanceStore = "d"
+	CapabilityExtra         = "e"
+	CapabilityNetwork       = "n"
+	CapabilityHighFreq      = "z"
+	CapabilityFlex          = "flex"
+
+	metalSize = "metal"
+
+	// MetalSizeUnits is the size of a metal instance type without a number of xlarges, which is larger than every other size.
+	// It is finite rather than infinite so that size ranges including metal can be encoded as JSON.
+	MetalSizeUnits = 1e6
+)
+
+var (
+	// familyRE matches the series, generation, and suffixes of an instance family, i.e. c7gn
+	familyRE = regexp.MustCompile(`^([a-z]+)([0-9]+)([a-z]*)$`)
+	// highMemoryVariantRE matches the memory and generation of a high memory family variant, i.e. 6tb1 of u-6tb1
+	highMemoryVariantRE = regexp.MustCompile(`^([0-9]+tb)([0-9]+)$`)
+	sizeRE              = regexp.MustCompile(`^([0-9]*)xlarge$`)
+	metalSizeRE         = regexp.MustCompile(`^metal-([0-9]+)xl$`)
+
+	// sizesBelowXLarge are the sizes smaller than an xlarge, each of which is half the size of the next
+	sizesBelowXLarge = []string{"nano", "micro", "small", "medium", "large"}
+
+	// Attributes are the known processor and capability suffixes of instance type names
+	Attributes = []string{
+		ProcessorAMD, ProcessorGraviton, ProcessorIntel,
+		CapabilityEBS, CapabilityInstanceStore, CapabilityExtra, CapabilityNetwork, CapabilityHighFreq, CapabilityFlex,
+	}
+)
+
+// Name holds the attributes parsed from an instance type name
+type Name struct {
+	// InstanceType is the full instance type name, i.e. c7gn.xlarge
+	InstanceType string
+	// Family is the part of the name before the size, i.e. c7gn
+	Family string
+	// Series is the letters before the generation, i.e. c of c7gn or inf of inf2
+	Series string
+	// Generation is the number following the series, i.e. 7 of c7gn
+	Generation int
+	// Processor is the processor suffix: a for AMD, g for AWS Graviton, i for Intel, or empty
+	Processor string
+	// Capabilities are the suffixes following the processor, i.e. n of c7gn or flex of m7i-flex
+	Capabilities []string
+	// Variant is the hyphenated part of the family which is not a capability, i.e. 12tb of u7i-12tb or m2pro of mac2-m2pro
+	Variant string
+	// Size is the size of the instance type, i.e. xlarge or metal-24xl
+	Size string
+	// Metal is true for bare metal sizes
+	Metal bool
+}
+
+// Parse parses an instance type name like c7gn.xlarge, m7i-flex.large, u-6tb1.metal or mac2-m2pro.metal
+func Parse(instanceType string) (Name, error) {
+	instanceType = strings.ToLower(strings.TrimSpace(instanceType))
+	invalidNameErr := fmt.Errorf("%s is not a valid instance type name", instanceType)
+	family, size, found := strings.Cut(instanceType, ".")
+	if !found || family == "" {
+		return Name{}, invalidNameErr
+	}
+	if _, err := ParseSize(size); err != nil {
+		return Name{}, invalidNameErr
+	}
+	base, variant, _ := strings.Cut(family, "-")
+	// high memory families put the generation after the memory, i.e. u-6tb1
+	if matches := highMemoryVariantRE.FindStringSubmatch(variant); matches != nil && !strings.ContainsAny(base, "0123456789") {
+		base, variant = base+matches[2], matches[1]
+	}
+	matches := familyRE.FindStringSubmatch(base)
+	if matches == nil {
+		return Name{}, invalidNameErr
+	}
+	generation, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return Name{}, invalidNameErr
+	}
+	name := Name{
+		InstanceType: instanceType,
+		Family:       family,
+		Series:       matches[1],
+		Generation:   generation,
+		Size:         size,
+		Metal:        strings.HasPrefix(size, metalSize),
+	}
+	suffixes := matches[3]
+	if suffixes != "" && strings.Contains(ProcessorAMD+ProcessorGraviton+ProcessorIntel, suffixes[:1]) {
+		name.Processor, suffixes = suffixes[:1], suffixes[1:]
+	}
+	for _, suffix := range suffixes {
+		name.Capabilities = append(name.Capabilities, string(suffix))
+	}
+	if variant == CapabilityFlex {
+		name.Capabilities = append(name.Capabilities, CapabilityFlex)
+	} else {
+		name.Variant = variant
+	}
+	return name, nil
+}
+
+// Attributes returns the processor suffix followed by the capability suffixes, i.e. g and n of c7gn
+func (n Name) Attributes() []string {
+	if n.Processor == "" {
+		return n.Capabilities
+	}
+	return append([]string{n.Processor}, n.Capabilities...)
+}
+
+// HasAttributes returns true if the name has every one of the processor or capability suffixes
+func (n Name) HasAttributes(attributes ...string) bool {
+	nameAttributes := n.Attributes()
+	for _, attribute := range attributes {
+		found := false
+		for _, nameAttribute := range nameAttributes {
+			if strings.EqualFold(attribute, nameAttribute) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SizeUnits returns the size of the instance type in multiples of an xlarge
+func (n Name) SizeUnits() float64 {
+	units, _ := ParseSize(n.Size)
+	return units
+}
+
+// ParseSize parses an instance size to multiples of an xlarge: large is 0.5, xlarge is 1 and 4xlarge is 4.
+// A metal size with a number of xlarges like metal-24xl is the same size as a 24xlarge,
+// while metal is MetalSizeUnits, which is larger than every other size since it depends on the family.
+func ParseSize(size string) (float64, error) {
+	size = strings.ToLower(strings.TrimSpace(size))
+	if size == metalSize {
+		return MetalSizeUnits, nil
+	}
+	for i, namedSize := range sizesBelowXLarge {
+		if size == namedSize {
+			return math.Pow(2, float64(i-len(sizesBelowXLarge))), nil
+		}
+	}
+	if matches := sizeRE.FindStringSubmatch(size); matches != nil {
+		if matches[1] == "" {
+			return 1, nil
+		}
+		if xlarges, err := strconv.Atoi(matches[1]); err == nil && xlarges > 0 {
+			return float64(xlarges), nil
+		}
+	}
+	if matches := metalSizeRE.FindStringSubmatch(size); matches != nil {
+		if xlarges, err := strconv.Atoi(matches[1]); err == nil && xlarges > 0 {
+			return float64(xlarges), nil
+		}
+	}
+	return 0, fmt.Errorf("%s is not a valid instance size like large, xlarge, 4xlarge or metal", size)
+}
+
+// Compare naturally compares two instance type names by family and then by size, so that c5.large < c5.xlarge < c5.2xlarge < c5.metal < c5a.large.
+// Families are compared with the numbers in them compared numerically. Names which cannot be parsed are compared as strings after every parsable name.
+func Compare(a string, b string) int {
+	nameA, errA := Parse(a)
+	nameB, errB := Parse(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	if comparison := compareNatural(nameA.Family, nameB.Family); comparison != 0 {
+		return comparison
+	}
+	if comparison := cmp.Compare(nameA.SizeUnits(), nameB.SizeUnits()); comparison != 0 {
+		return comparison
+	}
+	if nameA.Metal != nameB.Metal {
+		if nameA.Metal {
+			return 1
+		}
+		return -1
+	}
+	return strings.Compare(nameA.InstanceType, nameB.InstanceType)
+}
+
+// compareNatural compares two strings with runs of digits compared numerically, i.e. u-6tb1 < u-12tb1
+func compareNatural(a string, b string) int {
+	for a != "" && b != "" {
+		digitsA, digitsB := leadingDigits(a), leadingDigits(b)
+		if digitsA != "" && digitsB != "" {
+			numberA, _ := strconv.Atoi(digitsA)
+			numberB, _ := strconv.Atoi(digitsB)
+			if comparison := cmp.Compare(numberA, numberB); comparison != 0 {
+				return comparison
+			}
+			a, b = a[len(digitsA):], b[len(digitsB):]
+			continue
+		}
+		if comparison := cmp.Compare(a[0], b[0]); comparison != 0 {
+			return comparison
+		}
+		a, b = a[1:], b[1:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	return s[:end]
+}
@@ -0,0 +1,92 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancename_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestParse(t *testing.T) {
+	for instanceType, expected := range map[string]instancename.Name{
+		"c7gn.xlarge":        {Family: "c7gn", Series: "c", Generation: 7, Processor: "g", Capabilities: []string{"n"}, Size: "xlarge"},
+		"m5.large":           {Family: "m5", Series: "m", Generation: 5, Size: "large"},
+		"x2iedn.32xlarge":    {Family: "x2iedn", Series: "x", Generation: 2, Processor: "i", Capabilities: []string{"e", "d", "n"}, Size: "32xlarge"},
+		"m5zn.metal":         {Family: "m5zn", Series: "m", Generation: 5, Capabilities: []string{"z", "n"}, Size: "metal", Metal: true},
+		"m7i-flex.2xlarge":   {Family: "m7i-flex", Series: "m", Generation: 7, Processor: "i", Capabilities: []string{"flex"}, Size: "2xlarge"},
+		"c7i.metal-24xl":     {Family: "c7i", Series: "c", Generation: 7, Processor: "i", Size: "metal-24xl", Metal: true},
+		"u-6tb1.56xlarge":    {Family: "u-6tb1", Series: "u", Generation: 1, Variant: "6tb", Size: "56xlarge"},
+		"u7i-12tb.224xlarge": {Family: "u7i-12tb", Series: "u", Generation: 7, Processor: "i", Variant: "12tb", Size: "224xlarge"},
+		"mac2-m2pro.metal":   {Family: "mac2-m2pro", Series: "mac", Generation: 2, Variant: "m2pro", Size: "metal", Metal: true},
+		"inf2.xlarge":        {Family: "inf2", Series: "inf", Generation: 2, Size: "xlarge"},
+		"t3.nano":            {Family: "t3", Series: "t", Generation: 3, Size: "nano"},
+	} {
+		expected.InstanceType = instanceType
+		name, err := instancename.Parse(instanceType)
+		h.Ok(t, err)
+		h.Equals(t, expected, name)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, instanceType := range []string{"", "c7gn", "c7gn.", ".xlarge", "c.xlarge", "c7gn.huge", "c7gn.0xlarge", "7c.large"} {
+		_, err := instancename.Parse(instanceType)
+		h.Nok(t, err)
+	}
+}
+
+func TestName_HasAttributes(t *testing.T) {
+	name, err := instancename.Parse("c6gdn.xlarge")
+	h.Ok(t, err)
+	h.Equals(t, []string{"g", "d", "n"}, name.Attributes())
+	h.Assert(t, name.HasAttributes("d", "n"), "c6gdn should have the d and n attributes")
+	h.Assert(t, name.HasAttributes("G"), "attributes should be matched case insensitively")
+	h.Assert(t, !name.HasAttributes("d", "z"), "c6gdn should not have the z attribute")
+	h.Assert(t, name.HasAttributes(), "every name has no attributes")
+}
+
+func TestParseSize(t *testing.T) {
+	for size, expected := range map[string]float64{
+		"nano":       1.0 / 32,
+		"micro":      1.0 / 16,
+		"small":      1.0 / 8,
+		"medium":     0.25,
+		"large":      0.5,
+		"xlarge":     1,
+		"4xlarge":    4,
+		"metal-48xl": 48,
+		"metal":      instancename.MetalSizeUnits,
+	} {
+		units, err := instancename.ParseSize(size)
+		h.Ok(t, err)
+		h.Equals(t, expected, units)
+	}
+	_, err := instancename.ParseSize("huge")
+	h.Nok(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	instanceTypes := []string{"c5a.large", "c5.metal", "c10.large", "c5.2xlarge", "not-an-instance-type", "c5.large", "c5.xlarge", "u-12tb1.112xlarge", "u-6tb1.56xlarge", "c7i.24xlarge", "c7i.metal-24xl"}
+	sort.Slice(instanceTypes, func(i, j int) bool {
+		return instancename.Compare(instanceTypes[i], instanceTypes[j]) < 0
+	})
+	h.Equals(t, []string{
+		"c5.large", "c5.xlarge", "c5.2xlarge", "c5.metal", "c5a.large", "c7i.24xlarge", "c7i.metal-24xl", "c10.large",
+		"u-6tb1.56xlarge", "u-12tb1.112xlarge", "not-an-instance-type",
+	}, instanceTypes)
+}
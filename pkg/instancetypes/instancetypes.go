@@ -27,6 +27,8 @@ import (
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/mitchellh/go-homedir"
 	"github.com/patrickmn/go-cache"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
 )
 
 var CacheFileName = "ec2-instance-types.json"
@@ -36,6 +38,63 @@ type Details struct {
 	ec2types.InstanceTypeInfo
 	OndemandPricePerHour *float64
 	SpotPrice            *float64
+	// SpotDiscount is the percentage the spot price is cheaper than the on-demand price, which is nil when either price is not fetched
+	// The spot price of the availability zones selected by the spot AZ strategy is used if the strategy is set
+	SpotDiscount *float64
+	// SpotPriceStats are the statistics of the spot price history by availability zone, which are nil when the spot prices are not fetched
+	SpotPriceStats map[string]ec2pricing.SpotPriceStats `json:",omitempty"`
+	// SpotVolatility is the highest spot price volatility of the availability zones in SpotPriceStats
+	SpotVolatility *float64
+	// SpotPriceByAvailabilityZone is the spot price averaged over the spot price history of each availability zone
+	SpotPriceByAvailabilityZone map[string]float64 `json:",omitempty"`
+	// CheapestSpotAvailabilityZone is the availability zone with the lowest spot price in SpotPriceByAvailabilityZone
+	CheapestSpotAvailabilityZone *string `json:",omitempty"`
+	// ReservedPrices are the reserved instance prices by pricing model, which are nil when the on-demand prices are not fetched
+	ReservedPrices ec2pricing.ReservedPrices `json:",omitempty"`
+	// PricingModelPricePerHour is the hourly price of the pricing model of the filters, which is the effective hourly rate
+	// with the upfront fee spread over the term for reserved instances
+	PricingModelPricePerHour *float64
+	// MemoryGiBPerVCPU is the GiBs of memory per vcpu, which is the memory side of a 1:N vcpus to memory ratio
+	MemoryGiBPerVCPU *float64
+	// Price-performance metrics derived from the hourly prices, which are nil when the price is not fetched
+	// or the instance type does not have the resource
+	OndemandPricePerVCPU         *float64
+	SpotPricePerVCPU             *float64
+	OndemandPricePerGiBMemory    *float64
+	SpotPricePerGiBMemory        *float64
+	OndemandPricePerGPU          *float64
+	SpotPricePerGPU              *float64
+	OndemandPricePerGiBGPUMemory *float64
+	SpotPricePerGiBGPUMemory     *float64
+	OndemandPricePerGbpsNetwork  *float64
+	SpotPricePerGbpsNetwork      *float64
+	Regions                      []RegionDetails `json:",omitempty"`
+	Score                        *Score          `json:",omitempty"`
+	Similarity                   *Similarity     `json:",omitempty"`
+	// InstanceTypeBases are the base instance types the instance type is a candidate for when selecting for multiple base instance types
+	InstanceTypeBases []string `json:",omitempty"`
+}
+
+// Similarity holds the distance of an ec2 instance type to a base instance type and the distance for each compared attribute.
+type Similarity struct {
+	InstanceTypeBase string
+	Distance         float64
+	Attributes       map[string]float64
+}
+
+// Score holds the composite score of an ec2 instance type and the contribution of each weighted attribute to it.
+type Score struct {
+	Total         float64
+	Contributions map[string]float64
+}
+
+// RegionDetails hold the availability and prices of an ec2 instance type within a single region.
+type RegionDetails struct {
+	Region string
+	// Available is true if the instance type is offered in the region and satisfies the filters within the region
+	Available            bool
+	OndemandPricePerHour *float64
+	SpotPrice            *float64
 }
 
 type Provider struct {
@@ -64,32 +64,44 @@ func (itf Selector) TransformBaseInstanceType(ctx context.Context, filters Filte
 		return filters, fmt.Errorf("error instance type %s is not a valid instance type", *filters.InstanceTypeBase)
 	}
 	instanceTypeInfo := instanceTypesOutput.InstanceTypes[0]
+	lowPercentile, highPercentile := AggregateLowPercentile, AggregateHighPercentile
+	if filters.InstanceTypeBaseLowPercentile != nil {
+		lowPercentile = *filters.InstanceTypeBaseLowPercentile
+	}
+	if filters.InstanceTypeBaseHighPercentile != nil {
+		highPercentile = *filters.InstanceTypeBaseHighPercentile
+	}
+	if lowPercentile > highPercentile {
+		return filters, fmt.Errorf("base instance type low percentile %v must not be greater than the high percentile %v", lowPercentile, highPercentile)
+	}
+	// similarity searches rank by the resources rather than filtering on ranges of them
+	isSimilaritySearch := isSimilaritySearch(filters)
 	if filters.BareMetal == nil {
 		filters.BareMetal = instanceTypeInfo.BareMetal
 	}
-	if filters.CPUArchitecture == nil && len(instanceTypeInfo.ProcessorInfo.SupportedArchitectures) == 1 {
+	if filters.CPUArchitecture == nil && !isSimilaritySearch && len(instanceTypeInfo.ProcessorInfo.SupportedArchitectures) == 1 {
 		filters.CPUArchitecture = &instanceTypeInfo.ProcessorInfo.SupportedArchitectures[0]
 	}
 	if filters.Fpga == nil {
 		isFpgaSupported := instanceTypeInfo.FpgaInfo != nil
 		filters.Fpga = &isFpgaSupported
 	}
-	if filters.GpusRange == nil {
+	if filters.GpusRange == nil && !isSimilaritySearch {
 		gpuCount := int32(0)
 		if instanceTypeInfo.GpuInfo != nil {
 			gpuCount = *getTotalGpusCount(instanceTypeInfo.GpuInfo)
 		}
-		filters.GpusRange = &Int32RangeFilter{LowerBound: gpuCount, UpperBound: gpuCount}
+		filters.GpusRange = NewRange(gpuCount, gpuCount)
 	}
-	if filters.MemoryRange == nil {
-		lowerBound := bytequantity.ByteQuantity{Quantity: uint64(float64(*instanceTypeInfo.MemoryInfo.SizeInMiB) * AggregateLowPercentile)}
-		upperBound := bytequantity.ByteQuantity{Quantity: uint64(float64(*instanceTypeInfo.MemoryInfo.SizeInMiB) * AggregateHighPercentile)}
-		filters.MemoryRange = &ByteQuantityRangeFilter{LowerBound: lowerBound, UpperBound: upperBound}
+	if filters.MemoryRange == nil && !isSimilaritySearch {
+		lowerBound := bytequantity.ByteQuantity{Quantity: uint64(float64(*instanceTypeInfo.MemoryInfo.SizeInMiB) * lowPercentile)}
+		upperBound := bytequantity.ByteQuantity{Quantity: uint64(float64(*instanceTypeInfo.MemoryInfo.SizeInMiB) * highPercentile)}
+		filters.MemoryRange = NewRange(lowerBound, upperBound)
 	}
-	if filters.VCpusRange == nil {
-		lowerBound := int32(float32(*instanceTypeInfo.VCpuInfo.DefaultVCpus) * AggregateLowPercentile)
-		upperBound := int32(float32(*instanceTypeInfo.VCpuInfo.DefaultVCpus) * AggregateHighPercentile)
-		filters.VCpusRange = &Int32RangeFilter{LowerBound: lowerBound, UpperBound: upperBound}
+	if filters.VCpusRange == nil && !isSimilaritySearch {
+		lowerBound := int32(float32(*instanceTypeInfo.VCpuInfo.DefaultVCpus) * float32(lowPercentile))
+		upperBound := int32(float32(*instanceTypeInfo.VCpuInfo.DefaultVCpus) * float32(highPercentile))
+		filters.VCpusRange = NewRange(lowerBound, upperBound)
 	}
 	if filters.VirtualizationType == nil && len(instanceTypeInfo.SupportedVirtualizationTypes) == 1 {
 		filters.VirtualizationType = &instanceTypeInfo.SupportedVirtualizationTypes[0]
@@ -99,31 +111,38 @@ func (itf Selector) TransformBaseInstanceType(ctx context.Context, filters Filte
 	return filters, nil
 }
 
-// TransformFlexible transforms lower level filters based on a set of opinions.
+// TransformFlexible transforms lower level filters based on the opinions of the selected flexible profile.
 func (itf Selector) TransformFlexible(ctx context.Context, filters Filters) (Filters, error) {
 	if filters.Flexible == nil {
 		return filters, nil
 	}
+	profileName := DefaultFlexibleProfile
+	if filters.FlexibleProfile != nil && *filters.FlexibleProfile != "" {
+		profileName = *filters.FlexibleProfile
+	}
+	profile, err := itf.flexibleProfile(profileName)
+	if err != nil {
+		return filters, err
+	}
 	if filters.CPUArchitecture == nil {
-		defaultArchitecture := ec2types.ArchitectureTypeX8664
-		filters.CPUArchitecture = &defaultArchitecture
+		filters.CPUArchitecture = profile.CPUArchitecture
 	}
 	if filters.BareMetal == nil {
-		bareMetalDefault := false
-		filters.BareMetal = &bareMetalDefault
+		filters.BareMetal = profile.BareMetal
 	}
 	if filters.Fpga == nil {
-		fpgaDefault := false
-		filters.Fpga = &fpgaDefault
+		filters.Fpga = profile.Fpga
 	}
-
 	if filters.AllowList == nil {
-		filters.AllowList = baseAllowedInstanceTypesRE
+		filters.AllowList = profile.AllowList
+	}
+	if filters.DenyList == nil {
+		filters.DenyList = profile.DenyList
 	}
 
 	if filters.VCpusRange == nil && filters.MemoryRange == nil {
-		defaultVcpus := int32(4)
-		filters.VCpusRange = &Int32RangeFilter{LowerBound: defaultVcpus, UpperBound: defaultVcpus}
+		filters.VCpusRange = profile.VCpusRange
+		filters.MemoryRange = profile.MemoryRange
 	}
 
 	return filters, nil
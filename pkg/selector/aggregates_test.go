@@ -16,6 +16,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
 	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
 )
@@ -40,7 +43,7 @@ func TestTransformBaseInstanceType(t *testing.T) {
 	h.Assert(t, *filters.BareMetal == false, " should filter out bare metal instances")
 	h.Assert(t, *filters.Fpga == false, "should filter out FPGA instances")
 	h.Assert(t, *filters.CPUArchitecture == "x86_64", "should only return x86_64 instance types")
-	h.Assert(t, filters.GpusRange.LowerBound == 0 && filters.GpusRange.UpperBound == 0, "should only return non-gpu instance types")
+	h.Equals(t, selector.NewRange[int32](0, 0), filters.GpusRange)
 }
 
 func TestTransformBaseInstanceTypeWithGPU(t *testing.T) {
@@ -61,7 +64,7 @@ func TestTransformBaseInstanceTypeWithGPU(t *testing.T) {
 	h.Assert(t, *filters.BareMetal == false, " should filter out bare metal instances")
 	h.Assert(t, *filters.Fpga == false, "should filter out FPGA instances")
 	h.Assert(t, *filters.CPUArchitecture == "x86_64", "should only return x86_64 instance types")
-	h.Assert(t, filters.GpusRange.LowerBound == 1 && filters.GpusRange.UpperBound == 1, "should only return gpu instance types")
+	h.Equals(t, selector.NewRange[int32](1, 1), filters.GpusRange)
 }
 
 func TestTransformFamilyFlexibile(t *testing.T) {
@@ -77,3 +80,45 @@ func TestTransformFamilyFlexibile(t *testing.T) {
 	h.Assert(t, *filters.Fpga == false, "should filter out FPGA instances")
 	h.Assert(t, *filters.CPUArchitecture == "x86_64", "should only return x86_64 instance types")
 }
+
+func TestTransformBaseInstanceType_Percentiles(t *testing.T) {
+	ec2Mock := mockedEC2{
+		DescribeInstanceTypesResp: setupMock(t, describeInstanceTypes, "c4_large.json").DescribeInstanceTypesResp,
+	}
+	itf := selector.Selector{
+		EC2: ec2Mock,
+	}
+	filters := selector.Filters{
+		InstanceTypeBase:               aws.String("c4.large"),
+		InstanceTypeBaseLowPercentile:  aws.Float64(0.5),
+		InstanceTypeBaseHighPercentile: aws.Float64(2),
+	}
+	ctx := context.Background()
+	transformedFilters, err := itf.TransformBaseInstanceType(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange[int32](1, 4), transformedFilters.VCpusRange)
+	h.Equals(t, selector.NewRange(bytequantity.FromMiB(1920), bytequantity.FromMiB(7680)), transformedFilters.MemoryRange)
+
+	filters.InstanceTypeBaseLowPercentile = aws.Float64(3)
+	_, err = itf.TransformBaseInstanceType(ctx, filters)
+	h.Nok(t, err)
+}
+
+func TestTransformBaseInstanceType_Similarity(t *testing.T) {
+	ec2Mock := mockedEC2{
+		DescribeInstanceTypesResp: setupMock(t, describeInstanceTypes, "c4_large.json").DescribeInstanceTypesResp,
+	}
+	itf := selector.Selector{
+		EC2: ec2Mock,
+	}
+	filters := selector.Filters{
+		InstanceTypeBase:           aws.String("c4.large"),
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+	}
+	ctx := context.Background()
+	filters, err := itf.TransformBaseInstanceType(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, *filters.BareMetal == false, " should filter out bare metal instances")
+	h.Assert(t, filters.VCpusRange == nil && filters.MemoryRange == nil && filters.GpusRange == nil, "should not filter on ranges of the base instance type's resources")
+	h.Assert(t, filters.CPUArchitecture == nil, "should not filter on the base instance type's architecture")
+}
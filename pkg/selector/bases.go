@@ -0,0 +1,109 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// InstanceTypeBaseResults holds the instance types selected as candidates for a single base instance type.
+type InstanceTypeBaseResults struct {
+	InstanceTypeBase    string
+	InstanceTypes       []*instancetypes.Details
+	NumOfItemsTruncated int
+}
+
+// FilterInstanceTypeBases accepts a Filters struct with InstanceTypeBases and selects the candidates for each base instance type
+// as if InstanceTypeBase were set to it, returning the results in the order of InstanceTypeBases.
+// MaxResults is applied to each base instance type and the InstanceTypeBases of each candidate lists every base instance type
+// it is a candidate for.
+func (s Selector) FilterInstanceTypeBases(ctx context.Context, filters Filters) ([]InstanceTypeBaseResults, error) {
+	if filters.InstanceTypeBases == nil || len(*filters.InstanceTypeBases) == 0 {
+		return nil, fmt.Errorf("at least one base instance type is required")
+	}
+	results, err := s.filterInstanceTypeBases(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].InstanceTypes, results[i].NumOfItemsTruncated = s.truncateResults(filters.MaxResults, results[i].InstanceTypes)
+	}
+	AnnotateInstanceTypeBases(results)
+	return results, nil
+}
+
+// AnnotateInstanceTypeBases sets the InstanceTypeBases of each instance type in the results to the base instance types
+// it is a candidate for, so that instance types which are candidates for more than one base instance type can be identified.
+func AnnotateInstanceTypeBases(results []InstanceTypeBaseResults) {
+	instanceTypeBases := map[string][]string{}
+	for _, result := range results {
+		for _, instanceTypeInfo := range result.InstanceTypes {
+			instanceTypeName := string(instanceTypeInfo.InstanceType)
+			instanceTypeBases[instanceTypeName] = append(instanceTypeBases[instanceTypeName], result.InstanceTypeBase)
+		}
+	}
+	for _, result := range results {
+		for _, instanceTypeInfo := range result.InstanceTypes {
+			instanceTypeInfo.InstanceTypeBases = instanceTypeBases[string(instanceTypeInfo.InstanceType)]
+		}
+	}
+}
+
+// filterInstanceTypeBases selects the candidates for each of the InstanceTypeBases without truncating the results.
+func (s Selector) filterInstanceTypeBases(ctx context.Context, filters Filters) ([]InstanceTypeBaseResults, error) {
+	if filters.InstanceTypeBase != nil {
+		return nil, fmt.Errorf("InstanceTypeBase cannot be combined with InstanceTypeBases")
+	}
+	results := []InstanceTypeBaseResults{}
+	for _, instanceTypeBase := range *filters.InstanceTypeBases {
+		instanceTypeBase := instanceTypeBase
+		baseFilters := filters
+		baseFilters.InstanceTypeBases = nil
+		baseFilters.InstanceTypeBase = &instanceTypeBase
+		instanceTypeInfoSlice, err := s.rawFilter(ctx, baseFilters)
+		if err != nil {
+			return nil, fmt.Errorf("unable to select instance types for base instance type %s: %w", instanceTypeBase, err)
+		}
+		results = append(results, InstanceTypeBaseResults{
+			InstanceTypeBase: instanceTypeBase,
+			InstanceTypes:    instanceTypeInfoSlice,
+		})
+	}
+	return results, nil
+}
+
+// rawFilterInstanceTypeBases selects the candidates for any of the InstanceTypeBases annotated with the base instance types
+// they are a candidate for.
+func (s Selector) rawFilterInstanceTypeBases(ctx context.Context, filters Filters) ([]*instancetypes.Details, error) {
+	results, err := s.filterInstanceTypeBases(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	AnnotateInstanceTypeBases(results)
+	instanceTypes := map[string]*instancetypes.Details{}
+	for _, result := range results {
+		for _, instanceTypeInfo := range result.InstanceTypes {
+			if _, ok := instanceTypes[string(instanceTypeInfo.InstanceType)]; !ok {
+				instanceTypes[string(instanceTypeInfo.InstanceType)] = instanceTypeInfo
+			}
+		}
+	}
+	instanceTypeInfoSlice := []*instancetypes.Details{}
+	for _, instanceTypeInfo := range instanceTypes {
+		instanceTypeInfoSlice = append(instanceTypeInfoSlice, instanceTypeInfo)
+	}
+	return sortInstanceTypeInfo(instanceTypeInfoSlice), nil
+}
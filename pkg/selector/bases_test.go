@@ -0,0 +1,86 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector/outputs"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestFilterInstanceTypeBases(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		InstanceTypeBases:          &[]string{"c4.large", "c4.xlarge"},
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+		MaxResults:                 aws.Int(3),
+	}
+	ctx := context.Background()
+	results, err := itf.FilterInstanceTypeBases(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 2, len(results))
+	h.Equals(t, "c4.large", results[0].InstanceTypeBase)
+	h.Equals(t, []string{"c4.large", "c4.xlarge", "c3.large"}, outputs.SimpleInstanceTypeOutput(results[0].InstanceTypes))
+	h.Equals(t, 21, results[0].NumOfItemsTruncated)
+	h.Equals(t, "c4.xlarge", results[1].InstanceTypeBase)
+	h.Equals(t, []string{"c4.xlarge", "c4.2xlarge", "c4.large"}, outputs.SimpleInstanceTypeOutput(results[1].InstanceTypes))
+	h.Equals(t, []string{"c4.large", "c4.xlarge"}, results[0].InstanceTypes[1].InstanceTypeBases)
+	h.Equals(t, []string{"c4.large"}, results[0].InstanceTypes[2].InstanceTypeBases)
+	h.Equals(t, []string{"c4.xlarge"}, results[1].InstanceTypes[1].InstanceTypeBases)
+}
+
+func TestFilter_InstanceTypeBases(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		InstanceTypeBases: &[]string{"c4.large", "c4.xlarge"},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, len(results) > 0, "Should return at least 1 instance type")
+	for _, result := range results {
+		h.Assert(t, len(result.InstanceTypeBases) > 0, "%s should be annotated with its base instance types", result.InstanceType)
+	}
+}
+
+func TestFilterInstanceTypeBases_Errors(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	ctx := context.Background()
+
+	_, err := itf.FilterInstanceTypeBases(ctx, selector.Filters{})
+	h.Nok(t, err)
+
+	_, err = itf.FilterInstanceTypeBases(ctx, selector.Filters{
+		InstanceTypeBase:  aws.String("c4.large"),
+		InstanceTypeBases: &[]string{"c4.large", "c4.xlarge"},
+	})
+	h.Nok(t, err)
+
+	_, err = itf.FilterInstanceTypeBases(ctx, selector.Filters{
+		InstanceTypeBases:          &[]string{"c4.large", "m5.bogus"},
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+	})
+	h.Nok(t, err)
+
+	_, err = itf.Explain(ctx, selector.Filters{
+		InstanceTypeBases: &[]string{"c4.large", "c4.xlarge"},
+	})
+	h.Nok(t, err)
+}
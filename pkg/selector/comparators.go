@@ -21,6 +21,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
 )
 
 const (
@@ -50,13 +52,21 @@ func isSupportedFromStrings(instanceTypeValues []*string, target *string) bool {
 	return contains(instanceTypeValues, *target)
 }
 
-func isSupportedWithRangeInt(instanceTypeValue *int, target *IntRangeFilter) bool {
-	var instanceTypeValueInt64 *int64
-	if instanceTypeValue != nil {
-		nonPtr := int64(*instanceTypeValue)
-		instanceTypeValueInt64 = &nonPtr
+// isSupportedFromAllStrings returns true if every target is one of the instance type values
+func isSupportedFromAllStrings(instanceTypeValues []*string, targets *[]string) bool {
+	if targets == nil {
+		return true
 	}
-	return isSupportedWithRangeInt64(instanceTypeValueInt64, target)
+	for _, target := range *targets {
+		if !contains(instanceTypeValues, target) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSupportedWithRangeInt(instanceTypeValue *int, target *Range[int]) bool {
+	return isSupportedWithRange(instanceTypeValue, target)
 }
 
 func isSupportedWithFloat64(instanceTypeValue *float64, target *float64) bool {
@@ -170,51 +180,41 @@ func isMatchingCpuArchitecture(instanceTypeValue CPUManufacturer, target *CPUMan
 	return false
 }
 
-func isSupportedWithRangeInt64(instanceTypeValue *int64, target *IntRangeFilter) bool {
+// isSupportedWithRange returns true if the instance type value is within the target range.
+// A missing instance type value is only supported when the target range is exactly zero.
+func isSupportedWithRange[T RangeValue](instanceTypeValue *T, target *Range[T]) bool {
 	if target == nil {
 		return true
-	} else if instanceTypeValue == nil && target.LowerBound == 0 && target.UpperBound == 0 {
-		return true
 	} else if instanceTypeValue == nil {
-		return false
+		return target.isZero()
 	}
-	return int(*instanceTypeValue) >= target.LowerBound && int(*instanceTypeValue) <= target.UpperBound
+	return target.Contains(*instanceTypeValue)
 }
 
-func isSupportedWithRangeInt32(instanceTypeValue *int32, target *Int32RangeFilter) bool {
-	if target == nil {
-		return true
-	} else if instanceTypeValue == nil && target.LowerBound == 0 && target.UpperBound == 0 {
-		return true
-	} else if instanceTypeValue == nil {
-		return false
+func isSupportedWithRangeInt64(instanceTypeValue *int64, target *Range[int]) bool {
+	var instanceTypeValueInt *int
+	if instanceTypeValue != nil {
+		nonPtr := int(*instanceTypeValue)
+		instanceTypeValueInt = &nonPtr
 	}
-	return *instanceTypeValue >= target.LowerBound && *instanceTypeValue <= target.UpperBound
+	return isSupportedWithRange(instanceTypeValueInt, target)
 }
 
-func isSupportedWithRangeUint64(instanceTypeValue *int64, target *Uint64RangeFilter) bool {
-	if target == nil {
-		return true
-	} else if instanceTypeValue == nil && target.LowerBound == 0 && target.UpperBound == 0 {
-		return true
-	} else if instanceTypeValue == nil {
-		return false
-	}
-	if target.UpperBound > math.MaxInt64 {
-		target.UpperBound = math.MaxInt64
-	}
-	return uint64(*instanceTypeValue) >= target.LowerBound && uint64(*instanceTypeValue) <= target.UpperBound
+func isSupportedWithRangeInt32(instanceTypeValue *int32, target *Range[int32]) bool {
+	return isSupportedWithRange(instanceTypeValue, target)
 }
 
-func isSupportedWithRangeFloat64(instanceTypeValue *float64, target *Float64RangeFilter) bool {
-	if target == nil {
-		return true
-	} else if instanceTypeValue == nil && target.LowerBound == 0.0 && target.UpperBound == 0.0 {
-		return true
-	} else if instanceTypeValue == nil {
-		return false
+func isSupportedWithRangeUint64(instanceTypeValue *int64, target *Range[uint64]) bool {
+	var instanceTypeValueUint64 *uint64
+	if instanceTypeValue != nil {
+		nonPtr := uint64(*instanceTypeValue)
+		instanceTypeValueUint64 = &nonPtr
 	}
-	return float64(*instanceTypeValue) >= target.LowerBound && float64(*instanceTypeValue) <= target.UpperBound
+	return isSupportedWithRange(instanceTypeValueUint64, target)
+}
+
+func isSupportedWithRangeFloat64(instanceTypeValue *float64, target *Range[float64]) bool {
+	return isSupportedWithRange(instanceTypeValue, target)
 }
 
 func isSupportedWithBool(instanceTypeValue *bool, target *bool) bool {
@@ -377,18 +377,54 @@ func getEBSOptimizedBaselineIOPS(ebsInfo *ec2types.EbsInfo) *int32 {
 
 // getInstanceTypeGeneration returns the generation from an instance type name
 // i.e. c7i.xlarge -> 7
+// names which can not be parsed fall back to the first number following the letters of the name (i.e. mac-m4.metal -> 4)
 // if any error occurs, 0 will be returned.
 func getInstanceTypeGeneration(instanceTypeName string) *int {
-	zero := 0
+	name, err := instancename.Parse(instanceTypeName)
+	if err == nil {
+		return &name.Generation
+	}
 	matches := generationRE.FindStringSubmatch(instanceTypeName)
 	if len(matches) < 2 {
-		return &zero
+		return aws.Int(0)
 	}
-	gen, err := strconv.Atoi(matches[1])
+	generation, err := strconv.Atoi(matches[1])
 	if err != nil {
-		return &zero
+		return aws.Int(0)
 	}
-	return &gen
+	return &generation
+}
+
+// getInstanceTypeFamily returns the family filtered on which case-insensitively matches the instance family or series, and the series otherwise,
+// so that filtering on either c, c7gn or C7GN selects c7gn.xlarge. An empty family is returned if the name could not be parsed.
+func getInstanceTypeFamily(families *[]string, name instancename.Name, nameErr error) *string {
+	if nameErr != nil {
+		return aws.String("")
+	}
+	if families != nil {
+		for _, family := range *families {
+			if strings.EqualFold(family, name.Family) || strings.EqualFold(family, name.Series) {
+				return aws.String(family)
+			}
+		}
+	}
+	return &name.Series
+}
+
+// getInstanceTypeNameAttributes returns the processor and capability suffixes of the instance type name
+func getInstanceTypeNameAttributes(name instancename.Name, nameErr error) []*string {
+	if nameErr != nil {
+		return nil
+	}
+	return aws.StringSlice(name.Attributes())
+}
+
+// getInstanceTypeSize returns the size of the instance type in multiples of an xlarge
+func getInstanceTypeSize(name instancename.Name, nameErr error) *float64 {
+	if nameErr != nil {
+		return nil
+	}
+	return aws.Float64(name.SizeUnits())
 }
 
 // supportSyntaxToBool takes an instance spec field that uses ["unsupported", "supported", "required", or "default"]
@@ -412,6 +448,15 @@ func calculateVCpusToMemoryRatio(vcpusVal *int32, memoryVal *int64) *float64 {
 	return &result
 }
 
+// calculateMemoryGiBPerVCPU returns the unrounded GiBs of memory per vcpu, which is used to range filter the vcpus to memory ratio
+func calculateMemoryGiBPerVCPU(vcpusVal *int32, memoryVal *int64) *float64 {
+	if vcpusVal == nil || *vcpusVal == 0 || memoryVal == nil {
+		return nil
+	}
+	result := float64(*memoryVal) / 1024 / float64(*vcpusVal)
+	return &result
+}
+
 // Slice helper function
 
 func contains(slice []*string, target string) bool {
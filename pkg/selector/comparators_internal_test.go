@@ -80,115 +80,115 @@ func TestIsSupportedWithBool_Unsupported(t *testing.T) {
 }
 
 func TestIsSupportedWithRangeInt_SupportedExact(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 4, UpperBound: 4}
-	isSupported := isSupportedWithRangeInt(aws.Int(4), &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match exactly")
+	target := NewRange(4, 4)
+	isSupported := isSupportedWithRangeInt(aws.Int(4), target)
+	h.Assert(t, isSupported == true, "Range should match exactly")
 }
 
 func TestIsSupportedWithRangeInt_SupportedAround(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 2, UpperBound: 6}
-	isSupported := isSupportedWithRangeInt(aws.Int(4), &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with lower and upper bound around the desired source")
+	target := NewRange(2, 6)
+	isSupported := isSupportedWithRangeInt(aws.Int(4), target)
+	h.Assert(t, isSupported == true, "Range should match with lower and upper bound around the desired source")
 }
 
 func TestIsSupportedWithRangeInt_Nil(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 2, UpperBound: 6}
-	isSupported := isSupportedWithRangeInt(nil, &target)
-	h.Assert(t, isSupported == false, "IntRangeFilter should NOT match with nil source")
+	target := NewRange(2, 6)
+	isSupported := isSupportedWithRangeInt(nil, target)
+	h.Assert(t, isSupported == false, "Range should NOT match with nil source")
 }
 
 func TestIsSupportedWithRangeInt_NilTarget(t *testing.T) {
 	isSupported := isSupportedWithRangeInt(aws.Int(4), nil)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with nil target")
+	h.Assert(t, isSupported == true, "Range should match with nil target")
 }
 
 func TestIsSupportedWithRangeInt_BothNil(t *testing.T) {
 	isSupported := isSupportedWithRangeInt(nil, nil)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with nil target and nil source")
+	h.Assert(t, isSupported == true, "Range should match with nil target and nil source")
 }
 
 func TestIsSupportedWithRangeInt_SourceNilTarget0(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 0, UpperBound: 0}
-	isSupported := isSupportedWithRangeInt(nil, &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with 0 target and nil source")
+	target := NewRange(0, 0)
+	isSupported := isSupportedWithRangeInt(nil, target)
+	h.Assert(t, isSupported == true, "Range should match with 0 target and nil source")
 }
 
 // ==================
 
 func TestIsSupportedWithRangeInt64_SupportedExact(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 4, UpperBound: 4}
-	isSupported := isSupportedWithRangeInt64(aws.Int64(4), &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match exactly")
+	target := NewRange(4, 4)
+	isSupported := isSupportedWithRangeInt64(aws.Int64(4), target)
+	h.Assert(t, isSupported == true, "Range should match exactly")
 }
 
 func TestIsSupportedWithRangeInt64_SupportedAround(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 2, UpperBound: 6}
-	isSupported := isSupportedWithRangeInt64(aws.Int64(4), &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with lower and upper bound around the desired source")
+	target := NewRange(2, 6)
+	isSupported := isSupportedWithRangeInt64(aws.Int64(4), target)
+	h.Assert(t, isSupported == true, "Range should match with lower and upper bound around the desired source")
 }
 
 func TestIsSupportedWithRangeInt64_Nil(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 2, UpperBound: 6}
-	isSupported := isSupportedWithRangeInt64(nil, &target)
-	h.Assert(t, isSupported == false, "IntRangeFilter should NOT match with nil source")
+	target := NewRange(2, 6)
+	isSupported := isSupportedWithRangeInt64(nil, target)
+	h.Assert(t, isSupported == false, "Range should NOT match with nil source")
 }
 
 func TestIsSupportedWithRangeInt64_NilTarget(t *testing.T) {
 	isSupported := isSupportedWithRangeInt64(aws.Int64(4), nil)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with nil target")
+	h.Assert(t, isSupported == true, "Range should match with nil target")
 }
 
 func TestIsSupportedWithRangeInt64_BothNil(t *testing.T) {
 	isSupported := isSupportedWithRangeInt64(nil, nil)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with nil target and nil source")
+	h.Assert(t, isSupported == true, "Range should match with nil target and nil source")
 }
 
 func TestIsSupportedWithRangeInt64_SourceNilTarget0(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 0, UpperBound: 0}
-	isSupported := isSupportedWithRangeInt64(nil, &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match with 0 target and nil source")
+	target := NewRange(0, 0)
+	isSupported := isSupportedWithRangeInt64(nil, target)
+	h.Assert(t, isSupported == true, "Range should match with 0 target and nil source")
 }
 
 // uint64
 
 func TestIsSupportedWithRangeUint64_SupportedExact(t *testing.T) {
-	target := IntRangeFilter{LowerBound: 4, UpperBound: 4}
-	isSupported := isSupportedWithRangeInt64(aws.Int64(4), &target)
-	h.Assert(t, isSupported == true, "IntRangeFilter should match exactly")
+	target := NewRange(4, 4)
+	isSupported := isSupportedWithRangeInt64(aws.Int64(4), target)
+	h.Assert(t, isSupported == true, "Range should match exactly")
 }
 
 func TestIsSupportedWithRangeUint64_SupportedAround(t *testing.T) {
-	target := Uint64RangeFilter{LowerBound: 2, UpperBound: 6}
-	isSupported := isSupportedWithRangeUint64(aws.Int64(4), &target)
-	h.Assert(t, isSupported == true, "UintRangeFilter should match with lower and upper bound around the desired source")
+	target := NewRange[uint64](2, 6)
+	isSupported := isSupportedWithRangeUint64(aws.Int64(4), target)
+	h.Assert(t, isSupported == true, "Range should match with lower and upper bound around the desired source")
 }
 
 func TestIsSupportedWithRangeUint64_Nil(t *testing.T) {
-	target := Uint64RangeFilter{LowerBound: 2, UpperBound: 6}
-	isSupported := isSupportedWithRangeUint64(nil, &target)
-	h.Assert(t, isSupported == false, "Uint64RangeFilter should NOT match with nil source")
+	target := NewRange[uint64](2, 6)
+	isSupported := isSupportedWithRangeUint64(nil, target)
+	h.Assert(t, isSupported == false, "Range should NOT match with nil source")
 }
 
 func TestIsSupportedWithRangeUint64_NilTarget(t *testing.T) {
 	isSupported := isSupportedWithRangeUint64(aws.Int64(4), nil)
-	h.Assert(t, isSupported == true, "Uint64RangeFilter should match with nil target")
+	h.Assert(t, isSupported == true, "Range should match with nil target")
 }
 
 func TestIsSupportedWithRangeUint64_BothNil(t *testing.T) {
 	isSupported := isSupportedWithRangeUint64(nil, nil)
-	h.Assert(t, isSupported == true, "Uint64RangeFilter should match with nil target and nil source")
+	h.Assert(t, isSupported == true, "Range should match with nil target and nil source")
 }
 
 func TestIsSupportedWithRangeUint64_SourceNilTarget0(t *testing.T) {
-	target := Uint64RangeFilter{LowerBound: 0, UpperBound: 0}
-	isSupported := isSupportedWithRangeUint64(nil, &target)
-	h.Assert(t, isSupported == true, "Uint64RangeFilter should match with 0 target and nil source")
+	target := NewRange[uint64](0, 0)
+	isSupported := isSupportedWithRangeUint64(nil, target)
+	h.Assert(t, isSupported == true, "Range should match with 0 target and nil source")
 }
 
 func TestIsSupportedWithRangeUint64_Overflow(t *testing.T) {
-	target := Uint64RangeFilter{LowerBound: 0, UpperBound: math.MaxUint64}
-	isSupported := isSupportedWithRangeUint64(aws.Int64(4), &target)
-	h.Assert(t, isSupported == true, "Uint64RangeFilter should match with 0 - MAX target and source 4")
+	target := NewRange[uint64](0, math.MaxUint64)
+	isSupported := isSupportedWithRangeUint64(aws.Int64(4), target)
+	h.Assert(t, isSupported == true, "Range should match with 0 - MAX target and source 4")
 }
 
 // float64
@@ -323,3 +323,10 @@ func TestGetNetworkPerformance(t *testing.T) {
 	netPerformance = getNetworkPerformance(aws.String("abcd"))
 	h.Assert(t, *netPerformance == -1, "Networking performance should parse properly when an arbitrary string is passed")
 }
+
+func TestGetInstanceTypeGeneration(t *testing.T) {
+	h.Equals(t, 7, *getInstanceTypeGeneration("c7i.xlarge"))
+	h.Equals(t, 2, *getInstanceTypeGeneration("mac2-m2pro.metal"))
+	h.Equals(t, 4, *getInstanceTypeGeneration("mac-m4.metal"))
+	h.Equals(t, 0, *getInstanceTypeGeneration("metal"))
+}
@@ -0,0 +1,137 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// CustomFilterKeyPrefix namespaces the custom filter names reported by diagnostics so that they do not collide with the built-in filter keys.
+const CustomFilterKeyPrefix = "custom:"
+
+// CustomFilter is used to write custom filters which are evaluated against every instance type.
+type CustomFilter interface {
+	// Matches returns true if the instance type matches the filter value from Filters.CustomFilters
+	Matches(instanceTypeInfo *instancetypes.Details, filterValue interface{}) (bool, error)
+}
+
+// CustomFilterFn is the func type definition for the CustomFilter interface.
+type CustomFilterFn func(instanceTypeInfo *instancetypes.Details, filterValue interface{}) (bool, error)
+
+// Matches implements the CustomFilter interface on CustomFilterFn
+// This allows any CustomFilterFn to be passed into funcs accepting the CustomFilter interface.
+func (fn CustomFilterFn) Matches(instanceTypeInfo *instancetypes.Details, filterValue interface{}) (bool, error) {
+	return fn(instanceTypeInfo, filterValue)
+}
+
+// FilterRegistry is used to register custom filters which are set by name in Filters.CustomFilters.
+type FilterRegistry struct {
+	filters map[string]CustomFilter
+}
+
+// NewFilterRegistry creates a new instance of a FilterRegistry.
+func NewFilterRegistry() FilterRegistry {
+	return FilterRegistry{
+		filters: make(map[string]CustomFilter),
+	}
+}
+
+// Register takes a filter name and CustomFilter implementation that will be evaluated when the name is set in Filters.CustomFilters.
+func (fr *FilterRegistry) Register(name string, filter CustomFilter) error {
+	if fr.filters == nil {
+		fr.filters = make(map[string]CustomFilter)
+	}
+	if name == "" {
+		return fmt.Errorf("a custom filter must have a name")
+	}
+	if filter == nil {
+		return fmt.Errorf("custom filter %s must not be nil", name)
+	}
+	if _, ok := fr.filters[name]; ok {
+		return fmt.Errorf("custom filter %s is already registered", name)
+	}
+	fr.filters[name] = filter
+	return nil
+}
+
+// Names returns the names of the registered custom filters sorted alphabetically.
+func (fr *FilterRegistry) Names() []string {
+	names := []string{}
+	for name := range fr.filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validate returns an error if a custom filter set in the filters is not registered.
+func (fr *FilterRegistry) validate(filters Filters) error {
+	if filters.CustomFilters == nil {
+		return nil
+	}
+	for name := range *filters.CustomFilters {
+		if _, ok := fr.filters[name]; !ok {
+			return fmt.Errorf("custom filter %s is not registered, must be one of %v", name, fr.Names())
+		}
+	}
+	return nil
+}
+
+// customFilterValue pairs a custom filter with the value it is evaluated with.
+type customFilterValue struct {
+	filter CustomFilter
+	value  interface{}
+}
+
+// String returns the filter value so that custom filters are described by their value.
+func (c *customFilterValue) String() string {
+	return fmt.Sprintf("%v", c.value)
+}
+
+// addCustomFilterPairs adds a filter pair for each custom filter set in the filters to the filter mapping.
+// Custom filters with a nil value are not set and are skipped like the built-in filters.
+func (fr *FilterRegistry) addCustomFilterPairs(filters Filters, instanceTypeInfo *instancetypes.Details, filterToInstanceSpecMappingPairs map[string]filterPair) {
+	if filters.CustomFilters == nil {
+		return
+	}
+	for name, value := range *filters.CustomFilters {
+		filter, ok := fr.filters[name]
+		if !ok || isNilValue(value) {
+			continue
+		}
+		filterToInstanceSpecMappingPairs[CustomFilterKeyPrefix+name] = filterPair{&customFilterValue{filter: filter, value: value}, instanceTypeInfo}
+	}
+}
+
+// reportedFilterValue returns the value of custom filters and the filter value of built-in filters for diagnostic reports.
+func reportedFilterValue(filterValue interface{}) interface{} {
+	if customFilter, ok := filterValue.(*customFilterValue); ok {
+		return customFilter.value
+	}
+	return filterValue
+}
+
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		return reflect.ValueOf(value).IsNil()
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+// familyFilter matches instance types in the family passed as the filter value.
+func familyFilter(instanceTypeInfo *instancetypes.Details, filterValue interface{}) (bool, error) {
+	family, ok := filterValue.(*string)
+	if !ok {
+		return false, errors.New("family filter value must be a *string")
+	}
+	return strings.HasPrefix(string(instanceTypeInfo.InstanceType), *family+"."), nil
+}
+
+// Tests
+
+func TestFilterRegistry_Register(t *testing.T) {
+	registry := selector.NewFilterRegistry()
+	h.Ok(t, registry.Register("family", selector.CustomFilterFn(familyFilter)))
+	h.Nok(t, registry.Register("family", selector.CustomFilterFn(familyFilter)))
+	h.Nok(t, registry.Register("", selector.CustomFilterFn(familyFilter)))
+	h.Nok(t, registry.Register("nil-filter", nil))
+	h.Equals(t, []string{"family"}, registry.Names())
+
+	zeroRegistry := selector.FilterRegistry{}
+	h.Ok(t, zeroRegistry.Register("family", selector.CustomFilterFn(familyFilter)))
+}
+
+func TestFilter_CustomFilter(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	h.Ok(t, itf.FilterRegistry.Register("family", selector.CustomFilterFn(familyFilter)))
+	filters := selector.Filters{
+		CustomFilters: &map[string]interface{}{"family": aws.String("c4")},
+		VCpusRange:    selector.NewRange[int32](2, 4),
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []string{"c4.large", "c4.xlarge"}, results)
+}
+
+func TestFilter_CustomFilterNilValue(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	h.Ok(t, itf.FilterRegistry.Register("family", selector.CustomFilterFn(familyFilter)))
+	var family *string
+	filters := selector.Filters{
+		CustomFilters: &map[string]interface{}{"family": family},
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 25, len(results))
+}
+
+func TestFilter_CustomFilterNotRegistered(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		CustomFilters: &map[string]interface{}{"family": aws.String("c4")},
+	}
+	ctx := context.Background()
+	_, err := itf.Filter(ctx, filters)
+	h.Nok(t, err)
+}
+
+func TestWhyNot_CustomFilter(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	h.Ok(t, itf.FilterRegistry.Register("family", selector.CustomFilterFn(familyFilter)))
+	filters := selector.Filters{
+		CustomFilters: &map[string]interface{}{"family": aws.String("c4")},
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "c5.large")
+	h.Ok(t, err)
+	h.Equals(t, 1, len(report.FailedFilters))
+	h.Equals(t, selector.CustomFilterKeyPrefix+"family", report.FailedFilters[0].Filter)
+	h.Equals(t, aws.String("c4"), report.FailedFilters[0].FilterValue)
+}
@@ -0,0 +1,177 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FiltersFileStdin is the path passed to LoadFilters to read the filters from stdin.
+const FiltersFileStdin = "-"
+
+// MarshalJSON returns the json representation of Filters with the allow and deny lists as regex strings,
+// byte quantities as strings such as "8 GiB", and the expression as its source string.
+func (f Filters) MarshalJSON() ([]byte, error) {
+	type Alias Filters
+	return json.Marshal(&struct {
+		AllowList *string
+		DenyList  *string
+		Alias
+	}{
+		AllowList: getRegexpString(f.AllowList),
+		DenyList:  getRegexpString(f.DenyList),
+		Alias:     (Alias)(f),
+	})
+}
+
+// UnmarshalJSON parses the json representation of Filters returned by MarshalJSON.
+// Unknown fields are rejected so that misspelled filters are not silently ignored.
+func (f *Filters) UnmarshalJSON(data []byte) error {
+	type Alias Filters
+	aux := &struct {
+		AllowList *string
+		DenyList  *string
+		*Alias
+	}{
+		Alias: (*Alias)(f),
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(aux); err != nil {
+		return err
+	}
+	var err error
+	if f.AllowList, err = compileRegexp(aux.AllowList); err != nil {
+		return fmt.Errorf("invalid AllowList: %w", err)
+	}
+	if f.DenyList, err = compileRegexp(aux.DenyList); err != nil {
+		return fmt.Errorf("invalid DenyList: %w", err)
+	}
+	return nil
+}
+
+// MarshalYAML returns the yaml representation of Filters with the same field names and values as the json representation.
+// Filters which are not set are omitted.
+func (f Filters) MarshalYAML() (interface{}, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	document := yaml.Node{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return plainYAMLNode(document.Content[0]), nil
+}
+
+// UnmarshalYAML parses the yaml representation of Filters returned by MarshalYAML.
+func (f *Filters) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return f.UnmarshalJSON(data)
+}
+
+// ParseFilters parses Filters from their yaml or json representation.
+func ParseFilters(data []byte) (Filters, error) {
+	filters := Filters{}
+	if err := yaml.Unmarshal(data, &filters); err != nil {
+		return Filters{}, err
+	}
+	return filters, nil
+}
+
+// LoadFilters reads Filters from a yaml or json file, or from stdin if the path is FiltersFileStdin.
+func LoadFilters(path string) (Filters, error) {
+	var data []byte
+	var err error
+	if path == FiltersFileStdin {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return Filters{}, fmt.Errorf("unable to read filters file %s: %w", path, err)
+	}
+	filters, err := ParseFilters(data)
+	if err != nil {
+		return Filters{}, fmt.Errorf("unable to parse filters file %s: %w", path, err)
+	}
+	return filters, nil
+}
+
+// MergeFilters returns the filters with each filter which is not set, or is an empty list, taken from the defaults.
+// Custom filters are merged by name so that the defaults only provide the custom filters which are not set.
+func MergeFilters(filters Filters, defaults Filters) Filters {
+	merged := reflect.ValueOf(&filters).Elem()
+	defaultValues := reflect.ValueOf(defaults)
+	for i := 0; i < merged.NumField(); i++ {
+		field := merged.Field(i)
+		if !isUnsetFilter(field) {
+			continue
+		}
+		field.Set(defaultValues.Field(i))
+	}
+	if filters.CustomFilters != nil && defaults.CustomFilters != nil {
+		customFilters := map[string]interface{}{}
+		for name, value := range *defaults.CustomFilters {
+			customFilters[name] = value
+		}
+		for name, value := range *filters.CustomFilters {
+			customFilters[name] = value
+		}
+		filters.CustomFilters = &customFilters
+	}
+	return filters
+}
+
+func isUnsetFilter(field reflect.Value) bool {
+	if field.IsNil() {
+		return true
+	}
+	elem := field.Elem()
+	return (elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map) && elem.Len() == 0
+}
+
+// plainYAMLNode removes null mapping values and the json flow and quoting styles from a yaml node parsed from json
+// so that it is encoded in the block style.
+func plainYAMLNode(node *yaml.Node) *yaml.Node {
+	node.Style = 0
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			plainYAMLNode(child)
+		}
+		return node
+	}
+	content := []*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i+1].Tag == "!!null" {
+			continue
+		}
+		content = append(content, plainYAMLNode(node.Content[i]), plainYAMLNode(node.Content[i+1]))
+	}
+	node.Content = content
+	return node
+}
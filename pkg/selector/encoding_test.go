@@ -0,0 +1,156 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+const filtersFilesPath = mockFilesPath + "/Filters"
+
+// Helpers
+
+func requirementsFilters(t *testing.T) selector.Filters {
+	cpuArch := ec2types.ArchitectureTypeX8664
+	usageClass := ec2types.UsageClassTypeSpot
+	expr, err := selector.ParseExpression("NetworkInfo.MaximumNetworkInterfaces >= 4")
+	h.Ok(t, err)
+	return selector.Filters{
+		VCpusRange:        selector.NewRange[int32](4, 16),
+		MemoryRange:       selector.NewRange(bytequantity.FromGiB(8), bytequantity.FromGiB(64)),
+		CPUArchitecture:   &cpuArch,
+		UsageClass:        &usageClass,
+		AllowList:         regexp.MustCompile(`^(c|m|r)[5-7].*`),
+		CurrentGeneration: aws.Bool(true),
+		Expression:        expr,
+	}
+}
+
+// Tests
+
+func TestFiltersJSON_RoundTrip(t *testing.T) {
+	filters := requirementsFilters(t)
+	filters.CustomFilters = &map[string]interface{}{"family": "c5"}
+	out, err := json.Marshal(filters)
+	h.Ok(t, err)
+	h.Assert(t, strings.Contains(string(out), `"LowerBound":"8 GiB"`), "Does not include the memory range as a byte quantity string: %s", string(out))
+
+	parsed := selector.Filters{}
+	h.Ok(t, json.Unmarshal(out, &parsed))
+	h.Equals(t, filters, parsed)
+}
+
+func TestFiltersYAML_RoundTrip(t *testing.T) {
+	filters := requirementsFilters(t)
+	out, err := yaml.Marshal(filters)
+	h.Ok(t, err)
+	outStr := string(out)
+	h.Assert(t, strings.Contains(outStr, "LowerBound: 8 GiB"), "Does not include the memory range as a byte quantity string: %s", outStr)
+	h.Assert(t, !strings.Contains(outStr, "null"), "Includes filters which are not set: %s", outStr)
+
+	parsed, err := selector.ParseFilters(out)
+	h.Ok(t, err)
+	h.Equals(t, filters, parsed)
+}
+
+func TestFiltersJSON_MetalSizeRange(t *testing.T) {
+	metal, err := instancename.ParseSize("metal")
+	h.Ok(t, err)
+	filters := selector.Filters{SizeRange: selector.NewRange(4.0, metal)}
+	out, err := json.Marshal(filters)
+	h.Ok(t, err)
+
+	parsed := selector.Filters{}
+	h.Ok(t, json.Unmarshal(out, &parsed))
+	h.Equals(t, filters, parsed)
+}
+
+func TestParseFilters_QuotedScalars(t *testing.T) {
+	filters := selector.Filters{
+		Service:         aws.String("true"),
+		GPUModel:        aws.String("8"),
+		InstanceTypes:   &[]string{"m5.large", "null"},
+		CPUManufacturer: nil,
+	}
+	out, err := yaml.Marshal(filters)
+	h.Ok(t, err)
+	parsed, err := selector.ParseFilters(out)
+	h.Ok(t, err)
+	h.Equals(t, filters, parsed)
+}
+
+func TestLoadFilters(t *testing.T) {
+	expected := requirementsFilters(t)
+	for _, file := range []string{"requirements.yaml", "requirements.json"} {
+		filters, err := selector.LoadFilters(fmt.Sprintf("%s/%s", filtersFilesPath, file))
+		h.Ok(t, err)
+		h.Equals(t, expected, filters)
+	}
+}
+
+func TestLoadFilters_Errors(t *testing.T) {
+	_, err := selector.LoadFilters(fmt.Sprintf("%s/%s", filtersFilesPath, "unknown_field.yaml"))
+	h.Nok(t, err)
+	h.Assert(t, strings.Contains(err.Error(), "VCpuRange"), "Does not report the unknown field: %v", err)
+
+	_, err = selector.LoadFilters(fmt.Sprintf("%s/%s", filtersFilesPath, "does_not_exist.yaml"))
+	h.Nok(t, err)
+
+	for _, data := range []string{
+		`AllowList: "("`,
+		`Expression: "VCpuInfo.DefaultVCpus >>"`,
+		`MemoryRange: {LowerBound: 8 PiB}`,
+		`VCpusRange: four`,
+	} {
+		_, err = selector.ParseFilters([]byte(data))
+		h.Nok(t, err)
+	}
+}
+
+func TestMergeFilters(t *testing.T) {
+	defaults := requirementsFilters(t)
+	defaults.AvailabilityZones = &[]string{"us-east-2a"}
+	defaults.CustomFilters = &map[string]interface{}{"family": "c5", "size": "large"}
+	usageClass := ec2types.UsageClassTypeOnDemand
+	filters := selector.Filters{
+		VCpusRange:        selector.NewRange[int32](8, 8),
+		UsageClass:        &usageClass,
+		AvailabilityZones: &[]string{},
+		CustomFilters:     &map[string]interface{}{"family": "m5"},
+	}
+
+	merged := selector.MergeFilters(filters, defaults)
+	h.Equals(t, filters.VCpusRange, merged.VCpusRange)
+	h.Equals(t, filters.UsageClass, merged.UsageClass)
+	h.Equals(t, defaults.MemoryRange, merged.MemoryRange)
+	h.Equals(t, defaults.AllowList, merged.AllowList)
+	h.Equals(t, defaults.AvailabilityZones, merged.AvailabilityZones)
+	h.Equals(t, &map[string]interface{}{"family": "m5", "size": "large"}, merged.CustomFilters)
+	h.Equals(t, &map[string]interface{}{"family": "m5"}, filters.CustomFilters)
+
+	h.Equals(t, defaults, selector.MergeFilters(selector.Filters{}, defaults))
+	h.Equals(t, merged, selector.MergeFilters(merged, selector.Filters{}))
+}
@@ -0,0 +1,149 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// Explanation reports which filters eliminated which instance types for a Filters struct.
+type Explanation struct {
+	// TotalInstanceTypes is the number of instance types that were evaluated
+	TotalInstanceTypes int
+
+	// MatchedInstanceTypes are the instance types which satisfied every filter sorted by name
+	MatchedInstanceTypes []string
+
+	// Rejections lists each filter which eliminated at least one instance type
+	// sorted by the number of instance types eliminated in descending order
+	Rejections []FilterRejection
+}
+
+// FilterRejection holds the instance types eliminated by a single filter.
+type FilterRejection struct {
+	// Filter is the filter key (i.e. vcpusRange, location, allowList, denyList)
+	Filter string
+
+	// InstanceTypes are the instance types that did not satisfy the filter sorted by name
+	// An instance type is listed under every filter it did not satisfy
+	InstanceTypes []string
+
+	// SoleRejections is the number of instance types for which this was the only filter not satisfied
+	SoleRejections int
+}
+
+// filterFailure is a filter that an instance type did not satisfy.
+type filterFailure struct {
+	filterName string
+	pair       filterPair
+	err        error
+}
+
+// Explain accepts a Filters struct and evaluates every filter against every instance type without short-circuiting
+// to report how many and which instance types each filter eliminated, including eliminations by location offerings and
+// the allow and deny lists.
+func (s Selector) Explain(ctx context.Context, filters Filters) (*Explanation, error) {
+	group, err := s.prepareFilterGroup(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	instanceTypeDetails, err := s.InstanceTypesProvider.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	explanation := &Explanation{
+		TotalInstanceTypes:   len(instanceTypeDetails),
+		MatchedInstanceTypes: []string{},
+		Rejections:           []FilterRejection{},
+	}
+	rejections := map[string]*FilterRejection{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, instanceTypeInfo := range instanceTypeDetails {
+		wg.Add(1)
+		go func(instanceTypeInfo instancetypes.Details) {
+			defer wg.Done()
+			failures := s.findFilterFailures(ctx, group, &instanceTypeInfo)
+			instanceTypeName := string(instanceTypeInfo.InstanceType)
+			mu.Lock()
+			defer mu.Unlock()
+			if len(failures) == 0 {
+				explanation.MatchedInstanceTypes = append(explanation.MatchedInstanceTypes, instanceTypeName)
+				return
+			}
+			for _, failure := range failures {
+				rejection, ok := rejections[failure.filterName]
+				if !ok {
+					rejection = &FilterRejection{Filter: failure.filterName}
+					rejections[failure.filterName] = rejection
+				}
+				rejection.InstanceTypes = append(rejection.InstanceTypes, instanceTypeName)
+				if len(failures) == 1 {
+					rejection.SoleRejections++
+				}
+			}
+		}(*instanceTypeInfo)
+	}
+	wg.Wait()
+
+	sort.Strings(explanation.MatchedInstanceTypes)
+	for _, rejection := range rejections {
+		sort.Strings(rejection.InstanceTypes)
+		explanation.Rejections = append(explanation.Rejections, *rejection)
+	}
+	sort.Slice(explanation.Rejections, func(i, j int) bool {
+		if len(explanation.Rejections[i].InstanceTypes) != len(explanation.Rejections[j].InstanceTypes) {
+			return len(explanation.Rejections[i].InstanceTypes) > len(explanation.Rejections[j].InstanceTypes)
+		}
+		return explanation.Rejections[i].Filter < explanation.Rejections[j].Filter
+	})
+	return explanation, nil
+}
+
+// findFilterFailures evaluates every filter within the filter group against the instance type and returns
+// all of the filters that were not satisfied sorted by filter name.
+// Filters that could not be evaluated are returned as failures with the corresponding error.
+func (s Selector) findFilterFailures(ctx context.Context, group *filterGroup, instanceTypeInfo *instancetypes.Details) []filterFailure {
+	filters := group.filters
+	instanceTypeName := instanceTypeInfo.InstanceType
+	failures := []filterFailure{}
+
+	if isInDenyList(filters.DenyList, instanceTypeName) {
+		failures = append(failures, filterFailure{filterName: denyList, pair: filterPair{getRegexpString(filters.DenyList), string(instanceTypeName)}})
+	}
+	if !isInAllowList(filters.AllowList, instanceTypeName) {
+		failures = append(failures, filterFailure{filterName: allowList, pair: filterPair{getRegexpString(filters.AllowList), string(instanceTypeName)}})
+	}
+	if !isSupportedInLocation(group.locationInstanceOfferings, instanceTypeName) {
+		failures = append(failures, filterFailure{filterName: locationFilterKey, pair: filterPair{getLocations(filters), string(instanceTypeName)}})
+	}
+
+	filterToInstanceSpecMappingPairs := s.getFilterToInstanceSpecMapping(ctx, filters, instanceTypeInfo, group.availabilityZones)
+	for filterName, filter := range filterToInstanceSpecMappingPairs {
+		ok, err := exec(instanceTypeName, filterName, filter)
+		if err != nil {
+			s.Logger.Printf("Unable to evaluate filter %s for %s, %v", filterName, instanceTypeName, err)
+		}
+		if !ok || err != nil {
+			failures = append(failures, filterFailure{filterName: filterName, pair: filter, err: err})
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].filterName < failures[j].filterName
+	})
+	return failures
+}
@@ -0,0 +1,93 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestExplain(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		VCpusRange: selector.NewRange[int32](2, 2),
+		GpusRange:  selector.NewRange[int32](1, 8),
+		BareMetal:  aws.Bool(false),
+	}
+	ctx := context.Background()
+	explanation, err := itf.Explain(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 2, explanation.TotalInstanceTypes)
+	h.Equals(t, []string{}, explanation.MatchedInstanceTypes)
+	h.Equals(t, []selector.FilterRejection{
+		{Filter: "gpusRange", InstanceTypes: []string{"t3.micro"}, SoleRejections: 1},
+		{Filter: "vcpusRange", InstanceTypes: []string{"p3.16xlarge"}, SoleRejections: 1},
+	}, explanation.Rejections)
+}
+
+func TestExplain_Matched(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		VCpusRange: selector.NewRange[int32](2, 64),
+	}
+	ctx := context.Background()
+	explanation, err := itf.Explain(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []string{"p3.16xlarge", "t3.micro"}, explanation.MatchedInstanceTypes)
+	h.Equals(t, []selector.FilterRejection{}, explanation.Rejections)
+}
+
+func TestExplain_AllowDenyAndLocation(t *testing.T) {
+	ec2Mock := mockedEC2{
+		DescribeInstanceTypesResp:         setupMock(t, describeInstanceTypes, "25_instances.json").DescribeInstanceTypesResp,
+		DescribeInstanceTypeOfferingsResp: setupMock(t, describeInstanceTypeOfferings, "us-east-2a_only_c5d12x.json").DescribeInstanceTypeOfferingsResp,
+		DescribeAvailabilityZonesResp:     setupMock(t, describeAvailabilityZones, "us-east-2.json").DescribeAvailabilityZonesResp,
+	}
+	itf := getSelector(ec2Mock)
+	filters := selector.Filters{
+		AllowList:         regexp.MustCompile("c4.*"),
+		DenyList:          regexp.MustCompile("c4.large"),
+		AvailabilityZones: &[]string{"us-east-2a"},
+	}
+	ctx := context.Background()
+	explanation, err := itf.Explain(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 25, explanation.TotalInstanceTypes)
+	h.Equals(t, 0, len(explanation.MatchedInstanceTypes))
+	rejections := map[string]selector.FilterRejection{}
+	for _, rejection := range explanation.Rejections {
+		rejections[rejection.Filter] = rejection
+	}
+	h.Equals(t, 25, len(rejections["location"].InstanceTypes))
+	h.Equals(t, 20, len(rejections["allowList"].InstanceTypes))
+	h.Equals(t, []string{"c4.large"}, rejections["denyList"].InstanceTypes)
+	h.Equals(t, 4, rejections["location"].SoleRejections)
+	h.Equals(t, "location", explanation.Rejections[0].Filter)
+}
+
+func TestExplain_Failure(t *testing.T) {
+	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("error")})
+	ctx := context.Background()
+	explanation, err := itf.Explain(ctx, selector.Filters{})
+	h.Assert(t, explanation == nil, "Explanation should be nil")
+	h.Nok(t, err)
+}
@@ -0,0 +1,1072 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// Expression is a parsed boolean expression which is evaluated against the details of an instance type.
+// The syntax is a small subset of CEL:
+//   - fields of instancetypes.Details are referenced by path (i.e. MemoryInfo.SizeInMiB, OndemandPricePerHour)
+//   - derived fields TotalGpus, TotalGpuMemoryMiB, and TotalInferenceAccelerators are available
+//   - literals: numbers, "strings" or 'strings', true, false, null, and lists like ["a", "b"]
+//   - operators: || && ! == != < <= > >= + - * / % in
+//   - functions: size(x), x.startsWith(s), x.endsWith(s), x.contains(s), x.matches(regex)
+//
+// Unset fields (like prices that were not fetched) evaluate to null which never satisfies an ordering comparison.
+// Division and modulo by zero also evaluate to null.
+//
+// Example: MemoryInfo.SizeInMiB / VCpuInfo.DefaultVCpus >= 6144 && NetworkInfo.MaximumNetworkInterfaces >= 8
+type Expression struct {
+	source string
+	root   exprNode
+	fields []string
+}
+
+// exprKind is the statically known type of an expression node used for parse time type checking.
+type exprKind int
+
+const (
+	kindAny exprKind = iota
+	kindNull
+	kindBool
+	kindNumber
+	kindString
+	kindList
+	kindObject
+)
+
+func (k exprKind) String() string {
+	switch k {
+	case kindNull:
+		return "null"
+	case kindBool:
+		return "bool"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindList:
+		return "list"
+	case kindObject:
+		return "object"
+	default:
+		return "any"
+	}
+}
+
+// expressionDerivedFields are fields which are not directly on instancetypes.Details but are computed from it.
+var expressionDerivedFields = map[string]func(*instancetypes.Details) interface{}{
+	"TotalGpus": func(instanceTypeInfo *instancetypes.Details) interface{} {
+		return getTotalGpusCount(instanceTypeInfo.GpuInfo)
+	},
+	"TotalGpuMemoryMiB": func(instanceTypeInfo *instancetypes.Details) interface{} {
+		return getTotalGpuMemory(instanceTypeInfo.GpuInfo)
+	},
+	"TotalInferenceAccelerators": func(instanceTypeInfo *instancetypes.Details) interface{} {
+		return getTotalAcceleratorsCount(instanceTypeInfo.InferenceAcceleratorInfo)
+	},
+}
+
+// ParseExpression parses and type checks an expression so that it can be evaluated against instance types.
+func ParseExpression(source string) (*Expression, error) {
+	tokens, err := lexExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	kind, err := root.check()
+	if err != nil {
+		return nil, err
+	}
+	if kind != kindBool && kind != kindAny {
+		return nil, fmt.Errorf("expression must evaluate to a bool but evaluates to a %s", kind)
+	}
+	return &Expression{source: source, root: root, fields: p.fields}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Fields returns the field paths referenced within the expression.
+func (e *Expression) Fields() []string {
+	return e.fields
+}
+
+// MarshalJSON marshals the expression as its source string.
+func (e *Expression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.source)
+}
+
+// UnmarshalJSON parses the expression from its source string.
+func (e *Expression) UnmarshalJSON(data []byte) error {
+	var source string
+	if err := json.Unmarshal(data, &source); err != nil {
+		return err
+	}
+	expr, err := ParseExpression(source)
+	if err != nil {
+		return err
+	}
+	*e = *expr
+	return nil
+}
+
+// Evaluate returns true if the instance type satisfies the expression.
+func (e *Expression) Evaluate(instanceTypeInfo *instancetypes.Details) (bool, error) {
+	val, err := e.root.eval(instanceTypeInfo)
+	if err != nil {
+		return false, fmt.Errorf("unable to evaluate expression %q: %w", e.source, err)
+	}
+	switch v := val.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, fmt.Errorf("expression %q evaluated to %v which is not a bool", e.source, val)
+	}
+}
+
+// Lexer
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var expressionOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", "."}
+
+func lexExpression(source string) ([]exprToken, error) {
+	tokens := []exprToken{}
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var sb strings.Builder
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start)
+			}
+			i++
+			tokens = append(tokens, exprToken{kind: tokenString, text: sb.String(), pos: start})
+		default:
+			matched := false
+			for _, op := range expressionOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, exprToken{kind: tokenOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+		}
+	}
+	return append(tokens, exprToken{kind: tokenEOF, text: "end of expression", pos: len(runes)}), nil
+}
+
+// Parser
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	fields []string
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) isOperator(ops ...string) bool {
+	tok := p.peek()
+	if tok.kind != tokenOperator && !(tok.kind == tokenIdent && tok.text == "in") {
+		return false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *exprParser) expect(op string) error {
+	if tok := p.next(); tok.kind != tokenOperator || tok.text != op {
+		return fmt.Errorf("expected %q but found %q at position %d", op, tok.text, tok.pos)
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseRelation()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseRelation() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOperator("==", "!=", "<", "<=", ">", ">=", "in") {
+		op := p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if op.text == "in" {
+			return &inNode{left: left, right: right}, nil
+		}
+		return &compareNode{op: op.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("+", "-") {
+		op := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &arithmeticNode{op: op.text, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("*", "/", "%") {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &arithmeticNode{op: op.text, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isOperator("!", "-") {
+		op := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op.text, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(".") {
+		p.next()
+		tok := p.next()
+		if tok.kind != tokenIdent {
+			return nil, fmt.Errorf("expected a field or function name but found %q at position %d", tok.text, tok.pos)
+		}
+		if p.isOperator("(") {
+			if err := p.resolveField(node); err != nil {
+				return nil, err
+			}
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			node, err = newCallNode(tok, append([]exprNode{node}, args...))
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		field, ok := node.(*fieldNode)
+		if !ok || field.resolved {
+			return nil, fmt.Errorf("unexpected field access %q at position %d", tok.text, tok.pos)
+		}
+		field.path = append(field.path, tok.text)
+	}
+	if err := p.resolveField(node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// resolveField resolves the node if it is a field which has not been resolved yet and records the field path.
+func (p *exprParser) resolveField(node exprNode) error {
+	field, ok := node.(*fieldNode)
+	if !ok || field.resolved {
+		return nil
+	}
+	if err := field.resolve(); err != nil {
+		return err
+	}
+	p.fields = append(p.fields, strings.Join(field.path, "."))
+	return nil
+}
+
+func (p *exprParser) parseArgs() ([]exprNode, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	args := []exprNode{}
+	for !p.isOperator(")") {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if !p.isOperator(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		num, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return &literalNode{value: num}, nil
+	case tokenString:
+		return &literalNode{value: tok.text}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if p.isOperator("(") {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return newCallNode(tok, args)
+		}
+		return &fieldNode{path: []string{tok.text}}, nil
+	case tokenOperator:
+		switch tok.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		case "[":
+			list := &listNode{}
+			for !p.isOperator("]") {
+				elem, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.elems = append(list.elems, elem)
+				if !p.isOperator(",") {
+					break
+				}
+				p.next()
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+// AST
+
+type exprNode interface {
+	eval(instanceTypeInfo *instancetypes.Details) (interface{}, error)
+	check() (exprKind, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(*instancetypes.Details) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n *literalNode) check() (exprKind, error) {
+	return kindOfValue(n.value), nil
+}
+
+type listNode struct {
+	elems []exprNode
+}
+
+func (n *listNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	list := []interface{}{}
+	for _, elem := range n.elems {
+		val, err := elem.eval(instanceTypeInfo)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+	}
+	return list, nil
+}
+
+func (n *listNode) check() (exprKind, error) {
+	for _, elem := range n.elems {
+		if _, err := elem.check(); err != nil {
+			return kindAny, err
+		}
+	}
+	return kindList, nil
+}
+
+type fieldNode struct {
+	path     []string
+	kind     exprKind
+	resolved bool
+	derived  func(*instancetypes.Details) interface{}
+}
+
+// resolve validates the field path against instancetypes.Details and determines its kind.
+func (n *fieldNode) resolve() error {
+	n.resolved = true
+	fullPath := strings.Join(n.path, ".")
+	if derivedFn, ok := expressionDerivedFields[fullPath]; ok {
+		n.derived = derivedFn
+		n.kind = kindNumber
+		return nil
+	}
+	fieldType := reflect.TypeOf(instancetypes.Details{})
+	for i, name := range n.path {
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Struct {
+			return fmt.Errorf("unable to access %q in %q because %q is not an object", name, fullPath, strings.Join(n.path[:i], "."))
+		}
+		field, ok := fieldType.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return fmt.Errorf("unknown field %q in %q", name, fullPath)
+		}
+		fieldType = field.Type
+	}
+	n.kind = kindOfType(fieldType)
+	return nil
+}
+
+func (n *fieldNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	if n.derived != nil {
+		return normalizeExpressionValue(reflect.ValueOf(n.derived(instanceTypeInfo))), nil
+	}
+	val := reflect.ValueOf(instanceTypeInfo).Elem()
+	for _, name := range n.path {
+		for val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return nil, nil
+			}
+			val = val.Elem()
+		}
+		val = val.FieldByName(name)
+	}
+	return normalizeExpressionValue(val), nil
+}
+
+func (n *fieldNode) check() (exprKind, error) {
+	return n.kind, nil
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n *unaryNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	val, err := n.operand.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		b, err := toExpressionBool(val)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	}
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return -v, nil
+	default:
+		return nil, fmt.Errorf("unable to negate %v", val)
+	}
+}
+
+func (n *unaryNode) check() (exprKind, error) {
+	kind, err := n.operand.check()
+	if err != nil {
+		return kindAny, err
+	}
+	if n.op == "!" {
+		if !kindIsOneOf(kind, kindBool, kindNull) {
+			return kindAny, fmt.Errorf("operator ! cannot be applied to a %s", kind)
+		}
+		return kindBool, nil
+	}
+	if !kindIsOneOf(kind, kindNumber, kindNull) {
+		return kindAny, fmt.Errorf("operator - cannot be applied to a %s", kind)
+	}
+	return kindNumber, nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *logicalNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	leftVal, err := n.left.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	left, err := toExpressionBool(leftVal)
+	if err != nil {
+		return nil, err
+	}
+	if (n.op == "&&" && !left) || (n.op == "||" && left) {
+		return left, nil
+	}
+	rightVal, err := n.right.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	return toExpressionBool(rightVal)
+}
+
+func (n *logicalNode) check() (exprKind, error) {
+	for _, operand := range []exprNode{n.left, n.right} {
+		kind, err := operand.check()
+		if err != nil {
+			return kindAny, err
+		}
+		if !kindIsOneOf(kind, kindBool, kindNull) {
+			return kindAny, fmt.Errorf("operator %s cannot be applied to a %s", n.op, kind)
+		}
+	}
+	return kindBool, nil
+}
+
+type compareNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *compareNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	left, err := n.left.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return expressionValuesEqual(left, right), nil
+	case "!=":
+		return !expressionValuesEqual(left, right), nil
+	}
+	if left == nil || right == nil {
+		return false, nil
+	}
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return nil, fmt.Errorf("unable to compare %v and %v", left, right)
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to compare %v and %v", left, right)
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return nil, fmt.Errorf("unable to compare %v and %v", left, right)
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+func (n *compareNode) check() (exprKind, error) {
+	left, err := n.left.check()
+	if err != nil {
+		return kindAny, err
+	}
+	right, err := n.right.check()
+	if err != nil {
+		return kindAny, err
+	}
+	if n.op == "==" || n.op == "!=" {
+		return kindBool, nil
+	}
+	for _, kind := range []exprKind{left, right} {
+		if !kindIsOneOf(kind, kindNumber, kindString, kindNull) {
+			return kindAny, fmt.Errorf("operator %s cannot be applied to a %s", n.op, kind)
+		}
+	}
+	if kindIsOneOf(left, kindNumber, kindString) && kindIsOneOf(right, kindNumber, kindString) && left != right {
+		return kindAny, fmt.Errorf("operator %s cannot compare a %s to a %s", n.op, left, right)
+	}
+	return kindBool, nil
+}
+
+type inNode struct {
+	left, right exprNode
+}
+
+func (n *inNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	left, err := n.left.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := right.([]interface{})
+	if !ok {
+		return false, nil
+	}
+	for _, elem := range list {
+		if expressionValuesEqual(left, elem) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (n *inNode) check() (exprKind, error) {
+	if _, err := n.left.check(); err != nil {
+		return kindAny, err
+	}
+	right, err := n.right.check()
+	if err != nil {
+		return kindAny, err
+	}
+	if !kindIsOneOf(right, kindList, kindNull) {
+		return kindAny, fmt.Errorf("operator in requires a list but found a %s", right)
+	}
+	return kindBool, nil
+}
+
+type arithmeticNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *arithmeticNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	left, err := n.left.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(instanceTypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	if l, ok := left.(string); ok && n.op == "+" {
+		if r, ok := right.(string); ok {
+			return l + r, nil
+		}
+	}
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("operator %s cannot be applied to %v and %v", n.op, left, right)
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	}
+	if r == 0 {
+		return nil, nil
+	}
+	if n.op == "/" {
+		return l / r, nil
+	}
+	return math.Mod(l, r), nil
+}
+
+func (n *arithmeticNode) check() (exprKind, error) {
+	left, err := n.left.check()
+	if err != nil {
+		return kindAny, err
+	}
+	right, err := n.right.check()
+	if err != nil {
+		return kindAny, err
+	}
+	if n.op == "+" && (left == kindString || right == kindString) {
+		if !kindIsOneOf(left, kindString, kindNull) || !kindIsOneOf(right, kindString, kindNull) {
+			return kindAny, fmt.Errorf("operator + cannot be applied to a %s and a %s", left, right)
+		}
+		return kindString, nil
+	}
+	for _, kind := range []exprKind{left, right} {
+		if !kindIsOneOf(kind, kindNumber, kindNull) {
+			return kindAny, fmt.Errorf("operator %s cannot be applied to a %s", n.op, kind)
+		}
+	}
+	return kindNumber, nil
+}
+
+type callNode struct {
+	name  string
+	args  []exprNode
+	regex *regexp.Regexp
+}
+
+// expressionFunctions maps a function name to its number of arguments including the receiver for methods.
+var expressionFunctions = map[string]int{
+	"size":       1,
+	"startsWith": 2,
+	"endsWith":   2,
+	"contains":   2,
+	"matches":    2,
+}
+
+func newCallNode(name exprToken, args []exprNode) (*callNode, error) {
+	argCount, ok := expressionFunctions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	if len(args) != argCount {
+		return nil, fmt.Errorf("function %q at position %d expects %d arguments but received %d", name.text, name.pos, argCount, len(args))
+	}
+	n := &callNode{name: name.text, args: args}
+	if lit, ok := args[len(args)-1].(*literalNode); ok && n.name == "matches" {
+		pattern, ok := lit.value.(string)
+		if !ok {
+			return nil, fmt.Errorf("function \"matches\" at position %d expects a string regex", name.pos)
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex for \"matches\" at position %d: %w", name.pos, err)
+		}
+		n.regex = regex
+	}
+	return n, nil
+}
+
+func (n *callNode) eval(instanceTypeInfo *instancetypes.Details) (interface{}, error) {
+	vals := []interface{}{}
+	for _, arg := range n.args {
+		val, err := arg.eval(instanceTypeInfo)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	if n.name == "size" {
+		switch v := vals[0].(type) {
+		case nil:
+			return float64(0), nil
+		case string:
+			return float64(len(v)), nil
+		case []interface{}:
+			return float64(len(v)), nil
+		default:
+			return nil, fmt.Errorf("size cannot be applied to %v", vals[0])
+		}
+	}
+	if vals[0] == nil || vals[1] == nil {
+		return false, nil
+	}
+	receiver, rok := vals[0].(string)
+	arg, aok := vals[1].(string)
+	if !rok || !aok {
+		return nil, fmt.Errorf("%s requires string arguments but received %v and %v", n.name, vals[0], vals[1])
+	}
+	switch n.name {
+	case "startsWith":
+		return strings.HasPrefix(receiver, arg), nil
+	case "endsWith":
+		return strings.HasSuffix(receiver, arg), nil
+	case "contains":
+		return strings.Contains(receiver, arg), nil
+	}
+	regex := n.regex
+	if regex == nil {
+		var err error
+		if regex, err = regexp.Compile(arg); err != nil {
+			return nil, err
+		}
+	}
+	return regex.MatchString(receiver), nil
+}
+
+func (n *callNode) check() (exprKind, error) {
+	kinds := []exprKind{}
+	for _, arg := range n.args {
+		kind, err := arg.check()
+		if err != nil {
+			return kindAny, err
+		}
+		kinds = append(kinds, kind)
+	}
+	if n.name == "size" {
+		if !kindIsOneOf(kinds[0], kindString, kindList, kindNull) {
+			return kindAny, fmt.Errorf("size cannot be applied to a %s", kinds[0])
+		}
+		return kindNumber, nil
+	}
+	for _, kind := range kinds {
+		if !kindIsOneOf(kind, kindString, kindNull) {
+			return kindAny, fmt.Errorf("%s cannot be applied to a %s", n.name, kind)
+		}
+	}
+	return kindBool, nil
+}
+
+// Helpers
+
+// normalizeExpressionValue converts a reflected value into one of the expression value types:
+// nil, bool, float64, string, []interface{}, or the underlying value for objects.
+func normalizeExpressionValue(val reflect.Value) interface{} {
+	if !val.IsValid() {
+		return nil
+	}
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return normalizeExpressionValue(val.Elem())
+	case reflect.Bool:
+		return val.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return val.Float()
+	case reflect.String:
+		return val.String()
+	case reflect.Slice, reflect.Array:
+		list := []interface{}{}
+		for i := 0; i < val.Len(); i++ {
+			list = append(list, normalizeExpressionValue(val.Index(i)))
+		}
+		return list
+	default:
+		return val.Interface()
+	}
+}
+
+func kindOfType(t reflect.Type) exprKind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return kindNumber
+	case reflect.String:
+		return kindString
+	case reflect.Slice, reflect.Array:
+		return kindList
+	case reflect.Struct:
+		return kindObject
+	default:
+		return kindAny
+	}
+}
+
+func kindOfValue(val interface{}) exprKind {
+	switch val.(type) {
+	case nil:
+		return kindNull
+	case bool:
+		return kindBool
+	case float64:
+		return kindNumber
+	case string:
+		return kindString
+	default:
+		return kindAny
+	}
+}
+
+func kindIsOneOf(kind exprKind, kinds ...exprKind) bool {
+	if kind == kindAny {
+		return true
+	}
+	for _, k := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+func toExpressionBool(val interface{}) (bool, error) {
+	switch v := val.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, fmt.Errorf("%v is not a bool", val)
+	}
+}
+
+func expressionValuesEqual(left, right interface{}) bool {
+	return reflect.DeepEqual(left, right)
+}
@@ -0,0 +1,159 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func getExpressionTestDetails() *instancetypes.Details {
+	return &instancetypes.Details{
+		InstanceTypeInfo: ec2types.InstanceTypeInfo{
+			InstanceType:          ec2types.InstanceTypeP38xlarge,
+			CurrentGeneration:     aws.Bool(true),
+			SupportedUsageClasses: []ec2types.UsageClassType{ec2types.UsageClassTypeOnDemand, ec2types.UsageClassTypeSpot},
+			VCpuInfo:              &ec2types.VCpuInfo{DefaultVCpus: aws.Int32(32)},
+			MemoryInfo:            &ec2types.MemoryInfo{SizeInMiB: aws.Int64(249856)},
+			NetworkInfo:           &ec2types.NetworkInfo{MaximumNetworkInterfaces: aws.Int32(8)},
+			GpuInfo: &ec2types.GpuInfo{
+				Gpus: []ec2types.GpuDeviceInfo{
+					{Count: aws.Int32(4), Manufacturer: aws.String("NVIDIA"), MemoryInfo: &ec2types.GpuDeviceMemoryInfo{SizeInMiB: aws.Int32(16384)}},
+				},
+				TotalGpuMemoryInMiB: aws.Int32(65536),
+			},
+		},
+		OndemandPricePerHour: aws.Float64(12.24),
+	}
+}
+
+// Tests
+
+func TestParseExpression_Evaluate(t *testing.T) {
+	details := getExpressionTestDetails()
+	for _, tc := range []struct {
+		expression string
+		expected   bool
+	}{
+		{"MemoryInfo.SizeInMiB / VCpuInfo.DefaultVCpus >= 6144 && NetworkInfo.MaximumNetworkInterfaces >= 8", true},
+		{"MemoryInfo.SizeInMiB / VCpuInfo.DefaultVCpus > 8192", false},
+		{"TotalGpus == 4 && TotalGpuMemoryMiB >= 64 * 1024", true},
+		{"TotalInferenceAccelerators > 0", false},
+		{"OndemandPricePerHour < 15 || SpotPrice < 1", true},
+		{"SpotPrice < 100", false},
+		{"SpotPrice == null", true},
+		{"!(OndemandPricePerHour > 20)", true},
+		{"InstanceType.startsWith('p3') && InstanceType.endsWith(\"8xlarge\")", true},
+		{"InstanceType.matches('^p[0-9]\\\\.') && InstanceType.contains('.')", true},
+		{"InstanceType in ['p3.2xlarge', 'p3.8xlarge']", true},
+		{"'spot' in SupportedUsageClasses && size(SupportedUsageClasses) == 2", true},
+		{"CurrentGeneration && -VCpuInfo.DefaultVCpus < 0 && VCpuInfo.DefaultVCpus % 5 == 2", true},
+		{"MemoryInfo.SizeInMiB / TotalInferenceAccelerators > 0", false},
+		{"VCpuInfo.DefaultVCpus % 0.5 == 0 && OndemandPricePerHour % 5 > 2.2", true},
+		{"BareMetal", false},
+	} {
+		expr, err := selector.ParseExpression(tc.expression)
+		h.Ok(t, err)
+		result, err := expr.Evaluate(details)
+		h.Ok(t, err)
+		h.Assert(t, result == tc.expected, "expression %q should evaluate to %t", tc.expression, tc.expected)
+	}
+}
+
+func TestExpression_Evaluate_ModuloByZero(t *testing.T) {
+	for expression, expected := range map[string]bool{
+		"VCpuInfo.DefaultVCpus % (VCpuInfo.DefaultVCpus - 32) == 0":    false,
+		"VCpuInfo.DefaultVCpus % (VCpuInfo.DefaultVCpus - 32) == null": true,
+		"VCpuInfo.DefaultVCpus / (VCpuInfo.DefaultVCpus - 32) == null": true,
+	} {
+		expr, err := selector.ParseExpression(expression)
+		h.Ok(t, err)
+		result, err := expr.Evaluate(getExpressionTestDetails())
+		h.Ok(t, err)
+		h.Assert(t, result == expected, "expression %q should evaluate to %t", expression, expected)
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	for source, errContains := range map[string]string{
+		"VCpuInfo.DefaultVCpus >":                 "unexpected",
+		"VCpuInfo.DefaultVCPUs > 2":               "unknown field \"DefaultVCPUs\"",
+		"VCpuInfo.DefaultVCpus.Count > 2":         "not an object",
+		"VCpuInfo.DefaultVCpus + 2":               "must evaluate to a bool",
+		"InstanceType > 2":                        "cannot compare",
+		"VCpuInfo.DefaultVCpus && true":           "cannot be applied",
+		"InstanceType.sorted()":                   "unknown function",
+		"InstanceType.startsWith()":               "expects 2 arguments",
+		"InstanceType.matches('[')":               "invalid regex",
+		"InstanceType == 'p3":                     "unterminated string",
+		"VCpuInfo.DefaultVCpus > 2 $":             "unexpected character",
+		"(VCpuInfo.DefaultVCpus > 2":              "expected \")\"",
+		"VCpuInfo.DefaultVCpus in 2":              "requires a list",
+		"VCpuInfo.DefaultVCpus > 2 VCpuInfo > 2":  "unexpected",
+		"'p3'.Length > 2":                         "unexpected field access",
+		"size(VCpuInfo.DefaultVCpus) > 2":         "size cannot be applied",
+		"VCpuInfo.DefaultVCpus.startsWith('2')":   "startsWith cannot be applied",
+		"!VCpuInfo.DefaultVCpus":                  "operator ! cannot be applied",
+		"-InstanceType == 2":                      "operator - cannot be applied",
+		"InstanceType - 'a' == 'b'":               "operator - cannot be applied",
+		"VCpuInfo.DefaultVCpus + 'a' == 'b'":      "operator + cannot be applied",
+		"InstanceType.matches(VCpuInfo) == true":  "matches cannot be applied",
+		"VCpuInfo.DefaultVCpus > 2 && 1.2.3 > 1":  "invalid number",
+		"InstanceType.matches(2) == true":         "expects a string regex",
+		"ProcessorInfo < 2":                       "cannot be applied to a object",
+		"VCpuInfo.DefaultVCpus > 2 && VCpuInfo.(": "expected a field or function name",
+	} {
+		_, err := selector.ParseExpression(source)
+		h.Assert(t, err != nil, "expression %q should fail to parse", source)
+		h.Assert(t, strings.Contains(err.Error(), errContains), "expression %q error %q should contain %q", source, err.Error(), errContains)
+	}
+}
+
+func TestParseExpression_Fields(t *testing.T) {
+	expr, err := selector.ParseExpression("SpotPrice < 0.5 && InstanceType.startsWith('m') && TotalGpus == 0")
+	h.Ok(t, err)
+	h.Equals(t, []string{"SpotPrice", "InstanceType", "TotalGpus"}, expr.Fields())
+	h.Equals(t, "SpotPrice < 0.5 && InstanceType.startsWith('m') && TotalGpus == 0", expr.String())
+}
+
+func TestExpression_MarshalIndent(t *testing.T) {
+	expr, err := selector.ParseExpression("TotalGpus == 1")
+	h.Ok(t, err)
+	filters := selector.Filters{Expression: expr}
+	out, err := filters.MarshalIndent("", "    ")
+	h.Ok(t, err)
+	h.Assert(t, strings.Contains(string(out), `"Expression": "TotalGpus == 1"`), "Does not include Expression string: %s", string(out))
+}
+
+func TestFilter_Expression(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	expr, err := selector.ParseExpression("TotalGpus >= 8 && MemoryInfo.SizeInMiB / VCpuInfo.DefaultVCpus >= 6144")
+	h.Ok(t, err)
+	filters := selector.Filters{
+		Expression: expr,
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []string{"p3.16xlarge"}, results)
+}
@@ -0,0 +1,155 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+)
+
+// DefaultFlexibleProfile is the name of the flexible profile used when Filters.FlexibleProfile is not set.
+const DefaultFlexibleProfile = "default"
+
+// FlexibleProfile is a named set of opinions applied by TransformFlexible.
+// Each field is only applied when the corresponding filter is not already set.
+type FlexibleProfile struct {
+	// CPUArchitecture is the default cpu architecture
+	CPUArchitecture *ec2types.ArchitectureType
+
+	// BareMetal is the default for bare metal instance types
+	BareMetal *bool
+
+	// Fpga is the default for FPGA instance types
+	Fpga *bool
+
+	// AllowList is the default regex of instance type names to select from
+	AllowList *regexp.Regexp
+
+	// DenyList is the default regex of instance type names to exclude
+	DenyList *regexp.Regexp
+
+	// VCpusRange is the default vcpus range, applied when neither a vcpus nor a memory range is set
+	VCpusRange *Range[int32]
+
+	// MemoryRange is the default memory range, applied when neither a vcpus nor a memory range is set
+	MemoryRange *Range[bytequantity.ByteQuantity]
+}
+
+// MarshalJSON returns the json representation of a FlexibleProfile with the allow and deny lists as regex strings.
+func (p FlexibleProfile) MarshalJSON() ([]byte, error) {
+	type Alias FlexibleProfile
+	return json.Marshal(&struct {
+		AllowList *string
+		DenyList  *string
+		Alias
+	}{
+		AllowList: getRegexpString(p.AllowList),
+		DenyList:  getRegexpString(p.DenyList),
+		Alias:     (Alias)(p),
+	})
+}
+
+// UnmarshalJSON parses the json representation of a FlexibleProfile with the allow and deny lists as regex strings.
+func (p *FlexibleProfile) UnmarshalJSON(data []byte) error {
+	type Alias FlexibleProfile
+	aux := &struct {
+		AllowList *string
+		DenyList  *string
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	var err error
+	if p.AllowList, err = compileRegexp(aux.AllowList); err != nil {
+		return fmt.Errorf("invalid AllowList: %w", err)
+	}
+	if p.DenyList, err = compileRegexp(aux.DenyList); err != nil {
+		return fmt.Errorf("invalid DenyList: %w", err)
+	}
+	return nil
+}
+
+// BuiltInFlexibleProfiles returns the built-in flexible profiles.
+// The default profile selects general purpose (c, m, r, t) x86_64 instance types which are not bare metal and
+// do not have FPGAs, with 4 vcpus if no vcpus or memory range is specified.
+func BuiltInFlexibleProfiles() map[string]FlexibleProfile {
+	defaultArchitecture := ec2types.ArchitectureTypeX8664
+	bareMetalDefault := false
+	fpgaDefault := false
+	defaultVcpus := int32(4)
+	return map[string]FlexibleProfile{
+		DefaultFlexibleProfile: {
+			CPUArchitecture: &defaultArchitecture,
+			BareMetal:       &bareMetalDefault,
+			Fpga:            &fpgaDefault,
+			AllowList:       baseAllowedInstanceTypesRE,
+			VCpusRange:      NewRange(defaultVcpus, defaultVcpus),
+		},
+	}
+}
+
+// LoadFlexibleProfiles reads a json file of flexible profiles keyed by name and returns them merged with the
+// built-in profiles. Profiles in the file replace built-in profiles with the same name.
+func LoadFlexibleProfiles(path string) (map[string]FlexibleProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read flexible profiles file %s: %w", path, err)
+	}
+	fileProfiles := map[string]FlexibleProfile{}
+	if err := json.Unmarshal(data, &fileProfiles); err != nil {
+		return nil, fmt.Errorf("unable to parse flexible profiles file %s: %w", path, err)
+	}
+	profiles := BuiltInFlexibleProfiles()
+	for name, profile := range fileProfiles {
+		if name == "" {
+			return nil, fmt.Errorf("flexible profiles file %s contains a profile without a name", path)
+		}
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+// flexibleProfile returns the named flexible profile from the selector's profiles, falling back to the built-in profiles.
+func (s Selector) flexibleProfile(name string) (FlexibleProfile, error) {
+	profiles := BuiltInFlexibleProfiles()
+	for profileName, profile := range s.FlexibleProfiles {
+		profiles[profileName] = profile
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		names := []string{}
+		for profileName := range profiles {
+			names = append(names, profileName)
+		}
+		sort.Strings(names)
+		return FlexibleProfile{}, fmt.Errorf("flexible profile %s is not defined, must be one of %v", name, names)
+	}
+	return profile, nil
+}
+
+func compileRegexp(expr *string) (*regexp.Regexp, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	return regexp.Compile(*expr)
+}
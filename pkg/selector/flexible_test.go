@@ -0,0 +1,121 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+const flexibleProfiles = "FlexibleProfiles"
+
+// Helpers
+
+func loadFlexibleProfiles(t *testing.T, file string) map[string]selector.FlexibleProfile {
+	profiles, err := selector.LoadFlexibleProfiles(fmt.Sprintf("%s/%s/%s", mockFilesPath, flexibleProfiles, file))
+	h.Ok(t, err)
+	return profiles
+}
+
+// Tests
+
+func TestLoadFlexibleProfiles(t *testing.T) {
+	profiles := loadFlexibleProfiles(t, "profiles.json")
+	h.Equals(t, 3, len(profiles))
+	h.Equals(t, selector.BuiltInFlexibleProfiles()[selector.DefaultFlexibleProfile], profiles[selector.DefaultFlexibleProfile])
+
+	graviton := profiles["graviton"]
+	h.Equals(t, ec2types.ArchitectureTypeArm64, *graviton.CPUArchitecture)
+	h.Equals(t, "^a1\\..*$", graviton.AllowList.String())
+	h.Assert(t, graviton.Fpga == nil, "unset profile fields should be nil")
+	h.Equals(t, selector.NewRange[int32](2, 4), graviton.VCpusRange)
+
+	largeMemory := profiles["large-memory"]
+	h.Equals(t, "^t[2-9].*$", largeMemory.DenyList.String())
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(16), bytequantity.FromGiB(64)), largeMemory.MemoryRange)
+}
+
+func TestLoadFlexibleProfiles_Errors(t *testing.T) {
+	_, err := selector.LoadFlexibleProfiles(fmt.Sprintf("%s/%s/%s", mockFilesPath, flexibleProfiles, "invalid_regex.json"))
+	h.Nok(t, err)
+	_, err = selector.LoadFlexibleProfiles(fmt.Sprintf("%s/%s/%s", mockFilesPath, flexibleProfiles, "does_not_exist.json"))
+	h.Nok(t, err)
+}
+
+func TestTransformFlexible_DefaultProfile(t *testing.T) {
+	itf := selector.Selector{}
+	ctx := context.Background()
+	filters, err := itf.TransformFlexible(ctx, selector.Filters{Flexible: aws.Bool(true)})
+	h.Ok(t, err)
+	h.Equals(t, selector.NewRange[int32](4, 4), filters.VCpusRange)
+	h.Assert(t, filters.MemoryRange == nil, "the default profile should not set a memory range")
+	h.Assert(t, filters.AllowList.MatchString("m5.xlarge") && !filters.AllowList.MatchString("p3.16xlarge"), "the default profile should only allow general purpose instance types")
+
+	namedFilters, err := itf.TransformFlexible(ctx, selector.Filters{Flexible: aws.Bool(true), FlexibleProfile: aws.String(selector.DefaultFlexibleProfile)})
+	h.Ok(t, err)
+	h.Equals(t, filters.VCpusRange, namedFilters.VCpusRange)
+	h.Equals(t, *filters.CPUArchitecture, *namedFilters.CPUArchitecture)
+}
+
+func TestTransformFlexible_Profile(t *testing.T) {
+	itf := selector.Selector{FlexibleProfiles: loadFlexibleProfiles(t, "profiles.json")}
+	ctx := context.Background()
+	filters, err := itf.TransformFlexible(ctx, selector.Filters{
+		Flexible:        aws.Bool(true),
+		FlexibleProfile: aws.String("large-memory"),
+	})
+	h.Ok(t, err)
+	h.Assert(t, filters.CPUArchitecture == nil, "the profile should not set a cpu architecture")
+	h.Assert(t, filters.AllowList == nil, "the profile should not set an allow list")
+	h.Assert(t, filters.VCpusRange == nil, "the profile should not set a vcpus range")
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(16), bytequantity.FromGiB(64)), filters.MemoryRange)
+
+	filters, err = itf.TransformFlexible(ctx, selector.Filters{
+		Flexible:        aws.Bool(true),
+		FlexibleProfile: aws.String("large-memory"),
+		VCpusRange:      selector.NewRange[int32](2, 2),
+	})
+	h.Ok(t, err)
+	h.Assert(t, filters.MemoryRange == nil, "the profile ranges should not be applied when a vcpus range is set")
+}
+
+func TestTransformFlexible_UndefinedProfile(t *testing.T) {
+	itf := selector.Selector{}
+	ctx := context.Background()
+	_, err := itf.TransformFlexible(ctx, selector.Filters{
+		Flexible:        aws.Bool(true),
+		FlexibleProfile: aws.String("graviton"),
+	})
+	h.Nok(t, err)
+}
+
+func TestFilter_FlexibleProfile(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	itf.FlexibleProfiles = loadFlexibleProfiles(t, "profiles.json")
+	filters := selector.Filters{
+		Flexible:        aws.Bool(true),
+		FlexibleProfile: aws.String("graviton"),
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []string{"a1.large", "a1.xlarge"}, results)
+}
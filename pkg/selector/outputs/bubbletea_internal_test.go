@@ -147,6 +147,57 @@ func TestNewBubbleTeaModel_SpotPricing(t *testing.T) {
 	h.Assert(t, actualODPrice == expectedODPrice, "Actual spot price should be %s, but is actually %s", expectedODPrice, actualODPrice)
 }
 
+func TestNewBubbleTeaModel_SpotZonePricing(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+	instanceTypes[0].SpotPriceByAvailabilityZone = map[string]float64{"us-east-1a": 0.05, "us-east-1b": 0.04}
+	cheapestSpotZone := "us-east-1b"
+	instanceTypes[0].CheapestSpotAvailabilityZone = &cheapestSpotZone
+	instanceTypes[1].SpotPriceByAvailabilityZone = map[string]float64{"us-east-1c": 0.08}
+
+	model := NewBubbleTeaModel(instanceTypes)
+	rows := model.tableModel.table.GetVisibleRows()
+	expectedPrice := "$0.04"
+	actualPrice := fmt.Sprintf("%v", rows[0].Data["Spot Price/Hr (us-east-1b)"])
+
+	h.Assert(t, actualPrice == expectedPrice, "Actual us-east-1b spot price should be %s, but is actually %s", expectedPrice, actualPrice)
+	h.Equals(t, "us-east-1b", rows[0].Data["Cheapest Spot AZ"])
+
+	// test a zone which the instance type does not have a spot price in
+	expectedPrice = "-"
+	actualPrice = fmt.Sprintf("%v", rows[0].Data["Spot Price/Hr (us-east-1c)"])
+
+	h.Assert(t, actualPrice == expectedPrice, "Actual us-east-1c spot price should be %s, but is actually %s", expectedPrice, actualPrice)
+	h.Equals(t, "-", rows[2].Data["Cheapest Spot AZ"])
+}
+
+func TestNewBubbleTeaModel_PricePerformance(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "g3_16xlarge.json")
+	pricePerGPU := 1.14
+	instanceTypes[0].OndemandPricePerGPU = &pricePerGPU
+
+	model := NewBubbleTeaModel(instanceTypes)
+	rows := model.tableModel.table.GetVisibleRows()
+	expectedPrice := "$1.14"
+	actualPrice := fmt.Sprintf("%v", rows[0].Data["On-Demand Price/GPU"])
+
+	h.Assert(t, actualPrice == expectedPrice, "Actual OD price per GPU should be %s, but is actually %s", expectedPrice, actualPrice)
+
+	// test price fetched without the price-performance value
+	expectedPrice = "none"
+	actualPrice = fmt.Sprintf("%v", rows[0].Data["Spot Price/GPU"])
+
+	h.Assert(t, actualPrice == expectedPrice, "Actual spot price per GPU should be %s, but is actually %s", expectedPrice, actualPrice)
+
+	// test nil OD price
+	instanceTypes[0].OndemandPricePerHour = nil
+	model = NewBubbleTeaModel(instanceTypes)
+	rows = model.tableModel.table.GetVisibleRows()
+	expectedPrice = "-Not Fetched-"
+	actualPrice = fmt.Sprintf("%v", rows[0].Data["On-Demand Price/GPU"])
+
+	h.Assert(t, actualPrice == expectedPrice, "Actual OD price per GPU should be %s, but is actually %s", expectedPrice, actualPrice)
+}
+
 func TestNewBubbleTeaModel_Rows(t *testing.T) {
 	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
 	model := NewBubbleTeaModel(instanceTypes)
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -29,22 +31,38 @@ import (
 const columnTag = "column"
 
 // wideColumnsData stores the data that should be displayed on each column
-// of a wide output row.
+// of a wide output row. Fields without a column tag are column groups with a column per key.
 type wideColumnsData struct {
-	instanceName       string `column:"Instance Type"`
-	vcpu               int32  `column:"VCPUs"`
-	memory             string `column:"Mem (GiB)"`
-	hypervisor         string `column:"Hypervisor"`
-	currentGen         bool   `column:"Current Gen"`
-	hibernationSupport bool   `column:"Hibernation Support"`
-	cpuArch            string `column:"CPU Arch"`
-	networkPerformance string `column:"Network Performance"`
-	eni                int32  `column:"ENIs"`
-	gpu                int32  `column:"GPUs"`
-	gpuMemory          string `column:"GPU Mem (GiB)"`
-	gpuInfo            string `column:"GPU Info"`
-	odPrice            string `column:"On-Demand Price/Hr"`
-	spotPrice          string `column:"Spot Price/Hr"`
+	instanceName        string `column:"Instance Type"`
+	vcpu                int32  `column:"VCPUs"`
+	memory              string `column:"Mem (GiB)"`
+	vcpusToMemoryRatio  string `column:"vCPU:Mem Ratio"`
+	hypervisor          string `column:"Hypervisor"`
+	currentGen          bool   `column:"Current Gen"`
+	hibernationSupport  bool   `column:"Hibernation Support"`
+	cpuArch             string `column:"CPU Arch"`
+	networkPerformance  string `column:"Network Performance"`
+	eni                 int32  `column:"ENIs"`
+	gpu                 int32  `column:"GPUs"`
+	gpuMemory           string `column:"GPU Mem (GiB)"`
+	gpuInfo             string `column:"GPU Info"`
+	odPrice             string `column:"On-Demand Price/Hr"`
+	spotPrice           string `column:"Spot Price/Hr"`
+	cheapestSpotZone    string `column:"Cheapest Spot AZ"`
+	spotDiscount        string `column:"Spot Discount"`
+	pricingModelPrice   string `column:"Pricing Model Price/Hr"`
+	odPricePerVCPU      string `column:"On-Demand Price/vCPU"`
+	spotPricePerVCPU    string `column:"Spot Price/vCPU"`
+	odPricePerMemory    string `column:"On-Demand Price/GiB Mem"`
+	spotPricePerMemory  string `column:"Spot Price/GiB Mem"`
+	odPricePerGPU       string `column:"On-Demand Price/GPU"`
+	spotPricePerGPU     string `column:"Spot Price/GPU"`
+	odPricePerGPUMem    string `column:"On-Demand Price/GiB GPU Mem"`
+	spotPricePerGPUMem  string `column:"Spot Price/GiB GPU Mem"`
+	odPricePerNetwork   string `column:"On-Demand Price/Gbps"`
+	spotPricePerNetwork string `column:"Spot Price/Gbps"`
+	// spotZonePrices are the spot prices by availability zone, which are displayed in a column per availability zone
+	spotZonePrices map[string]string
 }
 
 // SimpleInstanceTypeOutput is an OutputFn which outputs a slice of instance type names.
@@ -115,11 +133,10 @@ func TableOutputWide(instanceTypeInfoSlice []*instancetypes.Details) []string {
 	w.Init(buf, 8, 8, 2, ' ', 0)
 	defer w.Flush()
 
-	columnDataStruct := wideColumnsData{}
+	zones := getSpotZones(instanceTypeInfoSlice)
+	columnHeaders := wideColumnHeaders(zones)
 	headers := []interface{}{}
-	structType := reflect.TypeOf(columnDataStruct)
-	for i := 0; i < structType.NumField(); i++ {
-		columnHeader := structType.Field(i).Tag.Get(columnTag)
+	for _, columnHeader := range columnHeaders {
 		headers = append(headers, columnHeader)
 	}
 	separators := make([]interface{}, 0)
@@ -134,23 +151,153 @@ func TableOutputWide(instanceTypeInfoSlice []*instancetypes.Details) []string {
 
 	columnsData := getWideColumnsData(instanceTypeInfoSlice)
 
+	// print the fields in the same order as the headers so that each column lines up with its header
 	for _, data := range columnsData {
-		fmt.Fprintf(w, "\n%s\t%d\t%s\t%s\t%t\t%t\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t",
-			data.instanceName,
-			data.vcpu,
-			data.memory,
-			data.hypervisor,
-			data.currentGen,
-			data.hibernationSupport,
-			data.cpuArch,
-			data.networkPerformance,
-			data.eni,
-			data.gpu,
-			data.gpuMemory,
-			data.gpuInfo,
-			data.odPrice,
-			data.spotPrice,
-		)
+		columnValues := data.columnValues(zones)
+		row := make([]interface{}, 0, len(columnHeaders))
+		for _, columnHeader := range columnHeaders {
+			row = append(row, columnValues[columnHeader])
+		}
+		fmt.Fprintf(w, "\n"+strings.ReplaceAll(headerFormat, "%s", "%v"), row...)
+	}
+	w.Flush()
+	return []string{buf.String()}
+}
+
+// TableOutputRegions is an OutputFn which returns a CLI table of the instance types with a column per region
+// showing the on-demand price or "available" if the instance type is available in the region, and "-" if not.
+func TableOutputRegions(instanceTypeInfoSlice []*instancetypes.Details) []string {
+	if len(instanceTypeInfoSlice) == 0 {
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	headers := []interface{}{"Instance Type"}
+	for _, regionDetails := range instanceTypeInfoSlice[0].Regions {
+		headers = append(headers, regionDetails.Region)
+	}
+	separators := []interface{}{}
+
+	headerFormat := ""
+	for _, header := range headers {
+		headerFormat = headerFormat + "%s\t"
+		separators = append(separators, strings.Repeat("-", len(header.(string))))
+	}
+	fmt.Fprintf(w, headerFormat, headers...)
+	fmt.Fprintf(w, "\n"+headerFormat, separators...)
+
+	for _, instanceTypeInfo := range instanceTypeInfoSlice {
+		row := []interface{}{string(instanceTypeInfo.InstanceType)}
+		for _, regionDetails := range instanceTypeInfo.Regions {
+			availability := "-"
+			if regionDetails.Available {
+				availability = "available"
+				if regionDetails.OndemandPricePerHour != nil {
+					availability = "$" + formatFloat(*regionDetails.OndemandPricePerHour)
+				}
+			}
+			row = append(row, availability)
+		}
+		fmt.Fprintf(w, "\n"+headerFormat, row...)
+	}
+	w.Flush()
+	return []string{buf.String()}
+}
+
+// TableOutputScores is an OutputFn which returns a CLI table of the instance types with their composite score
+// and a column per weighted attribute showing its contribution to the score.
+func TableOutputScores(instanceTypeInfoSlice []*instancetypes.Details) []string {
+	if len(instanceTypeInfoSlice) == 0 || instanceTypeInfoSlice[0].Score == nil {
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	attributes := []string{}
+	for attribute := range instanceTypeInfoSlice[0].Score.Contributions {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	headers := []interface{}{"Instance Type", "Score"}
+	for _, attribute := range attributes {
+		headers = append(headers, attribute)
+	}
+	separators := []interface{}{}
+
+	headerFormat := ""
+	for _, header := range headers {
+		headerFormat = headerFormat + "%s\t"
+		separators = append(separators, strings.Repeat("-", len(header.(string))))
+	}
+	fmt.Fprintf(w, headerFormat, headers...)
+	fmt.Fprintf(w, "\n"+headerFormat, separators...)
+
+	for _, instanceTypeInfo := range instanceTypeInfoSlice {
+		row := []interface{}{string(instanceTypeInfo.InstanceType), "-"}
+		if instanceTypeInfo.Score != nil {
+			row[1] = strconv.FormatFloat(instanceTypeInfo.Score.Total, 'f', 4, 64)
+		}
+		for _, attribute := range attributes {
+			contribution := "-"
+			if instanceTypeInfo.Score != nil {
+				contribution = strconv.FormatFloat(instanceTypeInfo.Score.Contributions[attribute], 'f', 4, 64)
+			}
+			row = append(row, contribution)
+		}
+		fmt.Fprintf(w, "\n"+headerFormat, row...)
+	}
+	w.Flush()
+	return []string{buf.String()}
+}
+
+// TableOutputSimilarity is an OutputFn which returns a CLI table of the instance types with their distance to the base
+// instance type and a column per compared attribute showing its distance.
+func TableOutputSimilarity(instanceTypeInfoSlice []*instancetypes.Details) []string {
+	if len(instanceTypeInfoSlice) == 0 || instanceTypeInfoSlice[0].Similarity == nil {
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	buf := new(bytes.Buffer)
+	w.Init(buf, 8, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	attributes := []string{}
+	for attribute := range instanceTypeInfoSlice[0].Similarity.Attributes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	headers := []interface{}{"Instance Type", "Distance"}
+	for _, attribute := range attributes {
+		headers = append(headers, attribute)
+	}
+	separators := []interface{}{}
+
+	headerFormat := ""
+	for _, header := range headers {
+		headerFormat = headerFormat + "%s\t"
+		separators = append(separators, strings.Repeat("-", len(header.(string))))
+	}
+	fmt.Fprintf(w, headerFormat, headers...)
+	fmt.Fprintf(w, "\n"+headerFormat, separators...)
+
+	for _, instanceTypeInfo := range instanceTypeInfoSlice {
+		row := []interface{}{string(instanceTypeInfo.InstanceType), "-"}
+		if instanceTypeInfo.Similarity != nil {
+			row[1] = strconv.FormatFloat(instanceTypeInfo.Similarity.Distance, 'f', 4, 64)
+		}
+		for _, attribute := range attributes {
+			distance := "-"
+			if instanceTypeInfo.Similarity != nil {
+				distance = strconv.FormatFloat(instanceTypeInfo.Similarity.Attributes[attribute], 'f', 4, 64)
+			}
+			row = append(row, distance)
+		}
+		fmt.Fprintf(w, "\n"+headerFormat, row...)
 	}
 	w.Flush()
 	return []string{buf.String()}
@@ -228,22 +375,54 @@ func getWideColumnsData(instanceTypes []*instancetypes.Details) []*wideColumnsDa
 		if instanceType.SpotPrice != nil {
 			spotPricePerHourStr = "$" + formatFloat(*instanceType.SpotPrice)
 		}
+		// the pricing model price is only set when a pricing model is selected
+		pricingModelPricePerHourStr := "-"
+		if instanceType.PricingModelPricePerHour != nil {
+			pricingModelPricePerHourStr = "$" + formatFloat(*instanceType.PricingModelPricePerHour)
+		}
+		spotDiscountStr := "-Not Fetched-"
+		if instanceType.SpotDiscount != nil {
+			spotDiscountStr = formatFloat(*instanceType.SpotDiscount) + "%"
+		}
+		cheapestSpotZoneStr := "-"
+		if instanceType.CheapestSpotAvailabilityZone != nil {
+			cheapestSpotZoneStr = *instanceType.CheapestSpotAvailabilityZone
+		}
+		spotZonePrices := map[string]string{}
+		for zone, price := range instanceType.SpotPriceByAvailabilityZone {
+			spotZonePrices[zone] = "$" + formatFloat(price)
+		}
 
 		newColumn := wideColumnsData{
-			instanceName:       string(instanceType.InstanceType),
-			vcpu:               *instanceType.VCpuInfo.DefaultVCpus,
-			memory:             formatFloat(float64(*instanceType.MemoryInfo.SizeInMiB) / 1024.0),
-			hypervisor:         string(instanceType.Hypervisor),
-			currentGen:         *instanceType.CurrentGeneration,
-			hibernationSupport: *instanceType.HibernationSupported,
-			cpuArch:            strings.Join(cpuArchitectures, ", "),
-			networkPerformance: *instanceType.NetworkInfo.NetworkPerformance,
-			eni:                *instanceType.NetworkInfo.MaximumNetworkInterfaces,
-			gpu:                gpus,
-			gpuMemory:          formatFloat(float64(gpuMemory) / 1024.0),
-			gpuInfo:            strings.Join(gpuType, ", "),
-			odPrice:            onDemandPricePerHourStr,
-			spotPrice:          spotPricePerHourStr,
+			instanceName:        string(instanceType.InstanceType),
+			vcpu:                *instanceType.VCpuInfo.DefaultVCpus,
+			memory:              formatFloat(float64(*instanceType.MemoryInfo.SizeInMiB) / 1024.0),
+			vcpusToMemoryRatio:  formatVCpusToMemoryRatio(*instanceType.VCpuInfo.DefaultVCpus, *instanceType.MemoryInfo.SizeInMiB),
+			hypervisor:          string(instanceType.Hypervisor),
+			currentGen:          *instanceType.CurrentGeneration,
+			hibernationSupport:  *instanceType.HibernationSupported,
+			cpuArch:             strings.Join(cpuArchitectures, ", "),
+			networkPerformance:  *instanceType.NetworkInfo.NetworkPerformance,
+			eni:                 *instanceType.NetworkInfo.MaximumNetworkInterfaces,
+			gpu:                 gpus,
+			gpuMemory:           formatFloat(float64(gpuMemory) / 1024.0),
+			gpuInfo:             strings.Join(gpuType, ", "),
+			odPrice:             onDemandPricePerHourStr,
+			spotPrice:           spotPricePerHourStr,
+			cheapestSpotZone:    cheapestSpotZoneStr,
+			spotDiscount:        spotDiscountStr,
+			pricingModelPrice:   pricingModelPricePerHourStr,
+			odPricePerVCPU:      formatPricePerUnit(instanceType.OndemandPricePerHour, instanceType.OndemandPricePerVCPU),
+			spotPricePerVCPU:    formatPricePerUnit(instanceType.SpotPrice, instanceType.SpotPricePerVCPU),
+			odPricePerMemory:    formatPricePerUnit(instanceType.OndemandPricePerHour, instanceType.OndemandPricePerGiBMemory),
+			spotPricePerMemory:  formatPricePerUnit(instanceType.SpotPrice, instanceType.SpotPricePerGiBMemory),
+			odPricePerGPU:       formatPricePerUnit(instanceType.OndemandPricePerHour, instanceType.OndemandPricePerGPU),
+			spotPricePerGPU:     formatPricePerUnit(instanceType.SpotPrice, instanceType.SpotPricePerGPU),
+			odPricePerGPUMem:    formatPricePerUnit(instanceType.OndemandPricePerHour, instanceType.OndemandPricePerGiBGPUMemory),
+			spotPricePerGPUMem:  formatPricePerUnit(instanceType.SpotPrice, instanceType.SpotPricePerGiBGPUMemory),
+			odPricePerNetwork:   formatPricePerUnit(instanceType.OndemandPricePerHour, instanceType.OndemandPricePerGbpsNetwork),
+			spotPricePerNetwork: formatPricePerUnit(instanceType.SpotPrice, instanceType.SpotPricePerGbpsNetwork),
+			spotZonePrices:      spotZonePrices,
 		}
 
 		columnsData = append(columnsData, &newColumn)
@@ -252,6 +431,82 @@ func getWideColumnsData(instanceTypes []*instancetypes.Details) []*wideColumnsDa
 	return columnsData
 }
 
+// getSpotZones returns the sorted availability zones which any of the instance types has a spot price in
+func getSpotZones(instanceTypes []*instancetypes.Details) []string {
+	zones := []string{}
+	for _, instanceType := range instanceTypes {
+		for zone := range instanceType.SpotPriceByAvailabilityZone {
+			if !slices.Contains(zones, zone) {
+				zones = append(zones, zone)
+			}
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// spotZoneColumnHeader returns the header of the spot price column of an availability zone
+func spotZoneColumnHeader(zone string) string {
+	return "Spot Price/Hr (" + zone + ")"
+}
+
+// wideColumnHeaders returns the headers of the wide output, which are the column tags of wideColumnsData
+// followed by the spot price column of each of the availability zones.
+func wideColumnHeaders(zones []string) []string {
+	headers := []string{}
+	structType := reflect.TypeOf(wideColumnsData{})
+	for i := 0; i < structType.NumField(); i++ {
+		if columnHeader := structType.Field(i).Tag.Get(columnTag); columnHeader != "" {
+			headers = append(headers, columnHeader)
+		}
+	}
+	for _, zone := range zones {
+		headers = append(headers, spotZoneColumnHeader(zone))
+	}
+	return headers
+}
+
+// columnValues returns the values of the columns of a wide output row by the headers of wideColumnHeaders.
+// The spot price column of an availability zone without a spot price is "-".
+func (data wideColumnsData) columnValues(zones []string) map[string]interface{} {
+	values := map[string]interface{}{}
+	structType := reflect.TypeOf(data)
+	structValue := reflect.ValueOf(data)
+	for i := 0; i < structType.NumField(); i++ {
+		if columnHeader := structType.Field(i).Tag.Get(columnTag); columnHeader != "" {
+			values[columnHeader] = getUnderlyingValue(structValue.Field(i))
+		}
+	}
+	for _, zone := range zones {
+		price, ok := data.spotZonePrices[zone]
+		if !ok {
+			price = "-"
+		}
+		values[spotZoneColumnHeader(zone)] = price
+	}
+	return values
+}
+
+// formatVCpusToMemoryRatio formats the GiBs of memory per vcpu as a ratio like 1:4
+func formatVCpusToMemoryRatio(vcpus int32, memoryMiB int64) string {
+	if vcpus == 0 {
+		return "none"
+	}
+	return "1:" + formatFloat(float64(memoryMiB)/1024.0/float64(vcpus))
+}
+
+// formatPricePerUnit formats a price-performance value, which is "-Not Fetched-" if the hourly price
+// is not fetched and "none" if the instance type does not have the resource.
+func formatPricePerUnit(pricePerHour *float64, pricePerUnit *float64) string {
+	if pricePerHour == nil {
+		return "-Not Fetched-"
+	}
+	if pricePerUnit == nil {
+		return "none"
+	}
+	return "$" + formatFloat(*pricePerUnit)
+}
+
 // getUnderlyingValue returns the underlying value of the given
 // reflect.Value type.
 func getUnderlyingValue(value reflect.Value) interface{} {
@@ -93,6 +93,36 @@ func TestTableOutputWide(t *testing.T) {
 	h.Assert(t, strings.Contains(outputStr, "g2.2xlarge"), "table should include instance type")
 	h.Assert(t, strings.Contains(outputStr, "Moderate"), "wide table should include network performance")
 	h.Assert(t, strings.Contains(outputStr, "NVIDIA K520"), "wide table should include GPU Info")
+	h.Assert(t, strings.Contains(outputStr, "1:1.875"), "wide table should include the vcpus to memory ratio")
+
+	odPrice := 0.65
+	odPricePerVCPU := odPrice / 8
+	instanceTypes[0].OndemandPricePerHour = &odPrice
+	instanceTypes[0].OndemandPricePerVCPU = &odPricePerVCPU
+	instanceTypeOut = outputs.TableOutputWide(instanceTypes)
+	lines = strings.Split(strings.Join(instanceTypeOut, ""), "\n")
+	h.Assert(t, strings.Contains(lines[2], "$0.08125"), "wide table should include the on-demand price per vCPU")
+	h.Assert(t, len(strings.Fields(lines[2])) >= len(strings.Fields(lines[1])), "wide table should include a value for every column")
+
+	pricingModelPrice := 0.4321
+	instanceTypes[0].PricingModelPricePerHour = &pricingModelPrice
+	outputStr = strings.Join(outputs.TableOutputWide(instanceTypes), "")
+	h.Assert(t, strings.Contains(outputStr, "Pricing Model Price/Hr"), "wide table should include the pricing model price column")
+	h.Assert(t, strings.Contains(outputStr, "$0.4321"), "wide table should include the pricing model price")
+
+	instanceTypes[0].SpotPriceByAvailabilityZone = map[string]float64{"us-east-1b": 0.0789, "us-east-1a": 0.0987}
+	cheapestSpotZone := "us-east-1b"
+	instanceTypes[0].CheapestSpotAvailabilityZone = &cheapestSpotZone
+	outputStr = strings.Join(outputs.TableOutputWide(instanceTypes), "")
+	h.Assert(t, strings.Contains(outputStr, "Spot Price/Hr (us-east-1a)  Spot Price/Hr (us-east-1b)"), "wide table should include a spot price column per availability zone in order")
+	h.Assert(t, strings.Contains(outputStr, "$0.0987") && strings.Contains(outputStr, "$0.0789"), "wide table should include the spot price of each availability zone")
+	h.Assert(t, strings.Contains(outputStr, "Cheapest Spot AZ"), "wide table should include the cheapest spot availability zone column")
+
+	spotDiscount := 62.5
+	instanceTypes[0].SpotDiscount = &spotDiscount
+	outputStr = strings.Join(outputs.TableOutputWide(instanceTypes), "")
+	h.Assert(t, strings.Contains(outputStr, "Spot Discount"), "wide table should include the spot discount column")
+	h.Assert(t, strings.Contains(outputStr, "62.5%"), "wide table should include the spot discount")
 }
 
 func TestTableOutput_MBtoGB(t *testing.T) {
@@ -107,6 +137,28 @@ func TestTableOutput_MBtoGB(t *testing.T) {
 	h.Assert(t, strings.Contains(outputStr, "15"), "table should include 15 GB of memory")
 }
 
+func TestTableOutputRegions(t *testing.T) {
+	instanceTypes := getInstanceTypes(t, "t3_micro_and_p3_16xl.json")
+	odPrice := float64(0.0104)
+	instanceTypes[0].Regions = []instancetypes.RegionDetails{
+		{Region: "us-east-1", Available: true, OndemandPricePerHour: &odPrice},
+		{Region: "us-west-2", Available: false},
+	}
+	instanceTypes[1].Regions = []instancetypes.RegionDetails{
+		{Region: "us-east-1", Available: true},
+		{Region: "us-west-2", Available: true},
+	}
+	instanceTypeOut := outputs.TableOutputRegions(instanceTypes)
+	outputStr := strings.Join(instanceTypeOut, "")
+	lines := strings.Split(outputStr, "\n")
+	h.Assert(t, strings.Contains(lines[0], "us-east-1") && strings.Contains(lines[0], "us-west-2"), "Should include a column per region")
+	h.Assert(t, strings.Contains(lines[2], "$0.0104") && strings.Contains(lines[2], "-"), "Should include the regional price and unavailable regions")
+	h.Assert(t, strings.Count(lines[3], "available") == 2, "Should mark regions without prices as available")
+
+	instanceTypeOut = outputs.TableOutputRegions([]*instancetypes.Details{})
+	h.Assert(t, len(instanceTypeOut) == 0, "Should return 0 instance types when passed empty slice")
+}
+
 func TestOneLineOutput(t *testing.T) {
 	instanceTypes := getInstanceTypes(t, "t3_micro_and_p3_16xl.json")
 	instanceTypeOut := outputs.OneLineOutput(instanceTypes)
@@ -119,3 +171,35 @@ func TestOneLineOutput(t *testing.T) {
 	instanceTypeOut = outputs.OneLineOutput(nil)
 	h.Assert(t, len(instanceTypeOut) == 0, "Should return 0 instance types when passed nil")
 }
+
+func TestTableOutputScores(t *testing.T) {
+	instanceTypes := getInstanceTypes(t, "g2_2xlarge.json")
+	instanceTypes[0].Score = &instancetypes.Score{
+		Total:         0.75,
+		Contributions: map[string]float64{"vcpus": 0.25, "on-demand-price": 0.5},
+	}
+	instanceTypeOut := outputs.TableOutputScores(instanceTypes)
+	outputStr := strings.Join(instanceTypeOut, "")
+	lines := strings.Split(outputStr, "\n")
+	h.Assert(t, len(lines) == 3, "table should include 2 header lines and 1 instance type result line")
+	h.Equals(t, []string{"Instance", "Type", "Score", "on-demand-price", "vcpus"}, strings.Fields(lines[0]))
+	h.Equals(t, []string{"g2.2xlarge", "0.7500", "0.5000", "0.2500"}, strings.Fields(lines[2]))
+
+	instanceTypes[0].Score = nil
+	h.Assert(t, outputs.TableOutputScores(instanceTypes) == nil, "table should not be output without scores")
+}
+
+func TestTableOutputSimilarity(t *testing.T) {
+	instanceTypes := getInstanceTypes(t, "g2_2xlarge.json")
+	instanceTypes[0].Similarity = &instancetypes.Similarity{
+		InstanceTypeBase: "g3.4xlarge",
+		Distance:         0.5,
+		Attributes:       map[string]float64{"vcpus": 0.5, "gpus": 0},
+	}
+	instanceTypeOut := outputs.TableOutputSimilarity(instanceTypes)
+	outputStr := strings.Join(instanceTypeOut, "")
+	lines := strings.Split(outputStr, "\n")
+	h.Assert(t, len(lines) == 3, "table should include 2 header lines and 1 instance type result line")
+	h.Equals(t, []string{"Instance", "Type", "Distance", "gpus", "vcpus"}, strings.Fields(lines[0]))
+	h.Equals(t, []string{"g2.2xlarge", "0.5000", "0.0000", "0.5000"}, strings.Fields(lines[2]))
+}
@@ -141,14 +141,29 @@ func createListKeyMap() list.KeyMap {
 // createListItems creates a list item for shorthand sorting flag.
 func createListItems() *[]list.Item {
 	shorthandFlags := []string{
+		sorter.InstanceTypeNameField,
 		sorter.GPUCountField,
 		sorter.InferenceAcceleratorsField,
 		sorter.VCPUs,
 		sorter.Memory,
+		sorter.VCPUsToMemoryRatio,
 		sorter.GPUMemoryTotal,
 		sorter.NetworkInterfaces,
 		sorter.SpotPrice,
 		sorter.ODPrice,
+		sorter.PricingModelPrice,
+		sorter.SpotVolatility,
+		sorter.SpotDiscount,
+		sorter.ODPricePerVCPU,
+		sorter.SpotPricePerVCPU,
+		sorter.ODPricePerGiBMemory,
+		sorter.SpotPricePerGiBMemory,
+		sorter.ODPricePerGPU,
+		sorter.SpotPricePerGPU,
+		sorter.ODPricePerGiBGPUMemory,
+		sorter.SpotPricePerGiBGPUMemory,
+		sorter.ODPricePerGbpsNetwork,
+		sorter.SpotPricePerGbpsNetwork,
 		sorter.InstanceStorage,
 		sorter.EBSOptimizedBaselineBandwidth,
 		sorter.EBSOptimizedBaselineThroughput,
@@ -14,7 +14,6 @@ package outputs
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -104,23 +103,26 @@ func createFilterTextInput() textinput.Model {
 	return filterTextInput
 }
 
+// getRowsColumnValues returns the column values of each row so that they are only calculated once per row.
+func getRowsColumnValues(columnsData []*wideColumnsData, zones []string) []map[string]interface{} {
+	rowsColumnValues := make([]map[string]interface{}, 0, len(columnsData))
+	for _, data := range columnsData {
+		rowsColumnValues = append(rowsColumnValues, data.columnValues(zones))
+	}
+	return rowsColumnValues
+}
+
 // createRows creates a row for each instance type in the passed in list.
-func createRows(columnsData []*wideColumnsData, instanceTypes []*instancetypes.Details) *[]table.Row {
+func createRows(rowsColumnValues []map[string]interface{}, instanceTypes []*instancetypes.Details) *[]table.Row {
 	rows := []table.Row{}
 
 	// create a row for each instance type
-	for i, data := range columnsData {
+	for i, columnValues := range rowsColumnValues {
 		rowData := table.RowData{}
 
-		// create a new row by iterating through the column data
-		// struct and using struct tags as column keys
-		structType := reflect.TypeOf(*data)
-		structValue := reflect.ValueOf(*data)
-		for i := 0; i < structType.NumField(); i++ {
-			currField := structType.Field(i)
-			columnName := currField.Tag.Get(columnTag)
-			colValue := structValue.Field(i)
-			rowData[columnName] = getUnderlyingValue(colValue)
+		// create a new row using the column headers as column keys
+		for columnName, colValue := range columnValues {
+			rowData[columnName] = colValue
 		}
 
 		// add instance type as metaData
@@ -138,24 +140,13 @@ func createRows(columnsData []*wideColumnsData, instanceTypes []*instancetypes.D
 }
 
 // maxColWidth finds the maximum width element in the given column.
-func maxColWidth(columnsData []*wideColumnsData, columnHeader string) int {
+func maxColWidth(rowsColumnValues []map[string]interface{}, columnHeader string) int {
 	// default max width is the width of the header itself with padding
 	maxWidth := len(columnHeader) + headerPadding
 
-	for _, data := range columnsData {
+	for _, columnValues := range rowsColumnValues {
 		// get data at given column
-		structType := reflect.TypeOf(*data)
-		structValue := reflect.ValueOf(*data)
-		var underlyingValue interface{}
-		for i := 0; i < structType.NumField(); i++ {
-			currField := structType.Field(i)
-			columnName := currField.Tag.Get(columnTag)
-			if columnName == columnHeader {
-				colValue := structValue.Field(i)
-				underlyingValue = getUnderlyingValue(colValue)
-				break
-			}
-		}
+		underlyingValue := columnValues[columnHeader]
 
 		// see if the width of the current column element exceeds
 		// the previous max width
@@ -169,16 +160,13 @@ func maxColWidth(columnsData []*wideColumnsData, columnHeader string) int {
 }
 
 // createColumns creates columns based on the tags in the wideColumnsData
-// struct.
-func createColumns(columnsData []*wideColumnsData) *[]table.Column {
+// struct and a spot price column for each of the availability zones.
+func createColumns(rowsColumnValues []map[string]interface{}, zones []string) *[]table.Column {
 	columns := []table.Column{}
 
-	// iterate through wideColumnsData struct and create a new column for each field tag
-	columnDataStruct := wideColumnsData{}
-	structType := reflect.TypeOf(columnDataStruct)
-	for i := 0; i < structType.NumField(); i++ {
-		columnHeader := structType.Field(i).Tag.Get(columnTag)
-		newCol := table.NewColumn(columnHeader, columnHeader, maxColWidth(columnsData, columnHeader)).
+	// create a new column for each header of the wide output
+	for _, columnHeader := range wideColumnHeaders(zones) {
+		newCol := table.NewColumn(columnHeader, columnHeader, maxColWidth(rowsColumnValues, columnHeader)).
 			WithFiltered(true)
 
 		columns = append(columns, newCol)
@@ -218,9 +206,11 @@ func createTableKeyMap() *table.KeyMap {
 func createTable(instanceTypes []*instancetypes.Details) table.Model {
 	// calculate and fetch all column data from instance types
 	columnsData := getWideColumnsData(instanceTypes)
+	zones := getSpotZones(instanceTypes)
+	rowsColumnValues := getRowsColumnValues(columnsData, zones)
 
-	newTable := table.New(*createColumns(columnsData)).
-		WithRows(*createRows(columnsData, instanceTypes)).
+	newTable := table.New(*createColumns(rowsColumnValues, zones)).
+		WithRows(*createRows(rowsColumnValues, instanceTypes)).
 		WithKeyMap(*createTableKeyMap()).
 		WithPageSize(initialDimensionVal).
 		Focused(true).
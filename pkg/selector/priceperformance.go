@@ -0,0 +1,195 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// pricePerformance holds the price per unit of each resource of an instance type for an hourly price.
+type pricePerformance struct {
+	perVCPU         *float64
+	perGiBMemory    *float64
+	perGPU          *float64
+	perGiBGPUMemory *float64
+	perGbpsNetwork  *float64
+}
+
+// setPricePerformance populates the price-performance fields of the instance type from its on-demand and spot prices.
+// A field is left nil if the price is not fetched or the instance type does not have the resource (i.e. GPUs).
+func setPricePerformance(instanceTypeInfo *instancetypes.Details) {
+	onDemand := getPricePerformance(instanceTypeInfo, instanceTypeInfo.OndemandPricePerHour)
+	instanceTypeInfo.OndemandPricePerVCPU = onDemand.perVCPU
+	instanceTypeInfo.OndemandPricePerGiBMemory = onDemand.perGiBMemory
+	instanceTypeInfo.OndemandPricePerGPU = onDemand.perGPU
+	instanceTypeInfo.OndemandPricePerGiBGPUMemory = onDemand.perGiBGPUMemory
+	instanceTypeInfo.OndemandPricePerGbpsNetwork = onDemand.perGbpsNetwork
+
+	spot := getPricePerformance(instanceTypeInfo, instanceTypeInfo.SpotPrice)
+	instanceTypeInfo.SpotPricePerVCPU = spot.perVCPU
+	instanceTypeInfo.SpotPricePerGiBMemory = spot.perGiBMemory
+	instanceTypeInfo.SpotPricePerGPU = spot.perGPU
+	instanceTypeInfo.SpotPricePerGiBGPUMemory = spot.perGiBGPUMemory
+	instanceTypeInfo.SpotPricePerGbpsNetwork = spot.perGbpsNetwork
+}
+
+// getPricePerformance divides the hourly price by the quantity of each resource of the instance type.
+func getPricePerformance(instanceTypeInfo *instancetypes.Details, price *float64) pricePerformance {
+	var vcpus, memoryGiB, gpus, gpuMemoryGiB float64
+	if instanceTypeInfo.VCpuInfo != nil && instanceTypeInfo.VCpuInfo.DefaultVCpus != nil {
+		vcpus = float64(*instanceTypeInfo.VCpuInfo.DefaultVCpus)
+	}
+	if instanceTypeInfo.MemoryInfo != nil && instanceTypeInfo.MemoryInfo.SizeInMiB != nil {
+		memoryGiB = float64(*instanceTypeInfo.MemoryInfo.SizeInMiB) / 1024
+	}
+	if totalGpus := getTotalGpusCount(instanceTypeInfo.GpuInfo); totalGpus != nil {
+		gpus = float64(*totalGpus)
+	}
+	if instanceTypeInfo.GpuInfo != nil && instanceTypeInfo.GpuInfo.TotalGpuMemoryInMiB != nil {
+		gpuMemoryGiB = float64(*instanceTypeInfo.GpuInfo.TotalGpuMemoryInMiB) / 1024
+	}
+	return pricePerformance{
+		perVCPU:         pricePerUnit(price, vcpus),
+		perGiBMemory:    pricePerUnit(price, memoryGiB),
+		perGPU:          pricePerUnit(price, gpus),
+		perGiBGPUMemory: pricePerUnit(price, gpuMemoryGiB),
+		perGbpsNetwork:  pricePerUnit(price, getNetworkBandwidthGbps(instanceTypeInfo.NetworkInfo)),
+	}
+}
+
+// getPricingModelPricePerHour returns the hourly price of the instance type for the pricing model, which is the effective
+// hourly rate for reserved instances, or nil if the pricing model is not set or its price is not fetched.
+func getPricingModelPricePerHour(pricingModel *ec2pricing.PricingModel, instanceTypeInfo *instancetypes.Details) *float64 {
+	if pricingModel == nil {
+		return nil
+	}
+	switch *pricingModel {
+	case ec2pricing.PricingModelOnDemand:
+		return instanceTypeInfo.OndemandPricePerHour
+	case ec2pricing.PricingModelSpot:
+		return instanceTypeInfo.SpotPrice
+	}
+	reservedPrice, ok := instanceTypeInfo.ReservedPrices[*pricingModel]
+	if !ok {
+		return nil
+	}
+	return &reservedPrice.EffectiveHourlyRate
+}
+
+// getSpotDiscount returns the percentage the spot price is cheaper than the on-demand price,
+// or nil if either price is not fetched.
+func getSpotDiscount(onDemandPrice *float64, spotPrice *float64) *float64 {
+	if onDemandPrice == nil || spotPrice == nil || *onDemandPrice <= 0 {
+		return nil
+	}
+	discount := (*onDemandPrice - *spotPrice) / *onDemandPrice * 100
+	return &discount
+}
+
+// getSpotVolatility returns the highest spot price volatility of the availability zones, or nil if there are no statistics.
+func getSpotVolatility(spotPriceStats map[string]ec2pricing.SpotPriceStats) *float64 {
+	var volatility *float64
+	for _, stats := range spotPriceStats {
+		if volatility == nil || stats.Volatility > *volatility {
+			zoneVolatility := stats.Volatility
+			volatility = &zoneVolatility
+		}
+	}
+	return volatility
+}
+
+// getZonalSpotPrices returns the mean spot price of each availability zone and the availability zone with the lowest
+// spot price, which are nil if there are no statistics.
+func getZonalSpotPrices(spotPriceStats map[string]ec2pricing.SpotPriceStats) (map[string]float64, *string) {
+	if len(spotPriceStats) == 0 {
+		return nil, nil
+	}
+	zonalSpotPrices := map[string]float64{}
+	var cheapestZone *string
+	for zone, stats := range spotPriceStats {
+		zonalSpotPrices[zone] = stats.Mean
+		// ties are broken by zone name so that the cheapest zone does not depend on map iteration order
+		if cheapestZone == nil || stats.Mean < zonalSpotPrices[*cheapestZone] ||
+			(stats.Mean == zonalSpotPrices[*cheapestZone] && zone < *cheapestZone) {
+			zone := zone
+			cheapestZone = &zone
+		}
+	}
+	return zonalSpotPrices, cheapestZone
+}
+
+// getSpotAZStrategyPrice returns the spot price of the availability zones selected by the strategy,
+// or nil if the strategy is not set or there are no spot prices by availability zone.
+func getSpotAZStrategyPrice(strategy *SpotAZStrategy, zonalSpotPrices map[string]float64) *float64 {
+	if strategy == nil || len(zonalSpotPrices) == 0 {
+		return nil
+	}
+	var minPrice, maxPrice, sum float64
+	first := true
+	for _, price := range zonalSpotPrices {
+		if first || price < minPrice {
+			minPrice = price
+		}
+		if first || price > maxPrice {
+			maxPrice = price
+		}
+		sum += price
+		first = false
+	}
+	var price float64
+	switch *strategy {
+	case SpotAZStrategyMin:
+		price = minPrice
+	case SpotAZStrategyMax:
+		price = maxPrice
+	case SpotAZStrategyAverage:
+		price = sum / float64(len(zonalSpotPrices))
+	default:
+		return nil
+	}
+	return &price
+}
+
+// getNetworkBandwidthGbps returns the sum of the baseline bandwidth of the network cards or
+// falls back to the bandwidth in the network performance description (i.e. "Up to 10 Gigabit")
+// when the baseline bandwidth is not reported.
+func getNetworkBandwidthGbps(networkInfo *ec2types.NetworkInfo) float64 {
+	if networkInfo == nil {
+		return 0
+	}
+	baselineBandwidth := 0.0
+	for _, networkCard := range networkInfo.NetworkCards {
+		if networkCard.BaselineBandwidthInGbps != nil {
+			baselineBandwidth += *networkCard.BaselineBandwidthInGbps
+		}
+	}
+	if baselineBandwidth > 0 {
+		return baselineBandwidth
+	}
+	if networkPerformance := getNetworkPerformance(networkInfo.NetworkPerformance); *networkPerformance > 0 {
+		return float64(*networkPerformance)
+	}
+	return 0
+}
+
+// pricePerUnit divides the hourly price by the quantity of a resource.
+func pricePerUnit(price *float64, quantity float64) *float64 {
+	if price == nil || quantity <= 0 {
+		return nil
+	}
+	pricePerUnit := *price / quantity
+	return &pricePerUnit
+}
@@ -0,0 +1,259 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"cmp"
+	"math"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+)
+
+// RangeValue is the set of types which can bound a Range
+type RangeValue interface {
+	int | int32 | int64 | uint64 | float64 | bytequantity.ByteQuantity
+}
+
+// Range holds an optional lower and upper bound which are used to range filter resource specs.
+// A range without a lower or upper bound is open on that side, so "at least 4" only sets the lower bound.
+// Bounds are inclusive unless they are marked as exclusive.
+type Range[T RangeValue] struct {
+	LowerBound          *T   `json:",omitempty"`
+	UpperBound          *T   `json:",omitempty"`
+	LowerBoundExclusive bool `json:",omitempty"`
+	UpperBoundExclusive bool `json:",omitempty"`
+}
+
+// rangeFilter is implemented by every Range so that range filters can be inspected and relaxed
+// without knowing the type of their bounds.
+type rangeFilter interface {
+	// floatBounds returns the bounds as float64s with a missing bound returned as an infinite bound
+	floatBounds() (float64, float64)
+	// floatPosition returns -1 if the value is below the range, 1 if it is above the range, or 0 if it is within the range
+	floatPosition(value float64) int
+	// withFloatLowerBound returns a copy of the range with an inclusive lower bound
+	withFloatLowerBound(lowerBound float64) rangeFilter
+	// withFloatUpperBound returns a copy of the range with an inclusive upper bound
+	withFloatUpperBound(upperBound float64) rangeFilter
+	IsEmpty() bool
+}
+
+// NewRange returns a range which includes both bounds
+func NewRange[T RangeValue](lowerBound T, upperBound T) *Range[T] {
+	return &Range[T]{LowerBound: &lowerBound, UpperBound: &upperBound}
+}
+
+// RangeAtLeast returns a range which includes the lower bound and has no upper bound
+func RangeAtLeast[T RangeValue](lowerBound T) *Range[T] {
+	return &Range[T]{LowerBound: &lowerBound}
+}
+
+// RangeAtMost returns a range which includes the upper bound and has no lower bound
+func RangeAtMost[T RangeValue](upperBound T) *Range[T] {
+	return &Range[T]{UpperBound: &upperBound}
+}
+
+// RangeGreaterThan returns a range which excludes the lower bound and has no upper bound
+func RangeGreaterThan[T RangeValue](lowerBound T) *Range[T] {
+	return &Range[T]{LowerBound: &lowerBound, LowerBoundExclusive: true}
+}
+
+// RangeLessThan returns a range which excludes the upper bound and has no lower bound
+func RangeLessThan[T RangeValue](upperBound T) *Range[T] {
+	return &Range[T]{UpperBound: &upperBound, UpperBoundExclusive: true}
+}
+
+// Contains returns true if the value is within the range. A nil range contains every value.
+func (r *Range[T]) Contains(value T) bool {
+	return r.position(value) == 0
+}
+
+// IsEmpty returns true if the bounds of the range exclude every value
+func (r *Range[T]) IsEmpty() bool {
+	if r == nil || r.LowerBound == nil || r.UpperBound == nil {
+		return false
+	}
+	comparison := compareRangeValues(*r.LowerBound, *r.UpperBound)
+	return comparison > 0 || (comparison == 0 && (r.LowerBoundExclusive || r.UpperBoundExclusive))
+}
+
+// isZero returns true if the range only includes the zero value, which also matches instance types without the resource spec
+func (r *Range[T]) isZero() bool {
+	var zero T
+	return r.LowerBound != nil && r.UpperBound != nil && !r.LowerBoundExclusive && !r.UpperBoundExclusive &&
+		compareRangeValues(*r.LowerBound, zero) == 0 && compareRangeValues(*r.UpperBound, zero) == 0
+}
+
+func (r *Range[T]) position(value T) int {
+	if r == nil {
+		return 0
+	}
+	if r.LowerBound != nil {
+		comparison := compareRangeValues(value, *r.LowerBound)
+		if comparison < 0 || (comparison == 0 && r.LowerBoundExclusive) {
+			return -1
+		}
+	}
+	if r.UpperBound != nil {
+		comparison := compareRangeValues(value, *r.UpperBound)
+		if comparison > 0 || (comparison == 0 && r.UpperBoundExclusive) {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (r *Range[T]) floatBounds() (float64, float64) {
+	lowerBound, upperBound := math.Inf(-1), math.Inf(1)
+	if r.LowerBound != nil {
+		lowerBound = rangeValueToFloat64(*r.LowerBound)
+	}
+	if r.UpperBound != nil {
+		upperBound = rangeValueToFloat64(*r.UpperBound)
+	}
+	return lowerBound, upperBound
+}
+
+func (r *Range[T]) floatPosition(value float64) int {
+	return r.position(rangeValueFromFloat64[T](value))
+}
+
+func (r *Range[T]) withFloatLowerBound(lowerBound float64) rangeFilter {
+	relaxed := *r
+	relaxed.LowerBound = pointerTo(rangeValueFromFloat64[T](lowerBound))
+	relaxed.LowerBoundExclusive = false
+	return &relaxed
+}
+
+func (r *Range[T]) withFloatUpperBound(upperBound float64) rangeFilter {
+	relaxed := *r
+	relaxed.UpperBound = pointerTo(rangeValueFromFloat64[T](upperBound))
+	relaxed.UpperBoundExclusive = false
+	return &relaxed
+}
+
+// mapRange returns a copy of the range with each bound converted by the passed in function
+func mapRange[T RangeValue, U RangeValue](r *Range[T], convert func(T) U) *Range[U] {
+	if r == nil {
+		return nil
+	}
+	mapped := &Range[U]{LowerBoundExclusive: r.LowerBoundExclusive, UpperBoundExclusive: r.UpperBoundExclusive}
+	if r.LowerBound != nil {
+		mapped.LowerBound = pointerTo(convert(*r.LowerBound))
+	}
+	if r.UpperBound != nil {
+		mapped.UpperBound = pointerTo(convert(*r.UpperBound))
+	}
+	return mapped
+}
+
+func compareRangeValues[T RangeValue](a T, b T) int {
+	switch a := any(a).(type) {
+	case int:
+		return cmp.Compare(a, any(b).(int))
+	case int32:
+		return cmp.Compare(a, any(b).(int32))
+	case int64:
+		return cmp.Compare(a, any(b).(int64))
+	case uint64:
+		return cmp.Compare(a, any(b).(uint64))
+	case float64:
+		return cmp.Compare(a, any(b).(float64))
+	case bytequantity.ByteQuantity:
+		return cmp.Compare(a.Quantity, any(b).(bytequantity.ByteQuantity).Quantity)
+	}
+	return 0
+}
+
+// rangeValueToFloat64 converts a bound to a float64, with byte quantities in MiB
+func rangeValueToFloat64[T RangeValue](value T) float64 {
+	switch v := any(value).(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float64:
+		return v
+	case bytequantity.ByteQuantity:
+		return float64(v.Quantity)
+	}
+	return 0
+}
+
+// rangeValueFromFloat64 converts a float64 to a bound, with byte quantities in MiB
+func rangeValueFromFloat64[T RangeValue](value float64) T {
+	var converted any
+	switch any(*new(T)).(type) {
+	case int:
+		converted = int(value)
+	case int32:
+		converted = int32(value)
+	case int64:
+		converted = int64(value)
+	case uint64:
+		converted = uint64(value)
+	case float64:
+		converted = value
+	case bytequantity.ByteQuantity:
+		converted = bytequantity.ByteQuantity{Quantity: uint64(value)}
+	}
+	return converted.(T)
+}
+
+func pointerTo[T any](value T) *T {
+	return &value
+}
+
+// ToRange returns the equivalent Range of an IntRangeFilter
+func (r *IntRangeFilter) ToRange() *Range[int] {
+	if r == nil {
+		return nil
+	}
+	return NewRange(r.LowerBound, r.UpperBound)
+}
+
+// ToRange returns the equivalent Range of an Int32RangeFilter
+func (r *Int32RangeFilter) ToRange() *Range[int32] {
+	if r == nil {
+		return nil
+	}
+	return NewRange(r.LowerBound, r.UpperBound)
+}
+
+// ToRange returns the equivalent Range of a Uint64RangeFilter
+func (r *Uint64RangeFilter) ToRange() *Range[uint64] {
+	if r == nil {
+		return nil
+	}
+	return NewRange(r.LowerBound, r.UpperBound)
+}
+
+// ToRange returns the equivalent Range of a ByteQuantityRangeFilter
+func (r *ByteQuantityRangeFilter) ToRange() *Range[bytequantity.ByteQuantity] {
+	if r == nil {
+		return nil
+	}
+	return NewRange(r.LowerBound, r.UpperBound)
+}
+
+// ToRange returns the equivalent Range of a Float64RangeFilter
+func (r *Float64RangeFilter) ToRange() *Range[float64] {
+	if r == nil {
+		return nil
+	}
+	return NewRange(r.LowerBound, r.UpperBound)
+}
@@ -0,0 +1,92 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestRange_Contains(t *testing.T) {
+	h.Assert(t, selector.NewRange(2, 4).Contains(2), "inclusive lower bound should be contained")
+	h.Assert(t, selector.NewRange(2, 4).Contains(4), "inclusive upper bound should be contained")
+	h.Assert(t, !selector.NewRange(2, 4).Contains(5), "value above the range should not be contained")
+	h.Assert(t, selector.RangeAtLeast[int32](4).Contains(1<<30), "range without an upper bound should contain large values")
+	h.Assert(t, !selector.RangeAtLeast[int32](4).Contains(3), "value below the lower bound should not be contained")
+	h.Assert(t, selector.RangeAtMost(0.5).Contains(-1), "range without a lower bound should contain small values")
+	h.Assert(t, !selector.RangeGreaterThan(4).Contains(4), "exclusive lower bound should not be contained")
+	h.Assert(t, !selector.RangeLessThan(bytequantity.FromGiB(8)).Contains(bytequantity.FromGiB(8)), "exclusive upper bound should not be contained")
+	h.Assert(t, selector.RangeLessThan(bytequantity.FromGiB(8)).Contains(bytequantity.FromMiB(8191)), "value below an exclusive upper bound should be contained")
+
+	var nilRange *selector.Range[int]
+	h.Assert(t, nilRange.Contains(42), "nil range should contain every value")
+}
+
+func TestRange_IsEmpty(t *testing.T) {
+	h.Assert(t, !selector.NewRange(4, 4).IsEmpty(), "range with equal inclusive bounds should not be empty")
+	h.Assert(t, selector.NewRange(8, 4).IsEmpty(), "range with a lower bound above the upper bound should be empty")
+	h.Assert(t, (&selector.Range[int]{LowerBound: selector.NewRange(4, 4).LowerBound, UpperBound: selector.NewRange(4, 4).UpperBound, UpperBoundExclusive: true}).IsEmpty(),
+		"range with equal bounds where one is exclusive should be empty")
+	h.Assert(t, !selector.RangeAtLeast(8).IsEmpty(), "range without an upper bound should not be empty")
+}
+
+func TestRange_JSON(t *testing.T) {
+	memoryRange := selector.RangeGreaterThan(bytequantity.FromGiB(8))
+	out, err := json.Marshal(memoryRange)
+	h.Ok(t, err)
+	h.Equals(t, `{"LowerBound":"8 GiB","LowerBoundExclusive":true}`, string(out))
+
+	parsed := &selector.Range[bytequantity.ByteQuantity]{}
+	h.Ok(t, json.Unmarshal(out, parsed))
+	h.Equals(t, memoryRange, parsed)
+
+	vcpusRange := &selector.Range[int32]{}
+	h.Ok(t, json.Unmarshal([]byte(`{"LowerBound": 2, "UpperBound": 8}`), vcpusRange))
+	h.Equals(t, selector.NewRange[int32](2, 8), vcpusRange)
+}
+
+func TestRange_ToRange(t *testing.T) {
+	h.Equals(t, selector.NewRange(1, 2), (&selector.IntRangeFilter{LowerBound: 1, UpperBound: 2}).ToRange())
+	h.Equals(t, selector.NewRange[int32](1, 2), (&selector.Int32RangeFilter{LowerBound: 1, UpperBound: 2}).ToRange())
+	h.Equals(t, selector.NewRange[uint64](1, 2), (&selector.Uint64RangeFilter{LowerBound: 1, UpperBound: 2}).ToRange())
+	h.Equals(t, selector.NewRange(1.5, 2.5), (&selector.Float64RangeFilter{LowerBound: 1.5, UpperBound: 2.5}).ToRange())
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(1), bytequantity.FromGiB(2)),
+		(&selector.ByteQuantityRangeFilter{LowerBound: bytequantity.FromGiB(1), UpperBound: bytequantity.FromGiB(2)}).ToRange())
+
+	var nilFilter *selector.Int32RangeFilter
+	h.Assert(t, nilFilter.ToRange() == nil, "nil range filter should convert to a nil range")
+}
+
+func TestFilter_OpenRange(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	ctx := context.Background()
+
+	results, err := itf.Filter(ctx, selector.Filters{VCpusRange: selector.RangeAtLeast[int32](2)})
+	h.Ok(t, err)
+	h.Equals(t, []string{"t3.micro"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{VCpusRange: selector.RangeGreaterThan[int32](2)})
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+
+	results, err = itf.Filter(ctx, selector.Filters{MemoryRange: selector.RangeLessThan(bytequantity.FromGiB(2))})
+	h.Ok(t, err)
+	h.Equals(t, []string{"t3.micro"}, results)
+}
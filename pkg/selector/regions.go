@@ -0,0 +1,186 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// RegionalSelectors creates and holds a Selector per region which are used to fan out multi-region queries.
+// Each regional Selector has its own instance type and pricing caches.
+type RegionalSelectors struct {
+	newSelector func(ctx context.Context, region string) (*Selector, error)
+	selectors   map[string]*Selector
+	logger      *log.Logger
+	mu          sync.Mutex
+}
+
+// NewRegionalSelectors creates a RegionalSelectors which calls newSelector to create the Selector for a region on first use.
+func NewRegionalSelectors(newSelector func(ctx context.Context, region string) (*Selector, error)) *RegionalSelectors {
+	return &RegionalSelectors{
+		newSelector: newSelector,
+		selectors:   map[string]*Selector{},
+	}
+}
+
+// Get returns the Selector for the region, creating it if it does not exist yet.
+func (r *RegionalSelectors) Get(ctx context.Context, region string) (*Selector, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if regionalSelector, ok := r.selectors[region]; ok {
+		return regionalSelector, nil
+	}
+	regionalSelector, err := r.newSelector(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the selector for region %s: %w", region, err)
+	}
+	if r.logger != nil {
+		regionalSelector.SetLogger(r.logger)
+	}
+	r.selectors[region] = regionalSelector
+	return regionalSelector, nil
+}
+
+// Save persists the cache data of every regional Selector to disk if caching is configured.
+func (r *RegionalSelectors) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs error
+	for _, regionalSelector := range r.selectors {
+		errs = multierr.Append(errs, regionalSelector.Save())
+	}
+	return errs
+}
+
+// SetLogger sets the logger on every existing and future regional Selector.
+func (r *RegionalSelectors) SetLogger(logger *log.Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logger = logger
+	for _, regionalSelector := range r.selectors {
+		regionalSelector.SetLogger(logger)
+	}
+}
+
+// rawFilterRegions selects the instance types matching the filters in each of the Regions with the corresponding regional Selector
+// and combines the results based on the RegionMode. The selected instance types are annotated with their availability and
+// prices in every region, and the top-level prices are from the first region in which the instance type is available.
+func (s Selector) rawFilterRegions(ctx context.Context, filters Filters) ([]*instancetypes.Details, error) {
+	if s.RegionalSelectors == nil {
+		return nil, fmt.Errorf("filtering multiple regions requires the selector to be configured with RegionalSelectors")
+	}
+	if filters.AvailabilityZones != nil && len(*filters.AvailabilityZones) > 0 {
+		return nil, fmt.Errorf("availability zones cannot be combined with multiple regions")
+	}
+	regionMode := RegionModeAll
+	if filters.RegionMode != nil {
+		regionMode = *filters.RegionMode
+	}
+	if regionMode != RegionModeAll && regionMode != RegionModeAny {
+		return nil, fmt.Errorf("region mode %s is not supported, must be one of %v", regionMode, RegionModeAll.Values())
+	}
+	regions := *filters.Regions
+
+	regionalResults := make([]map[string]*instancetypes.Details, len(regions))
+	var errs error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region string) {
+			defer wg.Done()
+			instanceTypeDetails, err := s.rawFilterRegion(ctx, filters, region)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				return
+			}
+			regionalResults[i] = map[string]*instancetypes.Details{}
+			for _, instanceTypeInfo := range instanceTypeDetails {
+				regionalResults[i][string(instanceTypeInfo.InstanceType)] = instanceTypeInfo
+			}
+		}(i, region)
+	}
+	wg.Wait()
+	if errs != nil {
+		return nil, errs
+	}
+
+	instanceTypes := map[string]*instancetypes.Details{}
+	for i, region := range regions {
+		for instanceTypeName, regionalInstanceTypeInfo := range regionalResults[i] {
+			instanceTypeInfo, ok := instanceTypes[instanceTypeName]
+			if !ok {
+				details := *regionalInstanceTypeInfo
+				details.Regions = make([]instancetypes.RegionDetails, len(regions))
+				for j, r := range regions {
+					details.Regions[j] = instancetypes.RegionDetails{Region: r}
+				}
+				instanceTypeInfo = &details
+				instanceTypes[instanceTypeName] = instanceTypeInfo
+			}
+			instanceTypeInfo.Regions[i] = instancetypes.RegionDetails{
+				Region:               region,
+				Available:            true,
+				OndemandPricePerHour: regionalInstanceTypeInfo.OndemandPricePerHour,
+				SpotPrice:            regionalInstanceTypeInfo.SpotPrice,
+			}
+		}
+	}
+
+	selectedInstanceTypes := []*instancetypes.Details{}
+	for _, instanceTypeInfo := range instanceTypes {
+		availableRegions := 0
+		for _, regionDetails := range instanceTypeInfo.Regions {
+			if regionDetails.Available {
+				availableRegions++
+			}
+		}
+		if regionMode == RegionModeAll && availableRegions != len(regions) {
+			continue
+		}
+		selectedInstanceTypes = append(selectedInstanceTypes, instanceTypeInfo)
+	}
+	return sortInstanceTypeInfo(selectedInstanceTypes), nil
+}
+
+// rawFilterRegion selects the instance types matching the filters within a single region.
+func (s Selector) rawFilterRegion(ctx context.Context, filters Filters, region string) ([]*instancetypes.Details, error) {
+	regionalSelectorPtr, err := s.RegionalSelectors.Get(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	// regional selectors apply the same flexible profiles, custom transforms, and custom filters as the selector fanning out the query
+	regionalSelector := *regionalSelectorPtr
+	regionalSelector.FilterRegistry = s.FilterRegistry
+	regionalSelector.FlexibleProfiles = s.FlexibleProfiles
+	regionalSelector.TransformsBefore = s.TransformsBefore
+	regionalSelector.TransformsAfter = s.TransformsAfter
+	regionalFilters := filters
+	regionalFilters.Regions = nil
+	regionalFilters.RegionMode = nil
+	regionalFilters.Region = &region
+	instanceTypeDetails, err := regionalSelector.rawFilter(ctx, regionalFilters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to filter instance types in region %s: %w", region, err)
+	}
+	return instanceTypeDetails, nil
+}
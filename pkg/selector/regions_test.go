@@ -0,0 +1,156 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func getMultiRegionSelector(t *testing.T) selector.Selector {
+	instanceTypesResp := setupMock(t, describeInstanceTypes, "25_instances.json").DescribeInstanceTypesResp
+	regionalMocks := map[string]mockedEC2{
+		"us-east-2": {
+			DescribeInstanceTypesResp:         instanceTypesResp,
+			DescribeInstanceTypeOfferingsResp: setupMock(t, describeInstanceTypeOfferings, "us-east-2a.json").DescribeInstanceTypeOfferingsResp,
+			DescribeAvailabilityZonesResp:     setupMock(t, describeAvailabilityZones, "us-east-2.json").DescribeAvailabilityZonesResp,
+		},
+		"us-west-2": {
+			DescribeInstanceTypesResp: instanceTypesResp,
+			DescribeInstanceTypeOfferingsResp: ec2.DescribeInstanceTypeOfferingsOutput{
+				InstanceTypeOfferings: []ec2types.InstanceTypeOffering{
+					{InstanceType: ec2types.InstanceTypeC4Large, Location: aws.String("us-west-2"), LocationType: ec2types.LocationTypeRegion},
+					{InstanceType: ec2types.InstanceTypeA1Large, Location: aws.String("us-west-2"), LocationType: ec2types.LocationTypeRegion},
+				},
+			},
+			DescribeAvailabilityZonesResp: ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []ec2types.AvailabilityZone{
+					{RegionName: aws.String("us-west-2"), ZoneName: aws.String("us-west-2a"), ZoneId: aws.String("usw2-az1")},
+				},
+			},
+		},
+	}
+	itf := getSelector(regionalMocks["us-east-2"])
+	itf.RegionalSelectors = selector.NewRegionalSelectors(func(ctx context.Context, region string) (*selector.Selector, error) {
+		ec2Mock, ok := regionalMocks[region]
+		if !ok {
+			return nil, errors.New("region is not mocked")
+		}
+		regionalSelector := getSelector(ec2Mock)
+		return &regionalSelector, nil
+	})
+	return itf
+}
+
+// Tests
+
+func TestFilterVerbose_RegionsAll(t *testing.T) {
+	itf := getMultiRegionSelector(t)
+	filters := selector.Filters{
+		AllowList: regexp.MustCompile("c4.*"),
+		Regions:   &[]string{"us-east-2", "us-west-2"},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(results))
+	h.Equals(t, ec2types.InstanceTypeC4Large, results[0].InstanceType)
+	h.Equals(t, []instancetypes.RegionDetails{
+		{Region: "us-east-2", Available: true},
+		{Region: "us-west-2", Available: true},
+	}, results[0].Regions)
+}
+
+func TestFilterVerbose_RegionsAny(t *testing.T) {
+	itf := getMultiRegionSelector(t)
+	regionMode := selector.RegionModeAny
+	filters := selector.Filters{
+		AllowList:  regexp.MustCompile("c4.*"),
+		Regions:    &[]string{"us-east-2", "us-west-2"},
+		RegionMode: &regionMode,
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 5, len(results))
+	availableRegions := map[ec2types.InstanceType][]string{}
+	for _, result := range results {
+		for _, regionDetails := range result.Regions {
+			if regionDetails.Available {
+				availableRegions[result.InstanceType] = append(availableRegions[result.InstanceType], regionDetails.Region)
+			}
+		}
+	}
+	h.Equals(t, []string{"us-east-2", "us-west-2"}, availableRegions[ec2types.InstanceTypeC4Large])
+	h.Equals(t, []string{"us-east-2"}, availableRegions[ec2types.InstanceTypeC4Xlarge])
+}
+
+func TestFilter_RegionsMaxResults(t *testing.T) {
+	itf := getMultiRegionSelector(t)
+	regionMode := selector.RegionModeAny
+	filters := selector.Filters{
+		Regions:    &[]string{"us-west-2", "us-east-2"},
+		RegionMode: &regionMode,
+		MaxResults: aws.Int(2),
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []string{"a1.2xlarge", "a1.4xlarge"}, results)
+}
+
+func TestFilter_RegionsErrors(t *testing.T) {
+	itf := getMultiRegionSelector(t)
+	ctx := context.Background()
+
+	_, err := itf.Filter(ctx, selector.Filters{
+		Regions:           &[]string{"us-east-2", "us-west-2"},
+		AvailabilityZones: &[]string{"us-east-2a"},
+	})
+	h.Nok(t, err)
+
+	regionMode := selector.RegionMode("some")
+	_, err = itf.Filter(ctx, selector.Filters{
+		Regions:    &[]string{"us-east-2", "us-west-2"},
+		RegionMode: &regionMode,
+	})
+	h.Nok(t, err)
+
+	_, err = itf.Filter(ctx, selector.Filters{
+		Regions: &[]string{"us-east-2", "eu-west-1"},
+	})
+	h.Nok(t, err)
+
+	_, err = itf.Explain(ctx, selector.Filters{
+		Regions: &[]string{"us-east-2", "us-west-2"},
+	})
+	h.Nok(t, err)
+
+	itf.RegionalSelectors = nil
+	_, err = itf.Filter(ctx, selector.Filters{
+		Regions: &[]string{"us-east-2", "us-west-2"},
+	})
+	h.Nok(t, err)
+}
@@ -0,0 +1,239 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// RelaxationAction is the type of change a RelaxationSuggestion makes to a filter.
+type RelaxationAction string
+
+// Enum values for RelaxationAction.
+const (
+	RelaxationLowerBound             RelaxationAction = "lower-bound"
+	RelaxationUpperBound             RelaxationAction = "upper-bound"
+	RelaxationRemoveFilter           RelaxationAction = "remove-filter"
+	RelaxationRemoveAvailabilityZone RelaxationAction = "remove-availability-zone"
+)
+
+// RelaxationSuggestion is a single change to the filters which would produce results.
+type RelaxationSuggestion struct {
+	// Filter is the filter key which is relaxed (i.e. memoryRange, hibernationSupported, location)
+	Filter string
+
+	// Action is the change made to the filter
+	Action RelaxationAction
+
+	// FilterValue is the relaxed filter value for bound changes, the remaining availability zones for
+	// availability zone removals, and nil for filter removals
+	FilterValue interface{}
+
+	// AvailabilityZone is the availability zone which is removed for availability zone removals
+	AvailabilityZone string
+
+	// Results is the number of instance types the relaxed filters would return
+	Results int
+
+	// Change is the relative size of the change used to rank suggestions
+	// Bound changes are relative to the original bound and removals are 1
+	Change float64
+}
+
+// SuggestRelaxations accepts a Filters struct and returns the smallest single filter changes that would produce results
+// ranked by the relative size of the change and then by the number of results.
+// Range filters are widened to the nearest bound which includes an instance type, other filters are removed, and availability
+// zones are removed since instance types must be offered in every availability zone.
+// Only instance types which fail a single filter are considered, so an empty slice is returned if every instance type fails multiple filters.
+func (s Selector) SuggestRelaxations(ctx context.Context, filters Filters) ([]RelaxationSuggestion, error) {
+	group, err := s.prepareFilterGroup(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	instanceTypeDetails, err := s.InstanceTypesProvider.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	soleFailures := map[string][]filterFailure{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, instanceTypeInfo := range instanceTypeDetails {
+		wg.Add(1)
+		go func(instanceTypeInfo instancetypes.Details) {
+			defer wg.Done()
+			failures := s.findFilterFailures(ctx, group, &instanceTypeInfo)
+			if len(failures) != 1 || failures[0].err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			soleFailures[failures[0].filterName] = append(soleFailures[failures[0].filterName], failures[0])
+		}(*instanceTypeInfo)
+	}
+	wg.Wait()
+
+	suggestions := []RelaxationSuggestion{}
+	for filterName, failures := range soleFailures {
+		if filterName == locationFilterKey {
+			locationSuggestions, err := s.suggestLocationRelaxations(ctx, group, failures)
+			if err != nil {
+				return nil, err
+			}
+			suggestions = append(suggestions, locationSuggestions...)
+			continue
+		}
+		suggestions = append(suggestions, suggestFilterRelaxations(filterName, failures)...)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Change != suggestions[j].Change {
+			return suggestions[i].Change < suggestions[j].Change
+		}
+		if suggestions[i].Results != suggestions[j].Results {
+			return suggestions[i].Results > suggestions[j].Results
+		}
+		if suggestions[i].Filter != suggestions[j].Filter {
+			return suggestions[i].Filter < suggestions[j].Filter
+		}
+		return suggestions[i].Action < suggestions[j].Action
+	})
+	return suggestions, nil
+}
+
+// suggestFilterRelaxations widens a range filter to the nearest lower and upper bounds which include an instance type
+// or removes the filter if it is not a range filter or an instance type's spec value is not numeric.
+func suggestFilterRelaxations(filterName string, failures []filterFailure) []RelaxationSuggestion {
+	removal := RelaxationSuggestion{Filter: filterName, Action: RelaxationRemoveFilter, Results: len(failures), Change: 1}
+	filterValue := failures[0].pair.filterValue
+	filter, ok := filterValue.(rangeFilter)
+	if !ok {
+		return []RelaxationSuggestion{removal}
+	}
+	lowerBound, upperBound := filter.floatBounds()
+	below := []float64{}
+	above := []float64{}
+	for _, failure := range failures {
+		spec, ok := numericSpec(failure.pair.instanceSpec)
+		if !ok {
+			return []RelaxationSuggestion{removal}
+		}
+		switch filter.floatPosition(spec) {
+		case -1:
+			below = append(below, spec)
+		case 1:
+			above = append(above, spec)
+		}
+	}
+	suggestions := []RelaxationSuggestion{}
+	if len(below) > 0 {
+		nearest := below[0]
+		for _, spec := range below {
+			nearest = math.Max(nearest, spec)
+		}
+		suggestions = append(suggestions, RelaxationSuggestion{
+			Filter:      filterName,
+			Action:      RelaxationLowerBound,
+			FilterValue: filter.withFloatLowerBound(nearest),
+			Results:     countEqual(below, nearest),
+			Change:      (lowerBound - nearest) / math.Max(math.Abs(lowerBound), 1),
+		})
+	}
+	if len(above) > 0 {
+		nearest := above[0]
+		for _, spec := range above {
+			nearest = math.Min(nearest, spec)
+		}
+		suggestions = append(suggestions, RelaxationSuggestion{
+			Filter:      filterName,
+			Action:      RelaxationUpperBound,
+			FilterValue: filter.withFloatUpperBound(nearest),
+			Results:     countEqual(above, nearest),
+			Change:      (nearest - upperBound) / math.Max(math.Abs(upperBound), 1),
+		})
+	}
+	return suggestions
+}
+
+// suggestLocationRelaxations removes each availability zone in the filter group and counts the instance types which would then be offered.
+func (s Selector) suggestLocationRelaxations(ctx context.Context, group *filterGroup, failures []filterFailure) ([]RelaxationSuggestion, error) {
+	suggestions := []RelaxationSuggestion{}
+	for i, availabilityZone := range group.availabilityZones {
+		remainingAvailabilityZones := append(append([]string{}, group.availabilityZones[:i]...), group.availabilityZones[i+1:]...)
+		relaxedFilters := group.filters
+		relaxedFilters.AvailabilityZones = nil
+		if len(remainingAvailabilityZones) > 0 {
+			relaxedFilters.AvailabilityZones = &remainingAvailabilityZones
+		}
+		locationInstanceOfferings, err := s.RetrieveInstanceTypesSupportedInLocations(ctx, getLocations(relaxedFilters))
+		if err != nil {
+			return nil, err
+		}
+		results := 0
+		for _, failure := range failures {
+			if instanceType, ok := failure.pair.instanceSpec.(string); ok && isSupportedInLocation(locationInstanceOfferings, ec2types.InstanceType(instanceType)) {
+				results++
+			}
+		}
+		if results == 0 {
+			continue
+		}
+		suggestions = append(suggestions, RelaxationSuggestion{
+			Filter:           locationFilterKey,
+			Action:           RelaxationRemoveAvailabilityZone,
+			FilterValue:      remainingAvailabilityZones,
+			AvailabilityZone: availabilityZone,
+			Results:          results,
+			Change:           1,
+		})
+	}
+	return suggestions, nil
+}
+
+// numericSpec returns an instance spec value as a float64 if it is numeric.
+func numericSpec(instanceSpec interface{}) (float64, bool) {
+	switch spec := instanceSpec.(type) {
+	case *int:
+		if spec != nil {
+			return float64(*spec), true
+		}
+	case *int32:
+		if spec != nil {
+			return float64(*spec), true
+		}
+	case *int64:
+		if spec != nil {
+			return float64(*spec), true
+		}
+	case *float64:
+		if spec != nil {
+			return *spec, true
+		}
+	}
+	return 0, false
+}
+
+func countEqual(values []float64, value float64) int {
+	count := 0
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,126 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestSuggestRelaxations_Range(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		VCpusRange: selector.NewRange[int32](4, 8),
+	}
+	ctx := context.Background()
+	suggestions, err := itf.SuggestRelaxations(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []selector.RelaxationSuggestion{
+		{
+			Filter:      "vcpusRange",
+			Action:      selector.RelaxationLowerBound,
+			FilterValue: selector.NewRange[int32](2, 8),
+			Results:     1,
+			Change:      0.5,
+		},
+		{
+			Filter:      "vcpusRange",
+			Action:      selector.RelaxationUpperBound,
+			FilterValue: selector.NewRange[int32](4, 64),
+			Results:     1,
+			Change:      7,
+		},
+	}, suggestions)
+}
+
+func TestSuggestRelaxations_ByteQuantityRange(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		MemoryRange: selector.NewRange(bytequantity.FromGiB(2), bytequantity.FromGiB(4)),
+	}
+	ctx := context.Background()
+	suggestions, err := itf.SuggestRelaxations(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 2, len(suggestions))
+	h.Equals(t, selector.RelaxationLowerBound, suggestions[0].Action)
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(1), bytequantity.FromGiB(4)), suggestions[0].FilterValue)
+	h.Equals(t, selector.RelaxationUpperBound, suggestions[1].Action)
+	h.Equals(t, selector.NewRange(bytequantity.FromGiB(2), bytequantity.FromGiB(488)), suggestions[1].FilterValue)
+}
+
+func TestSuggestRelaxations_RemoveFilter(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		BareMetal: aws.Bool(true),
+	}
+	ctx := context.Background()
+	suggestions, err := itf.SuggestRelaxations(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []selector.RelaxationSuggestion{
+		{Filter: "baremetal", Action: selector.RelaxationRemoveFilter, Results: 2, Change: 1},
+	}, suggestions)
+}
+
+func TestSuggestRelaxations_MultipleFailures(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		BareMetal:  aws.Bool(true),
+		VCpusRange: selector.NewRange[int32](4, 8),
+	}
+	ctx := context.Background()
+	suggestions, err := itf.SuggestRelaxations(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []selector.RelaxationSuggestion{}, suggestions)
+}
+
+func TestSuggestRelaxations_RemoveAvailabilityZone(t *testing.T) {
+	ec2Mock := mockedEC2{
+		DescribeInstanceTypesResp:         setupMock(t, describeInstanceTypes, "25_instances.json").DescribeInstanceTypesResp,
+		DescribeInstanceTypeOfferingsResp: setupMock(t, describeInstanceTypeOfferings, "us-east-2a_only_c5d12x.json").DescribeInstanceTypeOfferingsResp,
+		DescribeAvailabilityZonesResp:     setupMock(t, describeAvailabilityZones, "us-east-2.json").DescribeAvailabilityZonesResp,
+	}
+	itf := getSelector(ec2Mock)
+	filters := selector.Filters{
+		AvailabilityZones: &[]string{"us-east-2a"},
+	}
+	ctx := context.Background()
+	suggestions, err := itf.SuggestRelaxations(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, []selector.RelaxationSuggestion{
+		{
+			Filter:           "location",
+			Action:           selector.RelaxationRemoveAvailabilityZone,
+			FilterValue:      []string{},
+			AvailabilityZone: "us-east-2a",
+			Results:          25,
+			Change:           1,
+		},
+	}, suggestions)
+}
+
+func TestSuggestRelaxations_Failure(t *testing.T) {
+	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("error")})
+	ctx := context.Background()
+	suggestions, err := itf.SuggestRelaxations(ctx, selector.Filters{})
+	h.Assert(t, suggestions == nil, "Suggestions should be nil")
+	h.Nok(t, err)
+}
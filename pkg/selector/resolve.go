@@ -0,0 +1,67 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// ResolvedFilters is the effective Filters after the aggregate filter transforms.
+type ResolvedFilters struct {
+	// Filters are the filters after all of the aggregate filter transforms
+	Filters Filters
+
+	// SetBy maps the name of each set field of the Filters to the stage which last set or changed it
+	// Fields which were passed in and not changed by a transform are set by TransformStageInput
+	SetBy map[string]string
+}
+
+// Fields returns the names of the set fields of the resolved Filters sorted alphabetically.
+func (r *ResolvedFilters) Fields() []string {
+	fields := []string{}
+	for field := range r.SetBy {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ResolveFilters runs the aggregate filter transforms and returns the effective Filters annotated with the transform which set each field.
+// Instance types are not retrieved or filtered, so this can be used to inspect what a Filter call would search for.
+func (s Selector) ResolveFilters(ctx context.Context, filters Filters) (*ResolvedFilters, error) {
+	stages, err := s.AggregateFilterTransformStages(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	setBy := map[string]string{}
+	for i, stage := range stages {
+		stageValue := reflect.ValueOf(stage.Filters)
+		for j := 0; j < stageValue.NumField(); j++ {
+			field := stageValue.Type().Field(j).Name
+			value := stageValue.Field(j)
+			if value.IsNil() {
+				delete(setBy, field)
+				continue
+			}
+			if i == 0 || !reflect.DeepEqual(value.Interface(), reflect.ValueOf(stages[i-1].Filters).Field(j).Interface()) {
+				setBy[field] = stage.Name
+			}
+		}
+	}
+	return &ResolvedFilters{
+		Filters: stages[len(stages)-1].Filters,
+		SetBy:   setBy,
+	}, nil
+}
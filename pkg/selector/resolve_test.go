@@ -0,0 +1,79 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestResolveFilters(t *testing.T) {
+	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("instance types should not be retrieved")})
+	itf.ServiceRegistry.Register("emr", &selector.EMR{})
+	filters := selector.Filters{
+		Flexible:    aws.Bool(true),
+		MemoryRange: selector.NewRange(bytequantity.FromGiB(8), bytequantity.FromGiB(8)),
+		BareMetal:   aws.Bool(true),
+		Service:     aws.String("emr-5.20.0"),
+	}
+	ctx := context.Background()
+	resolvedFilters, err := itf.ResolveFilters(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, map[string]string{
+		"AllowList":          selector.TransformStageFlexible,
+		"BareMetal":          selector.TransformStageInput,
+		"CPUArchitecture":    selector.TransformStageFlexible,
+		"Flexible":           selector.TransformStageInput,
+		"Fpga":               selector.TransformStageFlexible,
+		"InstanceTypes":      selector.TransformStageService,
+		"MemoryRange":        selector.TransformStageInput,
+		"RootDeviceType":     selector.TransformStageService,
+		"Service":            selector.TransformStageInput,
+		"VirtualizationType": selector.TransformStageService,
+	}, resolvedFilters.SetBy)
+	h.Equals(t, []string{"AllowList", "BareMetal", "CPUArchitecture", "Flexible", "Fpga", "InstanceTypes", "MemoryRange", "RootDeviceType", "Service", "VirtualizationType"}, resolvedFilters.Fields())
+	h.Assert(t, resolvedFilters.Filters.VCpusRange == nil, "flexible should not set vcpus when memory is set")
+	h.Equals(t, true, *resolvedFilters.Filters.BareMetal)
+}
+
+func TestResolveFilters_CustomTransform(t *testing.T) {
+	itf := selector.Selector{}
+	h.Ok(t, itf.RegisterTransform("deny-previous-generation", selector.TransformBeforeBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+	h.Ok(t, itf.RegisterTransform("no-bare-metal", selector.TransformAfterBuiltIns, selector.TransformFn(func(ctx context.Context, filters selector.Filters) (selector.Filters, error) {
+		filters.BareMetal = aws.Bool(false)
+		return filters, nil
+	})))
+	ctx := context.Background()
+	resolvedFilters, err := itf.ResolveFilters(ctx, selector.Filters{BareMetal: aws.Bool(true)})
+	h.Ok(t, err)
+	h.Equals(t, map[string]string{
+		"BareMetal": "no-bare-metal",
+		"DenyList":  "deny-previous-generation",
+	}, resolvedFilters.SetBy)
+}
+
+func TestResolveFilters_Error(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "empty.json"))
+	ctx := context.Background()
+	_, err := itf.ResolveFilters(ctx, selector.Filters{InstanceTypeBase: aws.String("t3.microoon")})
+	h.Nok(t, err)
+}
@@ -0,0 +1,150 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+)
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	// byteQuantitySchemaPattern matches the byte quantity strings accepted by bytequantity.ParseToByteQuantity
+	byteQuantitySchemaPattern = `^[0-9]+\.?[0-9]{0,3} ?([mMgGtT][iI]?[bB]?)?$`
+)
+
+var (
+	regexpType       = reflect.TypeOf(regexp.Regexp{})
+	expressionType   = reflect.TypeOf(Expression{})
+	byteQuantityType = reflect.TypeOf(bytequantity.ByteQuantity{})
+	// legacyEnumValues are enum values accepted by the selector which are not returned by the enum's Values()
+	legacyEnumValues = map[reflect.Type][]string{
+		reflect.TypeOf(ec2types.ArchitectureType("")):   {string(ArchitectureTypeAMD64)},
+		reflect.TypeOf(ec2types.VirtualizationType("")): {string(VirtualizationTypePv)},
+	}
+)
+
+// FiltersJSONSchema returns a JSON Schema describing the json and yaml representation of Filters.
+func FiltersJSONSchema() ([]byte, error) {
+	definitions := map[string]interface{}{}
+	filtersSchema, err := jsonSchemaFor(reflect.TypeOf(Filters{}), definitions)
+	if err != nil {
+		return nil, err
+	}
+	schema := map[string]interface{}{
+		"$schema": jsonSchemaDraft,
+		"title":   "Filters",
+		"$defs":   definitions,
+	}
+	for key, value := range filtersSchema {
+		schema[key] = value
+	}
+	return json.MarshalIndent(schema, "", "    ")
+}
+
+// jsonSchemaFor returns the JSON Schema of a type, adding the schemas of the structs it references to the definitions.
+func jsonSchemaFor(t reflect.Type, definitions map[string]interface{}) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case regexpType:
+		return map[string]interface{}{"type": "string", "format": "regex"}, nil
+	case expressionType:
+		return map[string]interface{}{"type": "string"}, nil
+	case byteQuantityType:
+		return map[string]interface{}{"type": "string", "pattern": byteQuantitySchemaPattern}, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.String:
+		schema := map[string]interface{}{"type": "string"}
+		if enum := enumValues(t); enum != nil {
+			schema["enum"] = enum
+		}
+		return schema, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Slice:
+		items, err := jsonSchemaFor(t.Elem(), definitions)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}, nil
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldSchema, err := jsonSchemaFor(field.Type, definitions)
+			if err != nil {
+				return nil, fmt.Errorf("unable to describe field %s: %w", field.Name, err)
+			}
+			properties[field.Name] = fieldSchema
+		}
+		schema := map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if t == reflect.TypeOf(Filters{}) {
+			return schema, nil
+		}
+		name := definitionName(t)
+		definitions[name] = schema
+		return map[string]interface{}{"$ref": "#/$defs/" + name}, nil
+	}
+	return nil, fmt.Errorf("type %s is not supported", t)
+}
+
+// definitionName returns the name of a struct's schema definition, which for a generic type such as
+// Range[bytequantity.ByteQuantity] is its type argument followed by its name, e.g. ByteQuantityRange.
+func definitionName(t reflect.Type) string {
+	name, typeArgument, isGeneric := strings.Cut(t.Name(), "[")
+	if !isGeneric {
+		return name
+	}
+	typeArgument = strings.TrimSuffix(typeArgument, "]")
+	typeArgument = typeArgument[strings.LastIndex(typeArgument, ".")+1:]
+	return strings.ToUpper(typeArgument[:1]) + typeArgument[1:] + name
+}
+
+// enumValues returns the known values of a string enum type with a Values() method, or nil if the type is not an enum.
+func enumValues(t reflect.Type) []string {
+	valuesMethod, ok := t.MethodByName("Values")
+	if !ok {
+		return nil
+	}
+	values := valuesMethod.Func.Call([]reflect.Value{reflect.Zero(t)})[0]
+	enum := []string{}
+	for i := 0; i < values.Len(); i++ {
+		enum = append(enum, values.Index(i).String())
+	}
+	return append(enum, legacyEnumValues[t]...)
+}
@@ -0,0 +1,66 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+const filtersSchemaPath = "../../schema/filters.schema.json"
+
+var updateSchema = flag.Bool("update-schema", false, "regenerate the published filters JSON Schema")
+
+// Tests
+
+func TestFiltersJSONSchema_Published(t *testing.T) {
+	schema, err := selector.FiltersJSONSchema()
+	h.Ok(t, err)
+	schema = append(schema, '\n')
+	if *updateSchema {
+		h.Ok(t, os.WriteFile(filtersSchemaPath, schema, 0o644))
+	}
+	published, err := os.ReadFile(filtersSchemaPath)
+	h.Ok(t, err)
+	h.Assert(t, string(schema) == string(published), "%s is out of date, run make filters-schema", filtersSchemaPath)
+}
+
+func TestFiltersJSONSchema(t *testing.T) {
+	out, err := selector.FiltersJSONSchema()
+	h.Ok(t, err)
+	schema := map[string]interface{}{}
+	h.Ok(t, json.Unmarshal(out, &schema))
+	h.Equals(t, false, schema["additionalProperties"])
+
+	properties := schema["properties"].(map[string]interface{})
+	h.Equals(t, reflect.TypeOf(selector.Filters{}).NumField(), len(properties))
+	h.Equals(t, map[string]interface{}{"type": "boolean"}, properties["BareMetal"])
+	h.Equals(t, map[string]interface{}{"$ref": "#/$defs/ByteQuantityRange"}, properties["MemoryRange"])
+	h.Equals(t, map[string]interface{}{"type": "string", "format": "regex"}, properties["AllowList"])
+	h.Equals(t, []interface{}{"all", "any"}, properties["RegionMode"].(map[string]interface{})["enum"])
+	cpuArchitectures := []string{}
+	for _, value := range properties["CPUArchitecture"].(map[string]interface{})["enum"].([]interface{}) {
+		cpuArchitectures = append(cpuArchitectures, value.(string))
+	}
+	h.Assert(t, contains(cpuArchitectures, "amd64"), "CPUArchitecture does not include the legacy amd64 value")
+
+	definitions := schema["$defs"].(map[string]interface{})
+	memoryRange := definitions["ByteQuantityRange"].(map[string]interface{})["properties"].(map[string]interface{})
+	h.Equals(t, "string", memoryRange["LowerBound"].(map[string]interface{})["type"])
+}
@@ -0,0 +1,198 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// Attributes which can be weighted when scoring instance types.
+const (
+	ScoreOnDemandPrice = "on-demand-price"
+	ScoreSpotPrice     = "spot-price"
+	ScoreVCPUs         = "vcpus"
+	ScoreMemory        = "memory"
+	ScoreNetwork       = "network"
+	ScoreGeneration    = "generation"
+	ScoreEBSBandwidth  = "ebs-bandwidth"
+)
+
+// ScoreWeights maps an attribute (i.e. on-demand-price, vcpus) to its weight in the composite score.
+type ScoreWeights map[string]float64
+
+// scoreAttribute retrieves the raw value of an attribute from an instance type and whether lower values are better.
+type scoreAttribute struct {
+	value         func(instanceTypeInfo *instancetypes.Details) *float64
+	lowerIsBetter bool
+}
+
+var scoreAttributes = map[string]scoreAttribute{
+	ScoreOnDemandPrice: {
+		value:         func(instanceTypeInfo *instancetypes.Details) *float64 { return instanceTypeInfo.OndemandPricePerHour },
+		lowerIsBetter: true,
+	},
+	ScoreSpotPrice: {
+		value:         func(instanceTypeInfo *instancetypes.Details) *float64 { return instanceTypeInfo.SpotPrice },
+		lowerIsBetter: true,
+	},
+	ScoreVCPUs: {
+		value: func(instanceTypeInfo *instancetypes.Details) *float64 {
+			if instanceTypeInfo.VCpuInfo == nil || instanceTypeInfo.VCpuInfo.DefaultVCpus == nil {
+				return nil
+			}
+			vcpus := float64(*instanceTypeInfo.VCpuInfo.DefaultVCpus)
+			return &vcpus
+		},
+	},
+	ScoreMemory: {
+		value: func(instanceTypeInfo *instancetypes.Details) *float64 {
+			if instanceTypeInfo.MemoryInfo == nil || instanceTypeInfo.MemoryInfo.SizeInMiB == nil {
+				return nil
+			}
+			memory := float64(*instanceTypeInfo.MemoryInfo.SizeInMiB)
+			return &memory
+		},
+	},
+	ScoreNetwork: {
+		value: func(instanceTypeInfo *instancetypes.Details) *float64 {
+			networkGbps := getNetworkBandwidthGbps(instanceTypeInfo.NetworkInfo)
+			if networkGbps <= 0 {
+				return nil
+			}
+			return &networkGbps
+		},
+	},
+	ScoreGeneration: {
+		value: func(instanceTypeInfo *instancetypes.Details) *float64 {
+			generation := float64(*getInstanceTypeGeneration(string(instanceTypeInfo.InstanceType)))
+			return &generation
+		},
+	},
+	ScoreEBSBandwidth: {
+		value: func(instanceTypeInfo *instancetypes.Details) *float64 {
+			if instanceTypeInfo.EbsInfo == nil || instanceTypeInfo.EbsInfo.EbsOptimizedInfo == nil || instanceTypeInfo.EbsInfo.EbsOptimizedInfo.BaselineBandwidthInMbps == nil {
+				return nil
+			}
+			bandwidth := float64(*instanceTypeInfo.EbsInfo.EbsOptimizedInfo.BaselineBandwidthInMbps)
+			return &bandwidth
+		},
+	},
+}
+
+// ScoreAttributes returns the attributes which can be weighted when scoring instance types.
+func ScoreAttributes() []string {
+	attributes := []string{}
+	for attribute := range scoreAttributes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+// ParseScoreWeights parses weights in the form attribute=weight (i.e. on-demand-price=2).
+func ParseScoreWeights(weights []string) (ScoreWeights, error) {
+	scoreWeights := ScoreWeights{}
+	for _, weight := range weights {
+		attributeAndWeight := strings.SplitN(weight, "=", 2)
+		if len(attributeAndWeight) != 2 {
+			return nil, fmt.Errorf("score weight %s must be in the form attribute=weight", weight)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(attributeAndWeight[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("score weight %s is not a number: %w", weight, err)
+		}
+		scoreWeights[strings.TrimSpace(attributeAndWeight[0])] = value
+	}
+	return scoreWeights, scoreWeights.Validate()
+}
+
+// Validate returns an error if an attribute is not supported, a weight is negative, or every weight is 0.
+func (w ScoreWeights) Validate() error {
+	totalWeight := 0.0
+	for attribute, weight := range w {
+		if _, ok := scoreAttributes[attribute]; !ok {
+			return fmt.Errorf("score attribute %s is not supported, must be one of %v", attribute, ScoreAttributes())
+		}
+		if weight < 0 {
+			return fmt.Errorf("score weight for %s must not be negative", attribute)
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return fmt.Errorf("at least one score weight must be greater than 0")
+	}
+	return nil
+}
+
+// Score ranks the instance types by a composite score in descending order and populates the Score of each instance type.
+// Each attribute is normalized between 0 and 1 across the instance types, where 1 is the best value (i.e. the cheapest price or the most vCPUs),
+// and the score is the weighted average of the normalized attributes. Instance types without a value for an attribute (i.e. prices not fetched)
+// do not receive a contribution for it.
+func Score(instanceTypes []*instancetypes.Details, weights ScoreWeights) ([]*instancetypes.Details, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	for _, instanceTypeInfo := range instanceTypes {
+		instanceTypeInfo.Score = &instancetypes.Score{Contributions: map[string]float64{}}
+	}
+	for attribute, weight := range weights {
+		scoreAttribute := scoreAttributes[attribute]
+		values := make([]*float64, len(instanceTypes))
+		var min, max *float64
+		for i, instanceTypeInfo := range instanceTypes {
+			value := scoreAttribute.value(instanceTypeInfo)
+			values[i] = value
+			if value == nil {
+				continue
+			}
+			if min == nil || *value < *min {
+				min = value
+			}
+			if max == nil || *value > *max {
+				max = value
+			}
+		}
+		for i, instanceTypeInfo := range instanceTypes {
+			normalized := 0.0
+			if values[i] != nil {
+				normalized = 1
+				if *max > *min {
+					normalized = (*values[i] - *min) / (*max - *min)
+					if scoreAttribute.lowerIsBetter {
+						normalized = 1 - normalized
+					}
+				}
+			}
+			contribution := weight * normalized / totalWeight
+			instanceTypeInfo.Score.Contributions[attribute] = contribution
+			instanceTypeInfo.Score.Total += contribution
+		}
+	}
+	scoredInstanceTypes := append([]*instancetypes.Details{}, instanceTypes...)
+	sort.SliceStable(scoredInstanceTypes, func(i, j int) bool {
+		if scoredInstanceTypes[i].Score.Total != scoredInstanceTypes[j].Score.Total {
+			return scoredInstanceTypes[i].Score.Total > scoredInstanceTypes[j].Score.Total
+		}
+		return scoredInstanceTypes[i].InstanceType < scoredInstanceTypes[j].InstanceType
+	})
+	return scoredInstanceTypes, nil
+}
@@ -0,0 +1,105 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func getScoredInstanceTypes(t *testing.T) []*instancetypes.Details {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	ctx := context.Background()
+	instanceTypes, err := itf.FilterVerbose(ctx, selector.Filters{})
+	h.Ok(t, err)
+	h.Equals(t, 2, len(instanceTypes))
+	return instanceTypes
+}
+
+// Tests
+
+func TestScore(t *testing.T) {
+	instanceTypes := getScoredInstanceTypes(t)
+	scoredInstanceTypes, err := selector.Score(instanceTypes, selector.ScoreWeights{
+		selector.ScoreVCPUs:  1,
+		selector.ScoreMemory: 1,
+	})
+	h.Ok(t, err)
+	h.Equals(t, "p3.16xlarge", string(scoredInstanceTypes[0].InstanceType))
+	h.Equals(t, &instancetypes.Score{
+		Total:         1,
+		Contributions: map[string]float64{selector.ScoreVCPUs: 0.5, selector.ScoreMemory: 0.5},
+	}, scoredInstanceTypes[0].Score)
+	h.Equals(t, "t3.micro", string(scoredInstanceTypes[1].InstanceType))
+	h.Equals(t, 0.0, scoredInstanceTypes[1].Score.Total)
+}
+
+func TestScore_LowerPriceIsBetter(t *testing.T) {
+	instanceTypes := getScoredInstanceTypes(t)
+	for _, instanceTypeInfo := range instanceTypes {
+		if instanceTypeInfo.InstanceType == "t3.micro" {
+			instanceTypeInfo.OndemandPricePerHour = aws.Float64(0.0104)
+		} else {
+			instanceTypeInfo.OndemandPricePerHour = aws.Float64(24.48)
+		}
+	}
+	scoredInstanceTypes, err := selector.Score(instanceTypes, selector.ScoreWeights{
+		selector.ScoreOnDemandPrice: 3,
+		selector.ScoreVCPUs:         1,
+	})
+	h.Ok(t, err)
+	h.Equals(t, "t3.micro", string(scoredInstanceTypes[0].InstanceType))
+	h.Equals(t, 0.75, scoredInstanceTypes[0].Score.Total)
+	h.Equals(t, "p3.16xlarge", string(scoredInstanceTypes[1].InstanceType))
+	h.Equals(t, 0.25, scoredInstanceTypes[1].Score.Total)
+}
+
+func TestScore_MissingValues(t *testing.T) {
+	instanceTypes := getScoredInstanceTypes(t)
+	scoredInstanceTypes, err := selector.Score(instanceTypes, selector.ScoreWeights{
+		selector.ScoreSpotPrice: 1,
+		selector.ScoreNetwork:   1,
+	})
+	h.Ok(t, err)
+	h.Equals(t, "p3.16xlarge", string(scoredInstanceTypes[0].InstanceType))
+	h.Equals(t, map[string]float64{selector.ScoreSpotPrice: 0, selector.ScoreNetwork: 0.5}, scoredInstanceTypes[0].Score.Contributions)
+}
+
+func TestScore_InvalidWeights(t *testing.T) {
+	instanceTypes := getScoredInstanceTypes(t)
+	_, err := selector.Score(instanceTypes, selector.ScoreWeights{"price-per-unicorn": 1})
+	h.Nok(t, err)
+	_, err = selector.Score(instanceTypes, selector.ScoreWeights{selector.ScoreVCPUs: -1})
+	h.Nok(t, err)
+	_, err = selector.Score(instanceTypes, selector.ScoreWeights{selector.ScoreVCPUs: 0})
+	h.Nok(t, err)
+}
+
+func TestParseScoreWeights(t *testing.T) {
+	weights, err := selector.ParseScoreWeights([]string{"on-demand-price=2", "generation = 0.5"})
+	h.Ok(t, err)
+	h.Equals(t, selector.ScoreWeights{selector.ScoreOnDemandPrice: 2, selector.ScoreGeneration: 0.5}, weights)
+
+	_, err = selector.ParseScoreWeights([]string{"vcpus"})
+	h.Nok(t, err)
+	_, err = selector.ParseScoreWeights([]string{"vcpus=high"})
+	h.Nok(t, err)
+}
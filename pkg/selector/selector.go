@@ -31,7 +31,9 @@ import (
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"go.uber.org/multierr"
 
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector/outputs"
 )
@@ -70,6 +72,7 @@ const (
 	enaSupport                       = "enaSupport"
 	efaSupport                       = "efaSupport"
 	vcpusToMemoryRatio               = "vcpusToMemoryRatio"
+	vcpusToMemoryRatioRange          = "vcpusToMemoryRatioRange"
 	currentGeneration                = "currentGeneration"
 	networkInterfaces                = "networkInterfaces"
 	networkPerformance               = "networkPerformance"
@@ -91,12 +94,23 @@ const (
 	autoRecovery                     = "autoRecovery"
 	dedicatedHosts                   = "dedicatedHosts"
 	generation                       = "generation"
+	families                         = "families"
+	nameAttributes                   = "nameAttributes"
+	sizeRange                        = "sizeRange"
+	expression                       = "expression"
 
 	cpuArchitectureAMD64 = "amd64"
 
 	virtualizationTypePV = "pv"
 
-	pricePerHour = "pricePerHour"
+	pricePerHour         = "pricePerHour"
+	pricePerVCPU         = "pricePerVCPU"
+	pricePerGiBMemory    = "pricePerGiBMemory"
+	pricePerGPU          = "pricePerGPU"
+	pricePerGiBGPUMemory = "pricePerGiBGPUMemory"
+	pricePerGbpsNetwork  = "pricePerGbpsNetwork"
+	spotVolatility       = "spotVolatility"
+	spotDiscount         = "spotDiscount"
 )
 
 // New creates an instance of Selector provided an aws session.
@@ -121,12 +135,25 @@ func NewWithCache(ctx context.Context, cfg aws.Config, ttl time.Duration, cacheD
 		return nil, fmt.Errorf("unable to initialize instance type provider: %w", err)
 	}
 
+	regionalSelectors := NewRegionalSelectors(func(ctx context.Context, region string) (*Selector, error) {
+		regionalCfg := cfg.Copy()
+		regionalCfg.Region = region
+		regionalSelector, err := NewWithCache(ctx, regionalCfg, ttl, cacheDir)
+		if err != nil {
+			return nil, err
+		}
+		regionalSelector.RegionalSelectors = nil
+		return regionalSelector, nil
+	})
+
 	return &Selector{
 		EC2:                   ec2Client,
 		EC2Pricing:            pricingClient,
 		InstanceTypesProvider: instanceTypeProvider,
 		ServiceRegistry:       serviceRegistry,
+		FilterRegistry:        NewFilterRegistry(),
 		Logger:                log.New(io.Discard, "", 0),
+		RegionalSelectors:     regionalSelectors,
 	}, nil
 }
 
@@ -137,11 +164,18 @@ func (s *Selector) SetLogger(logger *log.Logger) {
 	s.Logger = logger
 	s.InstanceTypesProvider.SetLogger(logger)
 	s.EC2Pricing.SetLogger(logger)
+	if s.RegionalSelectors != nil {
+		s.RegionalSelectors.SetLogger(logger)
+	}
 }
 
 // Save persists the selector cache data to disk if caching is configured.
 func (s Selector) Save() error {
-	return multierr.Append(s.EC2Pricing.Save(), s.InstanceTypesProvider.Save())
+	errs := multierr.Append(s.EC2Pricing.Save(), s.InstanceTypesProvider.Save())
+	if s.RegionalSelectors != nil {
+		errs = multierr.Append(errs, s.RegionalSelectors.Save())
+	}
+	return errs
 }
 
 // Filter accepts a Filters struct which is used to select the available instance types
@@ -175,6 +209,27 @@ func (s Selector) FilterWithOutput(ctx context.Context, filters Filters, outputF
 	return output, numOfItemsTruncated, nil
 }
 
+// FilterAny accepts an AnyFilters struct which is used to select the available instance types
+// matching at least one of the Any filter groups and none of the Not filter groups and returns a simple list of instance type strings.
+func (s Selector) FilterAny(ctx context.Context, query AnyFilters) ([]string, error) {
+	instanceTypeInfoSlice, err := s.FilterAnyVerbose(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return outputs.SimpleInstanceTypeOutput(instanceTypeInfoSlice), nil
+}
+
+// FilterAnyVerbose accepts an AnyFilters struct which is used to select the available instance types
+// matching at least one of the Any filter groups and none of the Not filter groups and returns a list instanceTypeInfo.
+func (s Selector) FilterAnyVerbose(ctx context.Context, query AnyFilters) ([]*instancetypes.Details, error) {
+	instanceTypeInfoSlice, err := s.rawFilterAny(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	instanceTypeInfoSlice, _ = s.truncateResults(query.MaxResults, instanceTypeInfoSlice)
+	return instanceTypeInfoSlice, nil
+}
+
 func (s Selector) truncateResults(maxResults *int, instanceTypeInfoSlice []*instancetypes.Details) ([]*instancetypes.Details, int) {
 	if maxResults == nil {
 		return instanceTypeInfoSlice, 0
@@ -187,30 +242,108 @@ func (s Selector) truncateResults(maxResults *int, instanceTypeInfoSlice []*inst
 }
 
 // AggregateFilterTransform takes higher level filters which are used to affect multiple raw filters in an opinionated way.
+// Custom transforms registered with RegisterTransform run before or after the built-in transforms.
 func (s Selector) AggregateFilterTransform(ctx context.Context, filters Filters) (Filters, error) {
-	transforms := []FiltersTransform{
-		TransformFn(s.TransformBaseInstanceType),
-		TransformFn(s.TransformFlexible),
-		TransformFn(s.TransformForService),
+	stages, err := s.AggregateFilterTransformStages(ctx, filters)
+	return stages[len(stages)-1].Filters, err
+}
+
+// rawFilter accepts a Filters struct which is used to select the available instance types
+// matching the criteria within Filters and returns the detailed specs of matching instance types.
+func (s Selector) rawFilter(ctx context.Context, filters Filters) ([]*instancetypes.Details, error) {
+	if filters.InstanceTypeBases != nil && len(*filters.InstanceTypeBases) > 0 {
+		return s.rawFilterInstanceTypeBases(ctx, filters)
 	}
-	var err error
-	for _, transform := range transforms {
-		filters, err = transform.Transform(ctx, filters)
+	var instanceTypeInfoSlice []*instancetypes.Details
+	if filters.Regions != nil && len(*filters.Regions) > 0 {
+		regionalInstanceTypeInfoSlice, err := s.rawFilterRegions(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+		instanceTypeInfoSlice = regionalInstanceTypeInfoSlice
+	} else {
+		group, err := s.prepareFilterGroup(ctx, filters)
 		if err != nil {
-			return filters, err
+			return nil, err
 		}
+		instanceTypeInfoSlice, err = s.selectInstanceTypes(ctx, func(ctx context.Context, instanceTypeInfo instancetypes.Details) (*instancetypes.Details, error) {
+			return s.matchFilterGroup(ctx, group, instanceTypeInfo)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if isSimilaritySearch(filters) {
+		return s.rankBySimilarity(ctx, filters, instanceTypeInfoSlice)
 	}
-	return filters, nil
+	return instanceTypeInfoSlice, nil
 }
 
-// rawFilter accepts a Filters struct which is used to select the available instance types
-// matching the criteria within Filters and returns the detailed specs of matching instance types.
-func (s Selector) rawFilter(ctx context.Context, filters Filters) ([]*instancetypes.Details, error) {
+// rawFilterAny accepts an AnyFilters struct and returns the detailed specs of instance types
+// matching at least one of the Any groups and none of the Not groups.
+func (s Selector) rawFilterAny(ctx context.Context, query AnyFilters) ([]*instancetypes.Details, error) {
+	anyFilters := query.Any
+	if len(anyFilters) == 0 {
+		anyFilters = []Filters{{}}
+	}
+	includeGroups, err := s.prepareFilterGroups(ctx, anyFilters)
+	if err != nil {
+		return nil, err
+	}
+	excludeGroups, err := s.prepareFilterGroups(ctx, query.Not)
+	if err != nil {
+		return nil, err
+	}
+	return s.selectInstanceTypes(ctx, func(ctx context.Context, instanceTypeInfo instancetypes.Details) (*instancetypes.Details, error) {
+		for _, group := range excludeGroups {
+			it, err := s.matchFilterGroup(ctx, group, instanceTypeInfo)
+			if err != nil {
+				return nil, err
+			}
+			if it != nil {
+				return nil, nil
+			}
+		}
+		for _, group := range includeGroups {
+			it, err := s.matchFilterGroup(ctx, group, instanceTypeInfo)
+			if err != nil {
+				return nil, err
+			}
+			if it != nil {
+				return it, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+// filterGroup is a Filters struct with aggregate filters already transformed
+// along with the location data needed to evaluate it against an instance type.
+type filterGroup struct {
+	filters                   Filters
+	availabilityZones         []string
+	locationInstanceOfferings map[ec2types.InstanceType]string
+}
+
+// instanceTypeMatcher returns the populated instance type details if the instance type should be selected or nil if not.
+type instanceTypeMatcher func(ctx context.Context, instanceTypeInfo instancetypes.Details) (*instancetypes.Details, error)
+
+// prepareFilterGroup transforms the aggregate filters and retrieves the instance type offerings for the locations in the filters.
+func (s Selector) prepareFilterGroup(ctx context.Context, filters Filters) (*filterGroup, error) {
+	if filters.Regions != nil && len(*filters.Regions) > 0 {
+		return nil, fmt.Errorf("multiple regions are only supported by Filter, FilterVerbose, and FilterWithOutput")
+	}
+	if filters.InstanceTypeBases != nil && len(*filters.InstanceTypeBases) > 0 {
+		return nil, fmt.Errorf("multiple base instance types are only supported by Filter, FilterVerbose, FilterWithOutput, and FilterInstanceTypeBases")
+	}
 	filters, err := s.AggregateFilterTransform(ctx, filters)
 	if err != nil {
 		return nil, err
 	}
-	var locations, availabilityZones []string
+	if err := s.FilterRegistry.validate(filters); err != nil {
+		return nil, err
+	}
+	var availabilityZones []string
 
 	if filters.CPUArchitecture != nil && *filters.CPUArchitecture == cpuArchitectureAMD64 {
 		*filters.CPUArchitecture = ec2types.ArchitectureTypeX8664
@@ -220,15 +353,50 @@ func (s Selector) rawFilter(ctx context.Context, filters Filters) ([]*instancety
 	}
 	if filters.AvailabilityZones != nil {
 		availabilityZones = *filters.AvailabilityZones
-		locations = *filters.AvailabilityZones
-	} else if filters.Region != nil {
-		locations = []string{*filters.Region}
 	}
-	locationInstanceOfferings, err := s.RetrieveInstanceTypesSupportedInLocations(ctx, locations)
+	locationInstanceOfferings, err := s.RetrieveInstanceTypesSupportedInLocations(ctx, getLocations(filters))
 	if err != nil {
 		return nil, err
 	}
+	return &filterGroup{
+		filters:                   filters,
+		availabilityZones:         availabilityZones,
+		locationInstanceOfferings: locationInstanceOfferings,
+	}, nil
+}
+
+// getLocations returns the availability zones or region used to retrieve the instance type offerings for the filters.
+func getLocations(filters Filters) []string {
+	if filters.AvailabilityZones != nil {
+		return *filters.AvailabilityZones
+	}
+	if filters.Region != nil {
+		return []string{*filters.Region}
+	}
+	return nil
+}
 
+// prepareFilterGroups prepares each Filters struct with prepareFilterGroup.
+func (s Selector) prepareFilterGroups(ctx context.Context, filtersList []Filters) ([]*filterGroup, error) {
+	groups := []*filterGroup{}
+	for _, filters := range filtersList {
+		group, err := s.prepareFilterGroup(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// matchFilterGroup evaluates a single filter group against an instance type.
+func (s Selector) matchFilterGroup(ctx context.Context, group *filterGroup, instanceTypeInfo instancetypes.Details) (*instancetypes.Details, error) {
+	return s.prepareFilter(ctx, group.filters, instanceTypeInfo, group.availabilityZones, group.locationInstanceOfferings)
+}
+
+// selectInstanceTypes concurrently runs the matcher against every available instance type
+// and returns the selected instance types sorted by name.
+func (s Selector) selectInstanceTypes(ctx context.Context, matcher instanceTypeMatcher) ([]*instancetypes.Details, error) {
 	instanceTypeDetails, err := s.InstanceTypesProvider.Get(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -240,7 +408,7 @@ func (s Selector) rawFilter(ctx context.Context, filters Filters) ([]*instancety
 		wg.Add(1)
 		go func(instanceTypeInfo instancetypes.Details) {
 			defer wg.Done()
-			it, err := s.prepareFilter(ctx, filters, instanceTypeInfo, availabilityZones, locationInstanceOfferings)
+			it, err := matcher(ctx, instanceTypeInfo)
 			if err != nil {
 				s.Logger.Printf("Unable to prepare filter for %s, %v", instanceTypeInfo.InstanceType, err)
 			}
@@ -260,19 +428,52 @@ func (s Selector) rawFilter(ctx context.Context, filters Filters) ([]*instancety
 }
 
 func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTypeInfo instancetypes.Details, availabilityZones []string, locationInstanceOfferings map[ec2types.InstanceType]string) (*instancetypes.Details, error) {
+	instanceTypeName := instanceTypeInfo.InstanceType
+	filterToInstanceSpecMappingPairs := s.getFilterToInstanceSpecMapping(ctx, filters, &instanceTypeInfo, availabilityZones)
+
+	if isInDenyList(filters.DenyList, instanceTypeName) || !isInAllowList(filters.AllowList, instanceTypeName) {
+		return nil, nil
+	}
+
+	if !isSupportedInLocation(locationInstanceOfferings, instanceTypeName) {
+		return nil, nil
+	}
+
+	var isInstanceSupported bool
+	isInstanceSupported, err := s.executeFilters(ctx, filterToInstanceSpecMappingPairs, instanceTypeName)
+	if err != nil {
+		return nil, err
+	}
+	if !isInstanceSupported {
+		return nil, nil
+	}
+	return &instanceTypeInfo, nil
+}
+
+// getFilterToInstanceSpecMapping populates the prices of the instance type and returns
+// a map of filter name [key] to the filter pair [value] for each of the filters.
+func (s Selector) getFilterToInstanceSpecMapping(ctx context.Context, filters Filters, instanceTypeInfo *instancetypes.Details, availabilityZones []string) map[string]filterPair {
 	instanceTypeName := instanceTypeInfo.InstanceType
 	isFpga := instanceTypeInfo.FpgaInfo != nil
 	var instanceTypeHourlyPriceForFilter float64 // Price used to filter based on usage class
 	var instanceTypeHourlyPriceOnDemand, instanceTypeHourlyPriceSpot *float64
+	pricingOptions := filters.PricingOptions()
+	spotDays := filters.SpotDaysBack()
 	// If prices are fetched, populate the fields irrespective of the price filters
-	if s.EC2Pricing.OnDemandCacheCount() > 0 {
-		price, err := s.EC2Pricing.GetOnDemandInstanceTypeCost(ctx, instanceTypeName)
+	if s.EC2Pricing.OnDemandCacheCount(pricingOptions) > 0 {
+		price, err := s.EC2Pricing.GetOnDemandInstanceTypeCost(ctx, instanceTypeName, pricingOptions)
 		if err != nil {
 			s.Logger.Printf("Could not retrieve instantaneous hourly on-demand price for instance type %s - %s\n", instanceTypeName, err)
 		} else {
 			instanceTypeHourlyPriceOnDemand = &price
 			instanceTypeInfo.OndemandPricePerHour = instanceTypeHourlyPriceOnDemand
 		}
+		reservedPrices, err := s.EC2Pricing.GetReservedInstanceTypeCosts(ctx, instanceTypeName, pricingOptions)
+		if err != nil {
+			s.Logger.Printf("Could not retrieve reserved instance prices for instance type %s - %s\n", instanceTypeName, err)
+		} else {
+			instanceTypeInfo.ReservedPrices = reservedPrices
+		}
 	}
 
 	isSpotUsageClass := false
@@ -282,15 +483,32 @@ func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTy
 		}
 	}
 
-	if s.EC2Pricing.SpotCacheCount() > 0 && isSpotUsageClass {
-		price, err := s.EC2Pricing.GetSpotInstanceTypeNDayAvgCost(ctx, instanceTypeName, availabilityZones, 30)
+	if s.EC2Pricing.SpotCacheCount(pricingOptions, spotDays) > 0 && isSpotUsageClass {
+		price, err := s.EC2Pricing.GetSpotInstanceTypeNDayAvgCost(ctx, instanceTypeName, pricingOptions, availabilityZones, spotDays)
 		if err != nil {
-			s.Logger.Printf("Could not retrieve 30 day avg hourly spot price for instance type %s\n", instanceTypeName)
+			s.Logger.Printf("Could not retrieve %d day avg hourly spot price for instance type %s\n", spotDays, instanceTypeName)
 		} else {
 			instanceTypeHourlyPriceSpot = &price
 			instanceTypeInfo.SpotPrice = instanceTypeHourlyPriceSpot
 		}
+		spotPriceStats, err := s.EC2Pricing.GetSpotInstanceTypeNDayStats(ctx, instanceTypeName, pricingOptions, availabilityZones, spotDays)
+		if err != nil {
+			s.Logger.Printf("Could not retrieve %d day spot price statistics for instance type %s - %s\n", spotDays, instanceTypeName, err)
+		} else {
+			instanceTypeInfo.SpotPriceStats = spotPriceStats
+			instanceTypeInfo.SpotVolatility = getSpotVolatility(spotPriceStats)
+			instanceTypeInfo.SpotPriceByAvailabilityZone, instanceTypeInfo.CheapestSpotAvailabilityZone = getZonalSpotPrices(spotPriceStats)
+		}
 	}
+	// The spot AZ strategy selects the spot price used for filtering from the spot prices of the availability zones
+	if spotPrice := getSpotAZStrategyPrice(filters.SpotAZStrategy, instanceTypeInfo.SpotPriceByAvailabilityZone); spotPrice != nil {
+		instanceTypeHourlyPriceSpot = spotPrice
+	}
+	instanceTypeInfo.SpotDiscount = getSpotDiscount(instanceTypeHourlyPriceOnDemand, instanceTypeHourlyPriceSpot)
+	instanceTypeInfo.MemoryGiBPerVCPU = calculateMemoryGiBPerVCPU(instanceTypeInfo.VCpuInfo.DefaultVCpus, instanceTypeInfo.MemoryInfo.SizeInMiB)
+	setPricePerformance(instanceTypeInfo)
+	instanceTypeInfo.PricingModelPricePerHour = getPricingModelPricePerHour(filters.PricingModel, instanceTypeInfo)
+	pricePerHourForFilter := &instanceTypeHourlyPriceForFilter
 	if filters.PricePerHour != nil {
 		// If price filter is present, prices should be already fetched
 		// If prices are not fetched, filter should fail and the corresponding error is already printed
@@ -300,6 +518,29 @@ func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTy
 			instanceTypeHourlyPriceForFilter = *instanceTypeHourlyPriceOnDemand
 		}
 	}
+	// Price-performance filters follow the price filter and use spot prices for the spot usage class
+	isSpotPriceFilter := filters.UsageClass != nil && *filters.UsageClass == ec2types.UsageClassTypeSpot && instanceTypeHourlyPriceSpot != nil
+	pricePerVCPUForFilter, pricePerGiBMemoryForFilter := instanceTypeInfo.OndemandPricePerVCPU, instanceTypeInfo.OndemandPricePerGiBMemory
+	pricePerGPUForFilter, pricePerGiBGPUMemoryForFilter := instanceTypeInfo.OndemandPricePerGPU, instanceTypeInfo.OndemandPricePerGiBGPUMemory
+	pricePerGbpsNetworkForFilter := instanceTypeInfo.OndemandPricePerGbpsNetwork
+	if isSpotPriceFilter {
+		spotPricePerformance := getPricePerformance(instanceTypeInfo, instanceTypeHourlyPriceSpot)
+		pricePerVCPUForFilter, pricePerGiBMemoryForFilter = spotPricePerformance.perVCPU, spotPricePerformance.perGiBMemory
+		pricePerGPUForFilter, pricePerGiBGPUMemoryForFilter = spotPricePerformance.perGPU, spotPricePerformance.perGiBGPUMemory
+		pricePerGbpsNetworkForFilter = spotPricePerformance.perGbpsNetwork
+	}
+	// The pricing model takes precedence over the usage class, and instance types without a price for it are filtered out
+	if filters.PricingModel != nil {
+		pricingModelPricePerHour := instanceTypeInfo.PricingModelPricePerHour
+		if *filters.PricingModel == ec2pricing.PricingModelSpot {
+			pricingModelPricePerHour = instanceTypeHourlyPriceSpot
+		}
+		pricePerHourForFilter = pricingModelPricePerHour
+		pricingModelPricePerformance := getPricePerformance(instanceTypeInfo, pricingModelPricePerHour)
+		pricePerVCPUForFilter, pricePerGiBMemoryForFilter = pricingModelPricePerformance.perVCPU, pricingModelPricePerformance.perGiBMemory
+		pricePerGPUForFilter, pricePerGiBGPUMemoryForFilter = pricingModelPricePerformance.perGPU, pricingModelPricePerformance.perGiBGPUMemory
+		pricePerGbpsNetworkForFilter = pricingModelPricePerformance.perGbpsNetwork
+	}
 	eneaSupport := string(instanceTypeInfo.NetworkInfo.EnaSupport)
 	ebsOptimizedSupport := string(instanceTypeInfo.EbsInfo.EbsOptimizedSupport)
 
@@ -309,6 +550,8 @@ func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTy
 		filterInstanceTypes = nil
 	}
 
+	parsedName, nameErr := instancename.Parse(string(instanceTypeInfo.InstanceType))
+
 	var cpuManufacturerFilter *string
 	if filters.CPUManufacturer != nil {
 		cpuManufacturerFilter = aws.String(string(*filters.CPUManufacturer))
@@ -335,6 +578,7 @@ func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTy
 		enaSupport:                       {filters.EnaSupport, supportSyntaxToBool(&eneaSupport)},
 		efaSupport:                       {filters.EfaSupport, instanceTypeInfo.NetworkInfo.EfaSupported},
 		vcpusToMemoryRatio:               {filters.VCpusToMemoryRatio, calculateVCpusToMemoryRatio(instanceTypeInfo.VCpuInfo.DefaultVCpus, instanceTypeInfo.MemoryInfo.SizeInMiB)},
+		vcpusToMemoryRatioRange:          {filters.VCpusToMemoryRatioRange, instanceTypeInfo.MemoryGiBPerVCPU},
 		currentGeneration:                {filters.CurrentGeneration, instanceTypeInfo.CurrentGeneration},
 		networkInterfaces:                {filters.NetworkInterfaces, instanceTypeInfo.NetworkInfo.MaximumNetworkInterfaces},
 		networkPerformance:               {filters.NetworkPerformance, getNetworkPerformance(instanceTypeInfo.NetworkInfo.NetworkPerformance)},
@@ -342,7 +586,14 @@ func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTy
 		ipv6:                             {filters.IPv6, instanceTypeInfo.NetworkInfo.Ipv6Supported},
 		instanceTypes:                    {filterInstanceTypes, aws.String(string(instanceTypeInfo.InstanceType))},
 		virtualizationType:               {filters.VirtualizationType, instanceTypeInfo.SupportedVirtualizationTypes},
-		pricePerHour:                     {filters.PricePerHour, &instanceTypeHourlyPriceForFilter},
+		pricePerHour:                     {filters.PricePerHour, pricePerHourForFilter},
+		pricePerVCPU:                     {filters.PricePerVCPU, pricePerVCPUForFilter},
+		pricePerGiBMemory:                {filters.PricePerGiBMemory, pricePerGiBMemoryForFilter},
+		pricePerGPU:                      {filters.PricePerGPU, pricePerGPUForFilter},
+		pricePerGiBGPUMemory:             {filters.PricePerGiBGPUMemory, pricePerGiBGPUMemoryForFilter},
+		pricePerGbpsNetwork:              {filters.PricePerGbpsNetwork, pricePerGbpsNetworkForFilter},
+		spotVolatility:                   {filters.SpotVolatility, instanceTypeInfo.SpotVolatility},
+		spotDiscount:                     {filters.SpotDiscount, instanceTypeInfo.SpotDiscount},
 		instanceStorageRange:             {filters.InstanceStorageRange, getInstanceStorage(instanceTypeInfo.InstanceStorageInfo)},
 		diskType:                         {filters.DiskType, getDiskType(instanceTypeInfo.InstanceStorageInfo)},
 		nvme:                             {filters.NVME, getNVMESupport(instanceTypeInfo.InstanceStorageInfo, instanceTypeInfo.EbsInfo)},
@@ -359,25 +610,13 @@ func (s Selector) prepareFilter(ctx context.Context, filters Filters, instanceTy
 		inferenceAcceleratorModel:        {filters.InferenceAcceleratorModel, getInferenceAcceleratorModels(instanceTypeInfo.InferenceAcceleratorInfo)},
 		dedicatedHosts:                   {filters.DedicatedHosts, instanceTypeInfo.DedicatedHostsSupported},
 		generation:                       {filters.Generation, getInstanceTypeGeneration(string(instanceTypeInfo.InstanceType))},
+		families:                         {filters.Families, getInstanceTypeFamily(filters.Families, parsedName, nameErr)},
+		nameAttributes:                   {filters.NameAttributes, getInstanceTypeNameAttributes(parsedName, nameErr)},
+		sizeRange:                        {filters.SizeRange, getInstanceTypeSize(parsedName, nameErr)},
+		expression:                       {filters.Expression, instanceTypeInfo},
 	}
-
-	if isInDenyList(filters.DenyList, instanceTypeName) || !isInAllowList(filters.AllowList, instanceTypeName) {
-		return nil, nil
-	}
-
-	if !isSupportedInLocation(locationInstanceOfferings, instanceTypeName) {
-		return nil, nil
-	}
-
-	var isInstanceSupported bool
-	isInstanceSupported, err := s.executeFilters(ctx, filterToInstanceSpecMappingPairs, instanceTypeName)
-	if err != nil {
-		return nil, err
-	}
-	if !isInstanceSupported {
-		return nil, nil
-	}
-	return &instanceTypeInfo, nil
+	s.FilterRegistry.addCustomFilterPairs(filters, instanceTypeInfo, filterToInstanceSpecMappingPairs)
+	return filterToInstanceSpecMappingPairs
 }
 
 // sortInstanceTypeInfo will sort based on instance type info alpha-numerically.
@@ -478,7 +717,7 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 		default:
 			return false, errInvalidInstanceSpec
 		}
-	case *IntRangeFilter:
+	case *Range[int]:
 		switch iSpec := instanceSpec.(type) {
 		case *int64:
 			if !isSupportedWithRangeInt64(iSpec, filter) {
@@ -499,7 +738,7 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 		default:
 			return false, errInvalidInstanceSpec
 		}
-	case *Int32RangeFilter:
+	case *Range[int32]:
 		switch iSpec := instanceSpec.(type) {
 		case *int32:
 			if !isSupportedWithRangeInt32(iSpec, filter) {
@@ -508,7 +747,7 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 		default:
 			return false, errInvalidInstanceSpec
 		}
-	case *Float64RangeFilter:
+	case *Range[float64]:
 		switch iSpec := instanceSpec.(type) {
 		case *float64:
 			if !isSupportedWithRangeFloat64(iSpec, filter) {
@@ -517,11 +756,8 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 		default:
 			return false, errInvalidInstanceSpec
 		}
-	case *ByteQuantityRangeFilter:
-		mibRange := Uint64RangeFilter{
-			LowerBound: filter.LowerBound.Quantity,
-			UpperBound: filter.UpperBound.Quantity,
-		}
+	case *Range[bytequantity.ByteQuantity]:
+		mibRange := mapRange(filter, func(bq bytequantity.ByteQuantity) uint64 { return bq.Quantity })
 		switch iSpec := instanceSpec.(type) {
 		case *int:
 			var iSpec64 *int64
@@ -529,19 +765,16 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 				iSpecVal := int64(*iSpec)
 				iSpec64 = &iSpecVal
 			}
-			if !isSupportedWithRangeUint64(iSpec64, &mibRange) {
+			if !isSupportedWithRangeUint64(iSpec64, mibRange) {
 				return false, nil
 			}
 		case *int64:
-			if !isSupportedWithRangeUint64(iSpec, &mibRange) {
+			if !isSupportedWithRangeUint64(iSpec, mibRange) {
 				return false, nil
 			}
 		case *float64:
-			floatMiBRange := Float64RangeFilter{
-				LowerBound: float64(filter.LowerBound.Quantity),
-				UpperBound: float64(filter.UpperBound.Quantity),
-			}
-			if !isSupportedWithRangeFloat64(iSpec, &floatMiBRange) {
+			floatMiBRange := mapRange(filter, func(bq bytequantity.ByteQuantity) float64 { return float64(bq.Quantity) })
+			if !isSupportedWithRangeFloat64(iSpec, floatMiBRange) {
 				return false, nil
 			}
 		default:
@@ -612,6 +845,10 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 		}
 	case *[]string:
 		switch iSpec := instanceSpec.(type) {
+		case []*string:
+			if !isSupportedFromAllStrings(iSpec, filter) {
+				return false, nil
+			}
 		case *string:
 			filterOfPtrs := []*string{}
 			for _, f := range *filter {
@@ -626,6 +863,20 @@ func exec(instanceType ec2types.InstanceType, filterName string, filter filterPa
 		default:
 			return false, errInvalidInstanceSpec
 		}
+	case *Expression:
+		switch iSpec := instanceSpec.(type) {
+		case *instancetypes.Details:
+			return filter.Evaluate(iSpec)
+		default:
+			return false, errInvalidInstanceSpec
+		}
+	case *customFilterValue:
+		switch iSpec := instanceSpec.(type) {
+		case *instancetypes.Details:
+			return filter.filter.Matches(iSpec, filter.value)
+		default:
+			return false, errInvalidInstanceSpec
+		}
 	default:
 		return false, fmt.Errorf("no filter handler found for %s", filterDetailsMsg)
 	}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/awsapi"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
 	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
@@ -153,7 +154,7 @@ func TestNew(t *testing.T) {
 func TestFilterVerbose(t *testing.T) {
 	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
 	filters := selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 2, UpperBound: 2},
+		VCpusRange: selector.NewRange[int32](2, 2),
 	}
 	ctx := context.Background()
 	results, err := itf.FilterVerbose(ctx, filters)
@@ -165,7 +166,7 @@ func TestFilterVerbose(t *testing.T) {
 func TestFilterVerbose_NoResults(t *testing.T) {
 	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
 	filters := selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 4, UpperBound: 4},
+		VCpusRange: selector.NewRange[int32](4, 4),
 	}
 	ctx := context.Background()
 	results, err := itf.FilterVerbose(ctx, filters)
@@ -177,7 +178,7 @@ func TestFilterVerbose_Failure(t *testing.T) {
 	ctx := context.Background()
 	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("error")})
 	filters := selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 4, UpperBound: 4},
+		VCpusRange: selector.NewRange[int32](4, 4),
 	}
 	results, err := itf.FilterVerbose(ctx, filters)
 	h.Assert(t, results == nil, "Results should be nil")
@@ -192,7 +193,7 @@ func TestFilterVerbose_AZFilteredIn(t *testing.T) {
 	}
 	itf := getSelector(ec2Mock)
 	filters := selector.Filters{
-		VCpusRange:        &selector.Int32RangeFilter{LowerBound: 2, UpperBound: 2},
+		VCpusRange:        selector.NewRange[int32](2, 2),
 		AvailabilityZones: &[]string{"us-east-2a"},
 	}
 	ctx := context.Background()
@@ -221,7 +222,7 @@ func TestFilterVerbose_AZFilteredOut(t *testing.T) {
 func TestFilterVerboseAZ_FilteredErr(t *testing.T) {
 	itf := getSelector(mockedEC2{})
 	filters := selector.Filters{
-		VCpusRange:        &selector.Int32RangeFilter{LowerBound: 2, UpperBound: 2},
+		VCpusRange:        selector.NewRange[int32](2, 2),
 		AvailabilityZones: &[]string{"blah"},
 	}
 	ctx := context.Background()
@@ -234,11 +235,8 @@ func TestFilterVerbose_Gpus(t *testing.T) {
 	gpuMemory, err := bytequantity.ParseToByteQuantity("128g")
 	h.Ok(t, err)
 	filters := selector.Filters{
-		GpusRange: &selector.Int32RangeFilter{LowerBound: 8, UpperBound: 8},
-		GpuMemoryRange: &selector.ByteQuantityRangeFilter{
-			LowerBound: gpuMemory,
-			UpperBound: gpuMemory,
-		},
+		GpusRange:      selector.NewRange[int32](8, 8),
+		GpuMemoryRange: selector.NewRange(gpuMemory, gpuMemory),
 	}
 	ctx := context.Background()
 	results, err := itf.FilterVerbose(ctx, filters)
@@ -250,7 +248,7 @@ func TestFilterVerbose_Gpus(t *testing.T) {
 func TestFilter(t *testing.T) {
 	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
 	filters := selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 2, UpperBound: 2},
+		VCpusRange: selector.NewRange[int32](2, 2),
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
@@ -264,7 +262,7 @@ func TestFilter_MoreFilters(t *testing.T) {
 	X8664Architecture := ec2types.ArchitectureTypeX8664
 	NitroInstanceType := ec2types.InstanceTypeHypervisorNitro
 	filters := selector.Filters{
-		VCpusRange:      &selector.Int32RangeFilter{LowerBound: 2, UpperBound: 2},
+		VCpusRange:      selector.NewRange[int32](2, 2),
 		BareMetal:       aws.Bool(false),
 		CPUArchitecture: &X8664Architecture,
 		Hypervisor:      &NitroInstanceType,
@@ -280,7 +278,7 @@ func TestFilter_MoreFilters(t *testing.T) {
 func TestFilter_TruncateToMaxResults(t *testing.T) {
 	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
 	filters := selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 0, UpperBound: 100},
+		VCpusRange: selector.NewRange[int32](0, 100),
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
@@ -288,7 +286,7 @@ func TestFilter_TruncateToMaxResults(t *testing.T) {
 	h.Assert(t, len(results) > 1, "Should return > 1 instance types since max results is not set")
 
 	filters = selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 0, UpperBound: 100},
+		VCpusRange: selector.NewRange[int32](0, 100),
 		MaxResults: aws.Int(1),
 	}
 	results, err = itf.Filter(ctx, filters)
@@ -296,7 +294,7 @@ func TestFilter_TruncateToMaxResults(t *testing.T) {
 	h.Assert(t, len(results) == 1, "Should return 1 instance types since max results is set")
 
 	filters = selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 0, UpperBound: 100},
+		VCpusRange: selector.NewRange[int32](0, 100),
 		MaxResults: aws.Int(30),
 	}
 	results, err = itf.Filter(ctx, filters)
@@ -307,7 +305,7 @@ func TestFilter_TruncateToMaxResults(t *testing.T) {
 func TestFilter_Failure(t *testing.T) {
 	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("error")})
 	filters := selector.Filters{
-		VCpusRange: &selector.Int32RangeFilter{LowerBound: 4, UpperBound: 4},
+		VCpusRange: selector.NewRange[int32](4, 4),
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
@@ -315,6 +313,92 @@ func TestFilter_Failure(t *testing.T) {
 	h.Assert(t, err != nil, "An error should be returned")
 }
 
+func TestFilterAny(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	query := selector.AnyFilters{
+		Any: []selector.Filters{
+			{VCpusRange: selector.NewRange[int32](2, 2)},
+			{GpusRange: selector.NewRange[int32](8, 8)},
+		},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterAny(ctx, query)
+	h.Ok(t, err)
+	h.Equals(t, []string{"p3.16xlarge", "t3.micro"}, results)
+}
+
+func TestFilterAny_NoMatchingGroups(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	query := selector.AnyFilters{
+		Any: []selector.Filters{
+			{VCpusRange: selector.NewRange[int32](4, 4)},
+			{GpusRange: selector.NewRange[int32](1, 1)},
+		},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterAny(ctx, query)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 0, "Should return 0 instance types but actually returned "+strconv.Itoa(len(results)))
+}
+
+func TestFilterAny_Not(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	query := selector.AnyFilters{
+		Not: []selector.Filters{
+			{VCpusRange: selector.NewRange[int32](2, 2)},
+		},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterAnyVerbose(ctx, query)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 1, "Should only return 1 instance type without 2 vcpus but actually returned "+strconv.Itoa(len(results)))
+	h.Assert(t, results[0].InstanceType == "p3.16xlarge", "Should return p3.16xlarge, got %s instead", results[0].InstanceType)
+}
+
+func TestFilterAny_AnyAndNot(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	query := selector.AnyFilters{
+		Any: []selector.Filters{
+			{VCpusRange: selector.NewRange[int32](2, 2)},
+			{GpusRange: selector.NewRange[int32](8, 8)},
+		},
+		Not: []selector.Filters{
+			{GpusRange: selector.NewRange[int32](1, 16)},
+		},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterAny(ctx, query)
+	h.Ok(t, err)
+	h.Equals(t, []string{"t3.micro"}, results)
+}
+
+func TestFilterAny_TruncateToMaxResults(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	query := selector.AnyFilters{
+		Any: []selector.Filters{
+			{VCpusRange: selector.NewRange[int32](0, 100), MaxResults: aws.Int(30)},
+		},
+		MaxResults: aws.Int(1),
+	}
+	ctx := context.Background()
+	results, err := itf.FilterAny(ctx, query)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 1, "Should return 1 instance types since max results is set")
+}
+
+func TestFilterAny_Failure(t *testing.T) {
+	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("error")})
+	query := selector.AnyFilters{
+		Any: []selector.Filters{
+			{VCpusRange: selector.NewRange[int32](4, 4)},
+		},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterAny(ctx, query)
+	h.Assert(t, results == nil, "Results should be nil")
+	h.Assert(t, err != nil, "An error should be returned")
+}
+
 func TestRetrieveInstanceTypesSupportedInAZ_WithZoneName(t *testing.T) {
 	ec2Mock := setupMock(t, describeInstanceTypeOfferings, "us-east-2a.json")
 	ec2Mock.DescribeAvailabilityZonesResp = setupMock(t, describeAvailabilityZones, "us-east-2.json").DescribeAvailabilityZonesResp
@@ -530,6 +614,52 @@ func TestFilter_X8664_AMD64(t *testing.T) {
 	h.Assert(t, results[0] == "t3.micro", "Should return t3.micro, got %s instead", results[0])
 }
 
+func TestFilter_VCpusToMemoryRatioRange(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	ctx := context.Background()
+	// t3.micro has 2 vcpus and 1 GiB of memory, which is a 1:0.5 ratio
+	results, err := itf.Filter(ctx, selector.Filters{VCpusToMemoryRatioRange: selector.NewRange(0.5, 1.0)})
+	h.Ok(t, err)
+	h.Equals(t, []string{"t3.micro"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{VCpusToMemoryRatioRange: selector.RangeGreaterThan(0.5)})
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+
+	instanceTypes, err := itf.FilterVerbose(ctx, selector.Filters{})
+	h.Ok(t, err)
+	h.Equals(t, 0.5, *instanceTypes[0].MemoryGiBPerVCPU)
+}
+
+func TestFilter_InstanceTypeName(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	ctx := context.Background()
+
+	results, err := itf.Filter(ctx, selector.Filters{Families: &[]string{"a", "c4"}})
+	h.Ok(t, err)
+	h.Equals(t, []string{"a1.2xlarge", "a1.4xlarge", "a1.large", "a1.medium", "a1.metal", "a1.xlarge", "c4.2xlarge", "c4.4xlarge", "c4.8xlarge", "c4.large", "c4.xlarge"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{Families: &[]string{"c"}, SizeRange: selector.NewRange(1.0, 4.0)})
+	h.Ok(t, err)
+	h.Equals(t, []string{"c1.xlarge", "c3.2xlarge", "c3.4xlarge", "c3.xlarge", "c4.2xlarge", "c4.4xlarge", "c4.xlarge", "c5.2xlarge", "c5.4xlarge"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{Families: &[]string{"a1"}, SizeRange: selector.RangeAtLeast(4.0)})
+	h.Ok(t, err)
+	h.Equals(t, []string{"a1.4xlarge", "a1.metal"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{Families: &[]string{"A", "C4"}})
+	h.Ok(t, err)
+	h.Equals(t, []string{"a1.2xlarge", "a1.4xlarge", "a1.large", "a1.medium", "a1.metal", "a1.xlarge", "c4.2xlarge", "c4.4xlarge", "c4.8xlarge", "c4.large", "c4.xlarge"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{Families: &[]string{"C"}, SizeRange: selector.NewRange(1.0, 4.0)})
+	h.Ok(t, err)
+	h.Equals(t, []string{"c1.xlarge", "c3.2xlarge", "c3.4xlarge", "c3.xlarge", "c4.2xlarge", "c4.4xlarge", "c4.xlarge", "c5.2xlarge", "c5.4xlarge"}, results)
+
+	results, err = itf.Filter(ctx, selector.Filters{NameAttributes: &[]string{"d"}})
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+}
+
 func TestFilter_VirtType_PV(t *testing.T) {
 	itf := getSelector(setupMock(t, describeInstanceTypes, "pv_instances.json"))
 	pvType := selector.VirtualizationTypePv
@@ -555,33 +685,50 @@ type ec2PricingMock struct {
 	GetOndemandInstanceTypeCostErr     error
 	GetSpotInstanceTypeNDayAvgCostResp float64
 	GetSpotInstanceTypeNDayAvgCostErr  error
+	GetSpotInstanceTypeNDayStatsResp   map[string]ec2pricing.SpotPriceStats
+	GetSpotInstanceTypeNDayStatsErr    error
+	GetReservedInstanceTypeCostsResp   ec2pricing.ReservedPrices
+	GetReservedInstanceTypeCostsErr    error
 	RefreshOnDemandCacheErr            error
 	RefreshSpotCacheErr                error
 	onDemandCacheCount                 int
 	spotCacheCount                     int
+	pricingOptions                     ec2pricing.PricingOptions
+	spotDays                           int
 }
 
-func (p *ec2PricingMock) GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType) (float64, error) {
+func (p *ec2PricingMock) GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType, options ec2pricing.PricingOptions) (float64, error) {
+	p.pricingOptions = options
 	return p.GetOndemandInstanceTypeCostResp, p.GetOndemandInstanceTypeCostErr
 }
 
-func (p *ec2PricingMock) GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanceType ec2types.InstanceType, availabilityZones []string, days int) (float64, error) {
+func (p *ec2PricingMock) GetSpotInstanceTypeNDayAvgCost(ctx context.Context, instanceType ec2types.InstanceType, options ec2pricing.PricingOptions, availabilityZones []string, days int) (float64, error) {
+	p.pricingOptions = options
+	p.spotDays = days
 	return p.GetSpotInstanceTypeNDayAvgCostResp, p.GetSpotInstanceTypeNDayAvgCostErr
 }
 
-func (p *ec2PricingMock) RefreshOnDemandCache(ctx context.Context) error {
+func (p *ec2PricingMock) GetSpotInstanceTypeNDayStats(ctx context.Context, instanceType ec2types.InstanceType, options ec2pricing.PricingOptions, availabilityZones []string, days int) (map[string]ec2pricing.SpotPriceStats, error) {
+	return p.GetSpotInstanceTypeNDayStatsResp, p.GetSpotInstanceTypeNDayStatsErr
+}
+
+func (p *ec2PricingMock) GetReservedInstanceTypeCosts(ctx context.Context, instanceType ec2types.InstanceType, options ec2pricing.PricingOptions) (ec2pricing.ReservedPrices, error) {
+	return p.GetReservedInstanceTypeCostsResp, p.GetReservedInstanceTypeCostsErr
+}
+
+func (p *ec2PricingMock) RefreshOnDemandCache(ctx context.Context, options ec2pricing.PricingOptions) error {
 	return p.RefreshOnDemandCacheErr
 }
 
-func (p *ec2PricingMock) RefreshSpotCache(ctx context.Context, days int) error {
+func (p *ec2PricingMock) RefreshSpotCache(ctx context.Context, options ec2pricing.PricingOptions, days int) error {
 	return p.RefreshSpotCacheErr
 }
 
-func (p *ec2PricingMock) OnDemandCacheCount() int {
+func (p *ec2PricingMock) OnDemandCacheCount(options ec2pricing.PricingOptions) int {
 	return p.onDemandCacheCount
 }
 
-func (p *ec2PricingMock) SpotCacheCount() int {
+func (p *ec2PricingMock) SpotCacheCount(options ec2pricing.PricingOptions, days int) int {
 	return p.spotCacheCount
 }
 
@@ -597,10 +744,7 @@ func TestFilter_PricePerHour(t *testing.T) {
 		onDemandCacheCount:              1,
 	}
 	filters := selector.Filters{
-		PricePerHour: &selector.Float64RangeFilter{
-			LowerBound: 0.0104,
-			UpperBound: 0.0104,
-		},
+		PricePerHour: selector.NewRange[float64](0.0104, 0.0104),
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
@@ -615,10 +759,7 @@ func TestFilter_PricePerHour_NoResults(t *testing.T) {
 		onDemandCacheCount:              1,
 	}
 	filters := selector.Filters{
-		PricePerHour: &selector.Float64RangeFilter{
-			LowerBound: 0.0105,
-			UpperBound: 0.0105,
-		},
+		PricePerHour: selector.NewRange[float64](0.0105, 0.0105),
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
@@ -626,6 +767,170 @@ func TestFilter_PricePerHour_NoResults(t *testing.T) {
 	h.Assert(t, len(results) == 0, "Should return 0 instance types")
 }
 
+func TestFilter_PricePerHour_PricingOptions(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	pricingMock := &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp: 0.0196,
+		onDemandCacheCount:              1,
+	}
+	itf.EC2Pricing = pricingMock
+	operatingSystem, tenancy := ec2pricing.OperatingSystemWindows, ec2pricing.TenancyDedicated
+	filters := selector.Filters{
+		PricePerHour:    selector.NewRange[float64](0.0196, 0.0196),
+		OperatingSystem: &operatingSystem,
+		Tenancy:         &tenancy,
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 1, fmt.Sprintf("Should return 1 instance type; got %d", len(results)))
+	h.Equals(t, ec2pricing.PricingOptions{OperatingSystem: operatingSystem, Tenancy: tenancy}, pricingMock.pricingOptions)
+}
+
+func TestFilter_PricePerHour_PricingModel(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp: 0.0104,
+		GetReservedInstanceTypeCostsResp: ec2pricing.ReservedPrices{
+			ec2pricing.PricingModelReserved1YrStandardPartialUpfront: {HourlyRate: 0.003, UpfrontFee: 26.28, EffectiveHourlyRate: 0.006},
+		},
+		onDemandCacheCount: 1,
+	}
+	pricingModel := ec2pricing.PricingModelReserved1YrStandardPartialUpfront
+	filters := selector.Filters{
+		PricePerHour: selector.RangeAtMost(0.006),
+		PricePerVCPU: selector.RangeAtMost(0.003),
+		PricingModel: &pricingModel,
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(results))
+	h.Equals(t, 0.006, *results[0].PricingModelPricePerHour)
+	h.Equals(t, 26.28, results[0].ReservedPrices[pricingModel].UpfrontFee)
+
+	// instance types without a price for the pricing model are filtered out
+	pricingModel = ec2pricing.PricingModelReserved3YrConvertibleAllUpfront
+	results, err = itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+}
+
+func TestFilter_SpotVolatility(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	pricingMock := &ec2PricingMock{
+		GetSpotInstanceTypeNDayAvgCostResp: 0.0031,
+		GetSpotInstanceTypeNDayStatsResp: map[string]ec2pricing.SpotPriceStats{
+			"us-east-1a": {Current: 0.0031, Min: 0.003, Max: 0.0032, Mean: 0.0031, P50: 0.0031, P90: 0.0032, StdDev: 0.0001, Volatility: 0.03},
+			"us-east-1b": {Current: 0.0035, Min: 0.003, Max: 0.005, Mean: 0.0035, P50: 0.0034, P90: 0.0045, StdDev: 0.0007, Volatility: 0.2},
+		},
+		spotCacheCount: 1,
+	}
+	itf.EC2Pricing = pricingMock
+	filters := selector.Filters{
+		SpotDays:       aws.Int(7),
+		SpotVolatility: selector.RangeAtMost(0.25),
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(results))
+	h.Equals(t, 7, pricingMock.spotDays)
+	h.Equals(t, 0.2, *results[0].SpotVolatility)
+	h.Equals(t, 2, len(results[0].SpotPriceStats))
+
+	// the most volatile availability zone is filtered on
+	filters.SpotVolatility = selector.RangeAtMost(0.1)
+	results, err = itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+}
+
+func TestFilter_PricePerHour_SpotAZStrategy(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetSpotInstanceTypeNDayAvgCostResp: 0.0035,
+		GetSpotInstanceTypeNDayStatsResp: map[string]ec2pricing.SpotPriceStats{
+			"us-east-1a": {Mean: 0.005},
+			"us-east-1b": {Mean: 0.002},
+			"us-east-1c": {Mean: 0.0035},
+		},
+		spotCacheCount: 1,
+	}
+	spotUsage := ec2types.UsageClassTypeSpot
+	strategy := selector.SpotAZStrategyMin
+	filters := selector.Filters{
+		PricePerHour:   selector.RangeAtMost(0.003),
+		UsageClass:     &spotUsage,
+		SpotAZStrategy: &strategy,
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(results))
+	h.Equals(t, "us-east-1b", *results[0].CheapestSpotAvailabilityZone)
+	h.Equals(t, 0.005, results[0].SpotPriceByAvailabilityZone["us-east-1a"])
+	h.Equals(t, 0.0035, *results[0].SpotPrice)
+
+	for _, strategy = range []selector.SpotAZStrategy{selector.SpotAZStrategyAverage, selector.SpotAZStrategyMax} {
+		results, err = itf.FilterVerbose(ctx, filters)
+		h.Ok(t, err)
+		h.Assert(t, len(results) == 0, "Should not return instance types when filtering on the %s spot price of the availability zones", strategy)
+	}
+}
+
+func TestFilter_SpotDiscount(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp:    0.01,
+		GetSpotInstanceTypeNDayAvgCostResp: 0.003,
+		onDemandCacheCount:                 1,
+		spotCacheCount:                     1,
+	}
+	filters := selector.Filters{
+		SpotDiscount: selector.RangeAtLeast(60.0),
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(results))
+	h.Equals(t, (0.01-0.003)/0.01*100, *results[0].SpotDiscount)
+
+	filters.SpotDiscount = selector.RangeAtLeast(75.0)
+	results, err = itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+
+	// instance types without both prices are filtered out
+	itf.EC2Pricing = &ec2PricingMock{
+		GetSpotInstanceTypeNDayAvgCostResp: 0.003,
+		spotCacheCount:                     1,
+	}
+	filters.SpotDiscount = selector.RangeAtLeast(0.0)
+	results, err = itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 0, len(results))
+
+	// the spot discount of the spot AZ strategy is both filtered on and returned
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp:    0.01,
+		GetSpotInstanceTypeNDayAvgCostResp: 0.003,
+		GetSpotInstanceTypeNDayStatsResp: map[string]ec2pricing.SpotPriceStats{
+			"us-east-1a": {Mean: 0.005},
+			"us-east-1b": {Mean: 0.002},
+		},
+		onDemandCacheCount: 1,
+		spotCacheCount:     1,
+	}
+	strategy := selector.SpotAZStrategyMax
+	filters.SpotAZStrategy = &strategy
+	filters.SpotDiscount = selector.RangeAtMost(60.0)
+	results, err = itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 1, len(results))
+	h.Equals(t, (0.01-0.005)/0.01*100, *results[0].SpotDiscount)
+}
+
 func TestFilter_PricePerHour_OD(t *testing.T) {
 	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
 	itf.EC2Pricing = &ec2PricingMock{
@@ -634,11 +939,8 @@ func TestFilter_PricePerHour_OD(t *testing.T) {
 	}
 	onDemandUsage := ec2types.UsageClassTypeOnDemand
 	filters := selector.Filters{
-		PricePerHour: &selector.Float64RangeFilter{
-			LowerBound: 0.0104,
-			UpperBound: 0.0104,
-		},
-		UsageClass: &onDemandUsage,
+		PricePerHour: selector.NewRange[float64](0.0104, 0.0104),
+		UsageClass:   &onDemandUsage,
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
@@ -654,14 +956,82 @@ func TestFilter_PricePerHour_Spot(t *testing.T) {
 	}
 	spotUsage := ec2types.UsageClassTypeSpot
 	filters := selector.Filters{
-		PricePerHour: &selector.Float64RangeFilter{
-			LowerBound: 0.0104,
-			UpperBound: 0.0104,
-		},
-		UsageClass: &spotUsage,
+		PricePerHour: selector.NewRange[float64](0.0104, 0.0104),
+		UsageClass:   &spotUsage,
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 1, fmt.Sprintf("Should return 1 instance type; got %d", len(results)))
+}
+
+func TestFilterVerbose_PricePerformance(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp: 0.0104,
+		onDemandCacheCount:              1,
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, selector.Filters{})
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 1, fmt.Sprintf("Should return 1 instance type; got %d", len(results)))
+	h.Equals(t, aws.Float64(0.0052), results[0].OndemandPricePerVCPU)
+	h.Equals(t, aws.Float64(0.0104), results[0].OndemandPricePerGiBMemory)
+	h.Equals(t, aws.Float64(0.00208), results[0].OndemandPricePerGbpsNetwork)
+	h.Assert(t, results[0].OndemandPricePerGPU == nil, "Price per GPU should be nil for an instance type without GPUs")
+	h.Assert(t, results[0].SpotPricePerVCPU == nil, "Spot price per vCPU should be nil when spot prices are not fetched")
+}
+
+func TestFilter_PricePerVCPU(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp: 0.0104,
+		onDemandCacheCount:              1,
+	}
+	filters := selector.Filters{
+		PricePerVCPU: selector.NewRange[float64](0, 0.006),
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 1, fmt.Sprintf("Should return 1 instance type; got %d", len(results)))
+
+	filters.PricePerVCPU.UpperBound = aws.Float64(0.005)
+	results, err = itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 0, "Should return 0 instance types")
+}
+
+func TestFilter_PricePerGiBMemory_Spot(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp:    0.0104,
+		onDemandCacheCount:                 1,
+		GetSpotInstanceTypeNDayAvgCostResp: 0.0031,
+		spotCacheCount:                     1,
+	}
+	spotUsage := ec2types.UsageClassTypeSpot
+	filters := selector.Filters{
+		PricePerGiBMemory: selector.NewRange[float64](0, 0.004),
+		UsageClass:        &spotUsage,
 	}
 	ctx := context.Background()
 	results, err := itf.Filter(ctx, filters)
 	h.Ok(t, err)
 	h.Assert(t, len(results) == 1, fmt.Sprintf("Should return 1 instance type; got %d", len(results)))
 }
+
+func TestFilter_PricePerGPU_NoGPUs(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro.json"))
+	itf.EC2Pricing = &ec2PricingMock{
+		GetOndemandInstanceTypeCostResp: 0.0104,
+		onDemandCacheCount:              1,
+	}
+	filters := selector.Filters{
+		PricePerGPU: selector.NewRange[float64](0, 10),
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	h.Assert(t, len(results) == 0, "Should return 0 instance types")
+}
@@ -0,0 +1,178 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// Attributes which can be compared in similarity searches.
+const (
+	SimilarityVCPUs           = "vcpus"
+	SimilarityMemory          = "memory"
+	SimilarityNetwork         = "network"
+	SimilarityGPUs            = "gpus"
+	SimilarityInstanceStorage = "instance-storage"
+	SimilarityEBSBandwidth    = "ebs-bandwidth"
+	SimilarityArchitecture    = "architecture"
+)
+
+// similarityAttributes maps a similarity attribute to the distance between a candidate and the base instance type for it.
+var similarityAttributes = map[string]func(base *instancetypes.Details, candidate *instancetypes.Details) float64{
+	SimilarityVCPUs: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		return relativeDistance(int32Value(getDefaultVCpus(base.VCpuInfo)), int32Value(getDefaultVCpus(candidate.VCpuInfo)))
+	},
+	SimilarityMemory: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		return relativeDistance(int64Value(getMemorySizeInMiB(base.MemoryInfo)), int64Value(getMemorySizeInMiB(candidate.MemoryInfo)))
+	},
+	SimilarityNetwork: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		return relativeDistance(getNetworkBandwidthGbps(base.NetworkInfo), getNetworkBandwidthGbps(candidate.NetworkInfo))
+	},
+	SimilarityGPUs: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		return relativeDistance(int32Value(getTotalGpusCount(base.GpuInfo)), int32Value(getTotalGpusCount(candidate.GpuInfo)))
+	},
+	SimilarityInstanceStorage: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		return relativeDistance(int64Value(getInstanceStorage(base.InstanceStorageInfo)), int64Value(getInstanceStorage(candidate.InstanceStorageInfo)))
+	},
+	SimilarityEBSBandwidth: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		return relativeDistance(int32Value(getEBSOptimizedBaselineBandwidth(base.EbsInfo)), int32Value(getEBSOptimizedBaselineBandwidth(candidate.EbsInfo)))
+	},
+	SimilarityArchitecture: func(base *instancetypes.Details, candidate *instancetypes.Details) float64 {
+		for _, baseArchitecture := range getSupportedArchitectures(base.ProcessorInfo) {
+			for _, candidateArchitecture := range getSupportedArchitectures(candidate.ProcessorInfo) {
+				if baseArchitecture == candidateArchitecture {
+					return 0
+				}
+			}
+		}
+		return 1
+	},
+}
+
+// SimilarityAttributes returns the attributes which can be compared in similarity searches.
+func SimilarityAttributes() []string {
+	attributes := []string{}
+	for attribute := range similarityAttributes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+// isSimilaritySearch returns true if the filters rank instance types by their distance to the base instance type.
+func isSimilaritySearch(filters Filters) bool {
+	return filters.InstanceTypeBase != nil && filters.InstanceTypeBaseSimilarity != nil && *filters.InstanceTypeBaseSimilarity
+}
+
+// rankBySimilarity populates the Similarity of each instance type and sorts them by their distance to the base instance type in ascending order.
+// The distance is the euclidean distance of the per-attribute distances, where numeric attributes are compared by their relative
+// difference and the architecture is 0 if the instance types share an architecture and 1 otherwise.
+func (s Selector) rankBySimilarity(ctx context.Context, filters Filters, instanceTypes []*instancetypes.Details) ([]*instancetypes.Details, error) {
+	attributes := SimilarityAttributes()
+	if filters.SimilarityAttributes != nil && len(*filters.SimilarityAttributes) > 0 {
+		attributes = *filters.SimilarityAttributes
+	}
+	for _, attribute := range attributes {
+		if _, ok := similarityAttributes[attribute]; !ok {
+			return nil, fmt.Errorf("similarity attribute %s is not supported, must be one of %v", attribute, SimilarityAttributes())
+		}
+	}
+	baseInstanceTypes, err := s.InstanceTypesProvider.Get(ctx, []ec2types.InstanceType{ec2types.InstanceType(*filters.InstanceTypeBase)})
+	if err != nil {
+		return nil, err
+	}
+	var base *instancetypes.Details
+	for _, instanceTypeInfo := range baseInstanceTypes {
+		if string(instanceTypeInfo.InstanceType) == *filters.InstanceTypeBase {
+			base = instanceTypeInfo
+			break
+		}
+	}
+	if base == nil {
+		return nil, fmt.Errorf("error instance type %s is not a valid instance type", *filters.InstanceTypeBase)
+	}
+
+	for _, instanceTypeInfo := range instanceTypes {
+		similarity := &instancetypes.Similarity{InstanceTypeBase: *filters.InstanceTypeBase, Attributes: map[string]float64{}}
+		sumOfSquares := 0.0
+		for _, attribute := range attributes {
+			distance := similarityAttributes[attribute](base, instanceTypeInfo)
+			similarity.Attributes[attribute] = distance
+			sumOfSquares += distance * distance
+		}
+		similarity.Distance = math.Sqrt(sumOfSquares)
+		instanceTypeInfo.Similarity = similarity
+	}
+	rankedInstanceTypes := append([]*instancetypes.Details{}, instanceTypes...)
+	sort.SliceStable(rankedInstanceTypes, func(i, j int) bool {
+		if rankedInstanceTypes[i].Similarity.Distance != rankedInstanceTypes[j].Similarity.Distance {
+			return rankedInstanceTypes[i].Similarity.Distance < rankedInstanceTypes[j].Similarity.Distance
+		}
+		return rankedInstanceTypes[i].InstanceType < rankedInstanceTypes[j].InstanceType
+	})
+	return rankedInstanceTypes, nil
+}
+
+// relativeDistance returns the difference between the values relative to the larger value, which is between 0 and 1.
+func relativeDistance(base float64, candidate float64) float64 {
+	larger := math.Max(math.Abs(base), math.Abs(candidate))
+	if larger == 0 {
+		return 0
+	}
+	return math.Abs(base-candidate) / larger
+}
+
+func int32Value(value *int32) float64 {
+	if value == nil {
+		return 0
+	}
+	return float64(*value)
+}
+
+func int64Value(value *int64) float64 {
+	if value == nil {
+		return 0
+	}
+	return float64(*value)
+}
+
+// getDefaultVCpus returns the default vcpus of the vcpu info or nil if it is not reported.
+func getDefaultVCpus(vcpuInfo *ec2types.VCpuInfo) *int32 {
+	if vcpuInfo == nil {
+		return nil
+	}
+	return vcpuInfo.DefaultVCpus
+}
+
+// getMemorySizeInMiB returns the memory size of the memory info or nil if it is not reported.
+func getMemorySizeInMiB(memoryInfo *ec2types.MemoryInfo) *int64 {
+	if memoryInfo == nil {
+		return nil
+	}
+	return memoryInfo.SizeInMiB
+}
+
+// getSupportedArchitectures returns the supported architectures of the processor info or nil if it is not reported.
+func getSupportedArchitectures(processorInfo *ec2types.ProcessorInfo) []ec2types.ArchitectureType {
+	if processorInfo == nil {
+		return nil
+	}
+	return processorInfo.SupportedArchitectures
+}
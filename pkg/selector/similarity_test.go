@@ -0,0 +1,104 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector/outputs"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func mapKeys(m map[string]float64) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Tests
+
+func TestFilterVerbose_Similarity(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		InstanceTypeBase:           aws.String("c4.large"),
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+		MaxResults:                 aws.Int(3),
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	h.Equals(t, 3, len(results))
+	h.Equals(t, []string{"c4.large", "c4.xlarge", "c3.large"}, outputs.SimpleInstanceTypeOutput(results))
+	h.Equals(t, "c4.large", results[0].Similarity.InstanceTypeBase)
+	h.Equals(t, 0.0, results[0].Similarity.Distance)
+	h.Equals(t, math.Sqrt(0.5), results[1].Similarity.Distance)
+	h.Equals(t, 0.5, results[1].Similarity.Attributes[selector.SimilarityVCPUs])
+	h.Equals(t, 1.0, results[2].Similarity.Attributes[selector.SimilarityInstanceStorage])
+	h.Equals(t, len(selector.SimilarityAttributes()), len(results[2].Similarity.Attributes))
+}
+
+func TestFilterVerbose_SimilarityAttributes(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		InstanceTypeBase:           aws.String("c4.large"),
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+		SimilarityAttributes:       &[]string{selector.SimilarityInstanceStorage},
+	}
+	ctx := context.Background()
+	results, err := itf.FilterVerbose(ctx, filters)
+	h.Ok(t, err)
+	for _, result := range results {
+		h.Equals(t, []string{selector.SimilarityInstanceStorage}, mapKeys(result.Similarity.Attributes))
+	}
+	h.Assert(t, results[len(results)-1].Similarity.Distance == 1, "instance types with instance storage should be ranked last")
+
+	filters.SimilarityAttributes = &[]string{"color"}
+	_, err = itf.FilterVerbose(ctx, filters)
+	h.Nok(t, err)
+}
+
+func TestFilter_SimilarityInvalidBase(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		InstanceTypeBase:           aws.String("m5.bogus"),
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+	}
+	ctx := context.Background()
+	_, err := itf.Filter(ctx, filters)
+	h.Nok(t, err)
+}
+
+func TestWhyNot_Similarity(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		InstanceTypeBase:           aws.String("c4.large"),
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+		MaxResults:                 aws.Int(1),
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "c4.xlarge")
+	h.Ok(t, err)
+	h.Equals(t, 2, report.Rank)
+	h.Assert(t, report.ExcludedByMaxResults, "c4.xlarge should be excluded by max results")
+}
@@ -0,0 +1,100 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"fmt"
+)
+
+// Names of the built-in aggregate filter transforms.
+const (
+	TransformStageInput            = "input"
+	TransformStageBaseInstanceType = "base-instance-type"
+	TransformStageFlexible         = "flexible"
+	TransformStageService          = "service"
+)
+
+// TransformPosition is where a custom transform runs relative to the built-in transforms.
+type TransformPosition string
+
+// Enum values for TransformPosition.
+const (
+	TransformBeforeBuiltIns TransformPosition = "before"
+	TransformAfterBuiltIns  TransformPosition = "after"
+)
+
+// NamedTransform is a FiltersTransform with a name identifying its stage in the transform pipeline.
+type NamedTransform struct {
+	Name      string
+	Transform FiltersTransform
+}
+
+// TransformStage is the effective Filters after a stage of the transform pipeline.
+type TransformStage struct {
+	Name    string
+	Filters Filters
+}
+
+// RegisterTransform adds a custom transform which runs before or after the built-in transforms.
+// Transforms in the same position run in the order they are registered.
+func (s *Selector) RegisterTransform(name string, position TransformPosition, transform FiltersTransform) error {
+	if name == "" {
+		return fmt.Errorf("a transform must have a name")
+	}
+	if transform == nil {
+		return fmt.Errorf("transform %s must not be nil", name)
+	}
+	for _, namedTransform := range s.transforms() {
+		if namedTransform.Name == name {
+			return fmt.Errorf("transform %s is already registered", name)
+		}
+	}
+	namedTransform := NamedTransform{Name: name, Transform: transform}
+	switch position {
+	case TransformBeforeBuiltIns:
+		s.TransformsBefore = append(s.TransformsBefore, namedTransform)
+	case TransformAfterBuiltIns:
+		s.TransformsAfter = append(s.TransformsAfter, namedTransform)
+	default:
+		return fmt.Errorf("transform position %s is not supported, must be one of [%s %s]", position, TransformBeforeBuiltIns, TransformAfterBuiltIns)
+	}
+	return nil
+}
+
+// AggregateFilterTransformStages runs the transform pipeline like AggregateFilterTransform and returns the effective Filters
+// after each stage, starting with the input Filters. The stages completed before an error are returned with the error.
+func (s Selector) AggregateFilterTransformStages(ctx context.Context, filters Filters) ([]TransformStage, error) {
+	stages := []TransformStage{{Name: TransformStageInput, Filters: filters}}
+	var err error
+	for _, namedTransform := range s.transforms() {
+		filters, err = namedTransform.Transform.Transform(ctx, filters)
+		if err != nil {
+			return stages, err
+		}
+		stages = append(stages, TransformStage{Name: namedTransform.Name, Filters: filters})
+	}
+	return stages, nil
+}
+
+// transforms returns the custom transforms registered before the built-ins, the built-in transforms, and the custom transforms
+// registered after the built-ins in the order they run.
+func (s Selector) transforms() []NamedTransform {
+	transforms := append([]NamedTransform{}, s.TransformsBefore...)
+	transforms = append(transforms,
+		NamedTransform{Name: TransformStageBaseInstanceType, Transform: TransformFn(s.TransformBaseInstanceType)},
+		NamedTransform{Name: TransformStageFlexible, Transform: TransformFn(s.TransformFlexible)},
+		NamedTransform{Name: TransformStageService, Transform: TransformFn(s.TransformForService)},
+	)
+	return append(transforms, s.TransformsAfter...)
+}
@@ -0,0 +1,110 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func denyPreviousGeneration(ctx context.Context, filters selector.Filters) (selector.Filters, error) {
+	if filters.DenyList == nil {
+		filters.DenyList = regexp.MustCompile(`^[cmr][1-4]\..*$`)
+	}
+	return filters, nil
+}
+
+func stageNames(stages []selector.TransformStage) []string {
+	names := []string{}
+	for _, stage := range stages {
+		names = append(names, stage.Name)
+	}
+	return names
+}
+
+// Tests
+
+func TestRegisterTransform(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	h.Ok(t, itf.RegisterTransform("deny-previous-generation", selector.TransformBeforeBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+	filters := selector.Filters{
+		AllowList: regexp.MustCompile(`^c[45]\..*$|^c5d\..*$`),
+	}
+	ctx := context.Background()
+	results, err := itf.Filter(ctx, filters)
+	h.Ok(t, err)
+	for _, result := range results {
+		h.Assert(t, !regexp.MustCompile(`^c4\.`).MatchString(result), "%s should be denied by the custom transform", result)
+	}
+	h.Assert(t, len(results) > 0, "c5 instance types should not be denied by the custom transform")
+}
+
+func TestRegisterTransform_Errors(t *testing.T) {
+	itf := selector.Selector{}
+	h.Nok(t, itf.RegisterTransform("", selector.TransformBeforeBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+	h.Nok(t, itf.RegisterTransform("nil-transform", selector.TransformBeforeBuiltIns, nil))
+	h.Nok(t, itf.RegisterTransform("deny-previous-generation", selector.TransformPosition("middle"), selector.TransformFn(denyPreviousGeneration)))
+	h.Nok(t, itf.RegisterTransform(selector.TransformStageFlexible, selector.TransformAfterBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+	h.Ok(t, itf.RegisterTransform("deny-previous-generation", selector.TransformBeforeBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+	h.Nok(t, itf.RegisterTransform("deny-previous-generation", selector.TransformAfterBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+}
+
+func TestAggregateFilterTransformStages(t *testing.T) {
+	itf := selector.Selector{}
+	h.Ok(t, itf.RegisterTransform("deny-previous-generation", selector.TransformBeforeBuiltIns, selector.TransformFn(denyPreviousGeneration)))
+	h.Ok(t, itf.RegisterTransform("double-vcpus", selector.TransformAfterBuiltIns, selector.TransformFn(func(ctx context.Context, filters selector.Filters) (selector.Filters, error) {
+		filters.VCpusRange = selector.NewRange(*filters.VCpusRange.LowerBound*2, *filters.VCpusRange.UpperBound*2)
+		return filters, nil
+	})))
+	ctx := context.Background()
+	stages, err := itf.AggregateFilterTransformStages(ctx, selector.Filters{Flexible: aws.Bool(true)})
+	h.Ok(t, err)
+	h.Equals(t, []string{
+		selector.TransformStageInput,
+		"deny-previous-generation",
+		selector.TransformStageBaseInstanceType,
+		selector.TransformStageFlexible,
+		selector.TransformStageService,
+		"double-vcpus",
+	}, stageNames(stages))
+	h.Assert(t, stages[0].Filters.DenyList == nil, "the input stage should not be transformed")
+	h.Assert(t, stages[1].Filters.DenyList != nil && stages[1].Filters.VCpusRange == nil, "the custom transform should run before the built-ins")
+	h.Equals(t, selector.NewRange[int32](4, 4), stages[3].Filters.VCpusRange)
+	h.Equals(t, selector.NewRange[int32](8, 8), stages[5].Filters.VCpusRange)
+
+	filters, err := itf.AggregateFilterTransform(ctx, selector.Filters{Flexible: aws.Bool(true)})
+	h.Ok(t, err)
+	h.Equals(t, stages[5].Filters.VCpusRange, filters.VCpusRange)
+}
+
+func TestAggregateFilterTransformStages_Error(t *testing.T) {
+	itf := selector.Selector{}
+	h.Ok(t, itf.RegisterTransform("fail", selector.TransformAfterBuiltIns, selector.TransformFn(func(ctx context.Context, filters selector.Filters) (selector.Filters, error) {
+		return filters, errors.New("error")
+	})))
+	ctx := context.Background()
+	stages, err := itf.AggregateFilterTransformStages(ctx, selector.Filters{})
+	h.Nok(t, err)
+	h.Equals(t, selector.TransformStageService, stages[len(stages)-1].Name)
+	_, err = itf.Filter(ctx, selector.Filters{})
+	h.Nok(t, err)
+}
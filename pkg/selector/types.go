@@ -45,11 +45,18 @@ type Selector struct {
 	EC2Pricing            ec2pricing.EC2PricingIface
 	InstanceTypesProvider *instancetypes.Provider
 	ServiceRegistry       ServiceRegistry
+	FilterRegistry        FilterRegistry
 	Logger                *log.Logger
+	RegionalSelectors     *RegionalSelectors
+	FlexibleProfiles      map[string]FlexibleProfile
+	TransformsBefore      []NamedTransform
+	TransformsAfter       []NamedTransform
 }
 
 // IntRangeFilter holds an upper and lower bound int
 // The lower and upper bound are used to range filter resource specs.
+//
+// Deprecated: use Range[int], which ToRange converts to.
 type IntRangeFilter struct {
 	UpperBound int
 	LowerBound int
@@ -57,6 +64,8 @@ type IntRangeFilter struct {
 
 // Int32RangeFilter holds an upper and lower bound int
 // The lower and upper bound are used to range filter resource specs.
+//
+// Deprecated: use Range[int32], which ToRange converts to.
 type Int32RangeFilter struct {
 	UpperBound int32
 	LowerBound int32
@@ -64,6 +73,8 @@ type Int32RangeFilter struct {
 
 // Uint64RangeFilter holds an upper and lower bound uint64
 // The lower and upper bound are used to range filter resource specs.
+//
+// Deprecated: use Range[uint64], which ToRange converts to.
 type Uint64RangeFilter struct {
 	UpperBound uint64
 	LowerBound uint64
@@ -71,6 +82,8 @@ type Uint64RangeFilter struct {
 
 // ByteQuantityRangeFilter holds an upper and lower bound byte quantity
 // The lower and upper bound are used to range filter resource specs.
+//
+// Deprecated: use Range[bytequantity.ByteQuantity], which ToRange converts to.
 type ByteQuantityRangeFilter struct {
 	UpperBound bytequantity.ByteQuantity
 	LowerBound bytequantity.ByteQuantity
@@ -78,6 +91,8 @@ type ByteQuantityRangeFilter struct {
 
 // Float64RangeFilter holds an upper and lower bound float64
 // The lower and upper bound are used to range filter resource specs.
+//
+// Deprecated: use Range[float64], which ToRange converts to.
 type Float64RangeFilter struct {
 	UpperBound float64
 	LowerBound float64
@@ -99,16 +114,30 @@ func getRegexpString(r *regexp.Regexp) *string {
 
 // MarshalIndent is used to return a pretty-print json representation of a Filters struct.
 func (f *Filters) MarshalIndent(prefix, indent string) ([]byte, error) {
-	type Alias Filters
-	return json.MarshalIndent(&struct {
-		AllowList *string
-		DenyList  *string
-		*Alias
-	}{
-		AllowList: getRegexpString(f.AllowList),
-		DenyList:  getRegexpString(f.DenyList),
-		Alias:     (*Alias)(f),
-	}, prefix, indent)
+	return json.MarshalIndent(f, prefix, indent)
+}
+
+// PricingOptions returns the operating system, tenancy, and license model instance types are priced for.
+func (f Filters) PricingOptions() ec2pricing.PricingOptions {
+	options := ec2pricing.PricingOptions{}
+	if f.OperatingSystem != nil {
+		options.OperatingSystem = *f.OperatingSystem
+	}
+	if f.Tenancy != nil {
+		options.Tenancy = *f.Tenancy
+	}
+	if f.LicenseModel != nil {
+		options.LicenseModel = *f.LicenseModel
+	}
+	return options
+}
+
+// SpotDaysBack returns the number of days of spot price history the spot prices are calculated from.
+func (f Filters) SpotDaysBack() int {
+	if f.SpotDays != nil {
+		return *f.SpotDays
+	}
+	return ec2pricing.DefaultSpotDaysBack
 }
 
 // Filters is used to group instance type resource attributes for filtering.
@@ -150,10 +179,10 @@ type Filters struct {
 	Fpga *bool
 
 	// GpusRange filter is a range of acceptable GPU count available to an EC2 instance type
-	GpusRange *Int32RangeFilter
+	GpusRange *Range[int32]
 
 	// GpuMemoryRange filter is a range of acceptable GPU memory in Gibibytes (GiB) available to an EC2 instance type in aggreagte across all GPUs.
-	GpuMemoryRange *ByteQuantityRangeFilter
+	GpuMemoryRange *Range[bytequantity.ByteQuantity]
 
 	// GPUManufacturer filters by GPU manufacturer
 	GPUManufacturer *string
@@ -162,7 +191,7 @@ type Filters struct {
 	GPUModel *string
 
 	// InferenceAcceleratorsRange filters inference accelerators available to the instance type
-	InferenceAcceleratorsRange *IntRangeFilter
+	InferenceAcceleratorsRange *Range[int]
 
 	// InferenceAcceleratorManufacturer filters by inference acceleartor manufacturer
 	InferenceAcceleratorManufacturer *string
@@ -182,13 +211,13 @@ type Filters struct {
 	MaxResults *int
 
 	// MemoryRange filter is a range of acceptable DRAM memory in Gibibytes (GiB) for the instance type
-	MemoryRange *ByteQuantityRangeFilter
+	MemoryRange *Range[bytequantity.ByteQuantity]
 
 	// NetworkInterfaces filter is a range of the number of ENI attachments an instance type can support
-	NetworkInterfaces *Int32RangeFilter
+	NetworkInterfaces *Range[int32]
 
 	// NetworkPerformance filter is a range of network bandwidth an instance type can support
-	NetworkPerformance *IntRangeFilter
+	NetworkPerformance *Range[int]
 
 	// NetworkEncryption filters for instance types that automatically encrypt network traffic in-transit
 	NetworkEncryption *bool
@@ -206,6 +235,16 @@ type Filters struct {
 	// Example: us-east-1, us-east-2, eu-west-1, etc.
 	Region *string
 
+	// Regions are multiple AWS Regions to select instance types from in a single query
+	// Offerings and prices are retrieved per region and the selected instance types are annotated with their regional availability.
+	// Regions takes precedence over Region and cannot be combined with AvailabilityZones.
+	// Example: us-east-1, us-west-2, eu-west-1, etc.
+	Regions *[]string
+
+	// RegionMode determines if instance types must be available in all of the Regions or any of the Regions
+	// Possible values are: all or any (default: all)
+	RegionMode *RegionMode
+
 	// RootDeviceType is the backing device of the root storage volume
 	// Possible values are: instance-store or ebs
 	RootDeviceType *ec2types.RootDeviceType
@@ -214,12 +253,32 @@ type Filters struct {
 	// Possible values are: spot or on-demand
 	UsageClass *ec2types.UsageClassType
 
+	// PricingModel is the pricing model whose hourly price the PricePerHour and price-performance filters apply to
+	// Possible values are: on-demand, spot, or a reserved instance term like reserved-1yr-standard-no-upfront
+	// (default: spot prices for the spot usage class and on-demand prices otherwise)
+	PricingModel *ec2pricing.PricingModel
+
+	// OperatingSystem is the operating system and pre-installed software instance types are priced for
+	// Possible values are: linux, windows, rhel, suse, windows-sql-web, windows-sql-standard, or windows-sql-enterprise (default: linux)
+	OperatingSystem *ec2pricing.OperatingSystem
+
+	// Tenancy is the tenancy instance types are priced for
+	// Possible values are: shared or dedicated (default: shared)
+	Tenancy *ec2pricing.Tenancy
+
+	// LicenseModel is the license model instance types are priced for
+	// Possible values are: no-license-required or byol (default: no-license-required)
+	LicenseModel *ec2pricing.LicenseModel
+
 	// VCpusRange filter is a range of acceptable VCpus for the instance type
-	VCpusRange *Int32RangeFilter
+	VCpusRange *Range[int32]
 
 	// VcpusToMemoryRatio is a ratio of vcpus to memory expressed as a floating point
 	VCpusToMemoryRatio *float64
 
+	// VCpusToMemoryRatioRange filter is a range of acceptable GiBs of memory per vcpu (i.e. 1:4 is 4.0)
+	VCpusToMemoryRatioRange *Range[float64]
+
 	// AllowList is a regex of allowed instance types
 	AllowList *regexp.Regexp
 
@@ -229,10 +288,34 @@ type Filters struct {
 	// InstanceTypeBase is a base instance type which is used to retrieve similarly spec'd instance types
 	InstanceTypeBase *string
 
+	// InstanceTypeBases are base instance types which each retrieve similarly spec'd instance types as if set as the InstanceTypeBase
+	// Use FilterInstanceTypeBases to retrieve the candidates grouped by base instance type
+	InstanceTypeBases *[]string
+
+	// InstanceTypeBaseLowPercentile is the fraction of the base instance type's vcpus and memory used as the lower bound of their ranges
+	// Defaults to AggregateLowPercentile
+	InstanceTypeBaseLowPercentile *float64
+
+	// InstanceTypeBaseHighPercentile is the fraction of the base instance type's vcpus and memory used as the upper bound of their ranges
+	// Defaults to AggregateHighPercentile
+	InstanceTypeBaseHighPercentile *float64
+
+	// InstanceTypeBaseSimilarity ranks instance types by their distance to the base instance type across the SimilarityAttributes
+	// instead of filtering on ranges of the base instance type's vcpus, memory, gpus, and architecture
+	InstanceTypeBaseSimilarity *bool
+
+	// SimilarityAttributes are the attributes compared when ranking by similarity to the base instance type
+	// Defaults to all of SimilarityAttributes()
+	SimilarityAttributes *[]string
+
 	// Flexible finds an opinionated set of general (c, m, r, t, a, etc.) instance types that match a criteria specified
 	// or defaults to 4 vcpus
 	Flexible *bool
 
+	// FlexibleProfile is the name of the flexible profile applied when Flexible is set
+	// Defaults to DefaultFlexibleProfile
+	FlexibleProfile *string
+
 	// Service filters instance types based on a service's supported list of instance types
 	// Example: eks or emr
 	Service *string
@@ -244,10 +327,42 @@ type Filters struct {
 	VirtualizationType *ec2types.VirtualizationType
 
 	// PricePerHour is used to return instance types that are equal to or cheaper than the specified price
-	PricePerHour *Float64RangeFilter
+	PricePerHour *Range[float64]
+
+	// SpotDays is the number of days of spot price history the spot prices and their statistics are calculated from
+	// 0 uses only the most recent spot prices. Defaults to ec2pricing.DefaultSpotDaysBack
+	SpotDays *int
+
+	// SpotDiscount filters on a range of the percentage the spot price is cheaper than the on-demand price (i.e. 60 is 60% cheaper)
+	SpotDiscount *Range[float64]
+
+	// SpotAZStrategy selects the spot price of the availability zones which the price filters use for spot instances
+	// and the SpotDiscount filter uses
+	// Possible values are: average, min, or max (default: the spot price)
+	SpotAZStrategy *SpotAZStrategy
+
+	// SpotVolatility filters on a range of spot price volatility, the standard deviation of the spot price relative to its mean
+	// The highest volatility of the availability zones is used
+	SpotVolatility *Range[float64]
+
+	// PricePerVCPU filters on a range of hourly price per vCPU
+	// The spot price is used if the usage class is spot, otherwise the on-demand price is used
+	PricePerVCPU *Range[float64]
+
+	// PricePerGiBMemory filters on a range of hourly price per GiB of memory
+	PricePerGiBMemory *Range[float64]
+
+	// PricePerGPU filters on a range of hourly price per GPU
+	PricePerGPU *Range[float64]
+
+	// PricePerGiBGPUMemory filters on a range of hourly price per GiB of GPU memory
+	PricePerGiBGPUMemory *Range[float64]
+
+	// PricePerGbpsNetwork filters on a range of hourly price per Gbps of network bandwidth
+	PricePerGbpsNetwork *Range[float64]
 
 	// InstanceStorageRange filters on a range of storage available as local disk
-	InstanceStorageRange *ByteQuantityRangeFilter
+	InstanceStorageRange *Range[bytequantity.ByteQuantity]
 
 	// DiskType is the backing storage medium
 	// Possible values are: hdd or ssd
@@ -263,13 +378,13 @@ type Filters struct {
 	DiskEncryption *bool
 
 	// EBSOptimizedBaselineBandwidth filters on a range of bandwidth that an EBS Optimized volume supports
-	EBSOptimizedBaselineBandwidth *ByteQuantityRangeFilter
+	EBSOptimizedBaselineBandwidth *Range[bytequantity.ByteQuantity]
 
 	// EBSOptimizedBaselineThroughput filters on a range of throughput that an EBS Optimized volume supports
-	EBSOptimizedBaselineThroughput *ByteQuantityRangeFilter
+	EBSOptimizedBaselineThroughput *Range[bytequantity.ByteQuantity]
 
 	// EBSOptimizedBaselineIOPS filters on a range of IOPS that an EBS Optimized volume supports
-	EBSOptimizedBaselineIOPS *IntRangeFilter
+	EBSOptimizedBaselineIOPS *Range[int]
 
 	// DedicatedHosts filters on instance types that support dedicated hosts tenancy
 	DedicatedHosts *bool
@@ -279,7 +394,41 @@ type Filters struct {
 	// NOTE that generation is only comparable per instance family
 	// For example, i3 and c5 are both 5th generation, but the Generation filter will
 	// only filter on the number in the instance type name.
-	Generation *IntRangeFilter
+	Generation *Range[int]
+
+	// Families filters on instance type families or series parsed from the instance type name
+	// i.e. c matches every c instance family while c7gn only matches c7gn
+	Families *[]string
+
+	// NameAttributes filters on instance types whose name has every one of the processor or capability suffixes
+	// i.e. d and n select c5dn and m6idn. Possible values are: a, g, i, b, d, e, n, z, or flex
+	NameAttributes *[]string
+
+	// SizeRange filters on a range of instance sizes in multiples of an xlarge, which instancename.ParseSize converts sizes to
+	// i.e. large is 0.5 and 4xlarge is 4, while metal is larger than every other size
+	SizeRange *Range[float64]
+
+	// Expression filters on instance types satisfying a boolean expression evaluated against the instance type details
+	// Example: MemoryInfo.SizeInMiB / VCpuInfo.DefaultVCpus >= 6144 && NetworkInfo.MaximumNetworkInterfaces >= 8
+	Expression *Expression
+
+	// CustomFilters filters on the custom filters registered in the Selector's FilterRegistry
+	// The map is keyed by the custom filter name and the value is passed to the custom filter, nil values are skipped
+	CustomFilters *map[string]interface{}
+}
+
+// AnyFilters is used to select instance types matching at least one of several Filters groups in a single query.
+type AnyFilters struct {
+	// Any is a list of Filters groups where an instance type is selected if it matches at least one of the groups
+	// If Any is empty, all instance types not excluded by Not are selected
+	Any []Filters
+
+	// Not is a list of Filters groups where an instance type is excluded if it matches any of the groups
+	Not []Filters
+
+	// MaxResults is the maximum number of instance types to return that match the query
+	// MaxResults set within the Any and Not groups is ignored
+	MaxResults *int
 }
 
 type CPUManufacturer string
@@ -302,6 +451,47 @@ func (CPUManufacturer) Values() []CPUManufacturer {
 	}
 }
 
+// RegionMode determines how instance types are selected across multiple regions.
+type RegionMode string
+
+// Enum values for RegionMode.
+const (
+	// RegionModeAll selects instance types which are available in every region
+	RegionModeAll RegionMode = "all"
+	// RegionModeAny selects instance types which are available in at least one region
+	RegionModeAny RegionMode = "any"
+)
+
+// Values returns all known values for RegionMode.
+func (RegionMode) Values() []RegionMode {
+	return []RegionMode{
+		RegionModeAll,
+		RegionModeAny,
+	}
+}
+
+// SpotAZStrategy determines which spot price of the availability zones is used for filtering.
+type SpotAZStrategy string
+
+// Enum values for SpotAZStrategy.
+const (
+	// SpotAZStrategyAverage uses the average of the spot prices of the availability zones
+	SpotAZStrategyAverage SpotAZStrategy = "average"
+	// SpotAZStrategyMin uses the spot price of the cheapest availability zone
+	SpotAZStrategyMin SpotAZStrategy = "min"
+	// SpotAZStrategyMax uses the spot price of the most expensive availability zone
+	SpotAZStrategyMax SpotAZStrategy = "max"
+)
+
+// Values returns all known values for SpotAZStrategy.
+func (SpotAZStrategy) Values() []SpotAZStrategy {
+	return []SpotAZStrategy{
+		SpotAZStrategyAverage,
+		SpotAZStrategyMin,
+		SpotAZStrategyMax,
+	}
+}
+
 // ArchitectureTypeAMD64 is a legacy type we support for b/c that isn't in the API.
 const (
 	ArchitectureTypeAMD64 ec2types.ArchitectureType = "amd64"
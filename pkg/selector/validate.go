@@ -0,0 +1,306 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.uber.org/multierr"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
+)
+
+// ValidationSeverity is how severe a ValidationIssue is.
+type ValidationSeverity string
+
+// Enum values for ValidationSeverity.
+const (
+	// ValidationSeverityError is an issue which causes the filters to select no instance types or to fail
+	ValidationSeverityError ValidationSeverity = "error"
+	// ValidationSeverityWarning is an issue which is likely a mistake but does not prevent instance types from being selected
+	ValidationSeverityWarning ValidationSeverity = "warning"
+)
+
+// ValidationIssue is a problem with one or more filters found by Filters.Validate.
+type ValidationIssue struct {
+	Severity ValidationSeverity
+
+	// Filters are the names of the Filters fields the issue applies to
+	Filters []string
+
+	// Message describes the issue
+	Message string
+}
+
+// String returns the filters and message of the issue.
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s", strings.Join(i.Filters, ", "), i.Message)
+}
+
+// ValidationIssues are the issues found by Filters.Validate.
+type ValidationIssues []ValidationIssue
+
+// Errors returns the issues with the error severity.
+func (v ValidationIssues) Errors() ValidationIssues {
+	return v.withSeverity(ValidationSeverityError)
+}
+
+// Warnings returns the issues with the warning severity.
+func (v ValidationIssues) Warnings() ValidationIssues {
+	return v.withSeverity(ValidationSeverityWarning)
+}
+
+// Err returns an error combining the issues with the error severity, or nil if there are none.
+func (v ValidationIssues) Err() error {
+	var errs error
+	for _, issue := range v.Errors() {
+		errs = multierr.Append(errs, errors.New(issue.String()))
+	}
+	return errs
+}
+
+func (v ValidationIssues) withSeverity(severity ValidationSeverity) ValidationIssues {
+	issues := ValidationIssues{}
+	for _, issue := range v {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+var (
+	// selectorEnumTypes are the enums defined by the selector, where unknown values are errors.
+	// Unknown values of the EC2 enums are warnings since the EC2 API may return values which are newer than the client.
+	selectorEnumTypes = map[reflect.Type]bool{
+		reflect.TypeOf(CPUManufacturer("")):            true,
+		reflect.TypeOf(RegionMode("")):                 true,
+		reflect.TypeOf(SpotAZStrategy("")):             true,
+		reflect.TypeOf(ec2pricing.OperatingSystem("")): true,
+		reflect.TypeOf(ec2pricing.Tenancy("")):         true,
+		reflect.TypeOf(ec2pricing.LicenseModel("")):    true,
+		reflect.TypeOf(ec2pricing.PricingModel("")):    true,
+	}
+	// stringEnumTypes are the EC2 enums of the filters which are plain strings
+	stringEnumTypes = map[string]reflect.Type{
+		"PlacementGroupStrategy": reflect.TypeOf(ec2types.PlacementGroupStrategy("")),
+		"DiskType":               reflect.TypeOf(ec2types.DiskType("")),
+	}
+	// zoneNameRegionRE matches the region prefix of an availability zone, local zone, or wavelength zone name
+	zoneNameRegionRE = regexp.MustCompile(`^([a-z]{2}(-gov)?-[a-z]+-[0-9]+)`)
+)
+
+// Validate checks the filters for impossible ranges, contradictory filters, and unknown enum values and availability zones
+// which would cause the filters to silently select no instance types. Availability zones are only checked against the
+// Region, use Selector.ValidateFilters to also check that they exist.
+func (f Filters) Validate() ValidationIssues {
+	issues := ValidationIssues{}
+	addIssue := func(severity ValidationSeverity, message string, filters ...string) {
+		issues = append(issues, ValidationIssue{Severity: severity, Filters: filters, Message: message})
+	}
+
+	filtersValue := reflect.ValueOf(f)
+	filtersType := filtersValue.Type()
+	for i := 0; i < filtersType.NumField(); i++ {
+		name := filtersType.Field(i).Name
+		field := filtersValue.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		if filter, ok := field.Interface().(rangeFilter); ok {
+			lower, upper := filter.floatBounds()
+			if lower < 0 && !math.IsInf(lower, -1) {
+				addIssue(ValidationSeverityError, fmt.Sprintf("lower bound %v must not be negative", lower), name)
+			}
+			if lower > upper {
+				addIssue(ValidationSeverityError, fmt.Sprintf("lower bound %v must not be greater than the upper bound %v", lower, upper), name)
+			} else if filter.IsEmpty() {
+				addIssue(ValidationSeverityError, fmt.Sprintf("lower bound %v must be less than the upper bound %v when either bound is exclusive", lower, upper), name)
+			}
+			continue
+		}
+		elem := field.Elem()
+		enumType := elem.Type()
+		if stringEnumType, ok := stringEnumTypes[name]; ok {
+			enumType = stringEnumType
+		}
+		if elem.Kind() != reflect.String {
+			continue
+		}
+		if known := enumValues(enumType); known != nil && !containsString(known, elem.String()) {
+			severity := ValidationSeverityWarning
+			if selectorEnumTypes[enumType] {
+				severity = ValidationSeverityError
+			}
+			addIssue(severity, fmt.Sprintf("%s is not a known value, must be one of %v", elem.String(), known), name)
+		}
+	}
+
+	isTrue := func(b *bool) bool { return b != nil && *b }
+	if isTrue(f.BareMetal) && isTrue(f.Burstable) {
+		addIssue(ValidationSeverityError, "bare metal instance types are not burstable", "BareMetal", "Burstable")
+	}
+	if isTrue(f.Burstable) && isTrue(f.Fpga) {
+		addIssue(ValidationSeverityError, "burstable instance types do not have FPGAs", "Burstable", "Fpga")
+	}
+	if isTrue(f.BareMetal) && f.Hypervisor != nil {
+		addIssue(ValidationSeverityError, "bare metal instance types do not have a hypervisor", "BareMetal", "Hypervisor")
+	}
+	if isTrue(f.FreeTier) && f.UsageClass != nil && *f.UsageClass == ec2types.UsageClassTypeSpot {
+		addIssue(ValidationSeverityError, "the free tier only applies to on-demand instances", "FreeTier", "UsageClass")
+	}
+	if f.UsageClass != nil && f.PricingModel != nil &&
+		(*f.UsageClass == ec2types.UsageClassTypeSpot) != (*f.PricingModel == ec2pricing.PricingModelSpot) {
+		addIssue(ValidationSeverityError, fmt.Sprintf("the %s pricing model does not apply to the %s usage class", *f.PricingModel, *f.UsageClass), "UsageClass", "PricingModel")
+	}
+	if f.VirtualizationType != nil && isTrue(f.EnaSupport) &&
+		(*f.VirtualizationType == ec2types.VirtualizationTypeParavirtual || *f.VirtualizationType == VirtualizationTypePv) {
+		addIssue(ValidationSeverityWarning, "paravirtual instance types do not support ENA", "VirtualizationType", "EnaSupport")
+	}
+	if f.GpusRange != nil && f.GpusRange.position(1) > 0 {
+		if f.GpuMemoryRange.position(bytequantity.ByteQuantity{}) < 0 {
+			addIssue(ValidationSeverityError, "instance types without GPUs do not have GPU memory", "GpusRange", "GpuMemoryRange")
+		}
+		if f.GPUManufacturer != nil || f.GPUModel != nil {
+			addIssue(ValidationSeverityError, "instance types without GPUs do not have a GPU manufacturer or model", "GpusRange", "GPUManufacturer", "GPUModel")
+		}
+	}
+	if f.InferenceAcceleratorsRange != nil && f.InferenceAcceleratorsRange.position(1) > 0 &&
+		(f.InferenceAcceleratorManufacturer != nil || f.InferenceAcceleratorModel != nil) {
+		addIssue(ValidationSeverityError, "instance types without inference accelerators do not have an inference accelerator manufacturer or model",
+			"InferenceAcceleratorsRange", "InferenceAcceleratorManufacturer", "InferenceAcceleratorModel")
+	}
+	if f.VCpusToMemoryRatio != nil && *f.VCpusToMemoryRatio <= 0 {
+		addIssue(ValidationSeverityError, fmt.Sprintf("ratio %v must be greater than 0", *f.VCpusToMemoryRatio), "VCpusToMemoryRatio")
+	}
+	if f.SpotDays != nil && (*f.SpotDays < 0 || *f.SpotDays > ec2pricing.MaxSpotDaysBack) {
+		addIssue(ValidationSeverityError, fmt.Sprintf("%d days of spot price history must be between 0 and %d", *f.SpotDays, ec2pricing.MaxSpotDaysBack), "SpotDays")
+	}
+	if f.SpotVolatility != nil && f.SpotDaysBack() == 0 {
+		addIssue(ValidationSeverityError, "spot price volatility can not be calculated from 0 days of spot price history", "SpotVolatility", "SpotDays")
+	}
+	if f.NameAttributes != nil {
+		for _, attribute := range *f.NameAttributes {
+			if !containsString(instancename.Attributes, strings.ToLower(attribute)) {
+				addIssue(ValidationSeverityError, fmt.Sprintf("%s is not a known instance type name attribute, must be one of %v", attribute, instancename.Attributes), "NameAttributes")
+			}
+		}
+	}
+	if f.MaxResults != nil && *f.MaxResults < 0 {
+		addIssue(ValidationSeverityError, fmt.Sprintf("%d must not be negative", *f.MaxResults), "MaxResults")
+	} else if f.MaxResults != nil && *f.MaxResults == 0 {
+		addIssue(ValidationSeverityWarning, "no instance types are returned when the max results is 0", "MaxResults")
+	}
+
+	for _, percentile := range []struct {
+		name  string
+		value *float64
+	}{
+		{"InstanceTypeBaseLowPercentile", f.InstanceTypeBaseLowPercentile},
+		{"InstanceTypeBaseHighPercentile", f.InstanceTypeBaseHighPercentile},
+	} {
+		if percentile.value != nil && *percentile.value < 0 {
+			addIssue(ValidationSeverityError, fmt.Sprintf("%v must not be negative", *percentile.value), percentile.name)
+		}
+	}
+	if f.InstanceTypeBaseLowPercentile != nil && f.InstanceTypeBaseHighPercentile != nil && *f.InstanceTypeBaseLowPercentile > *f.InstanceTypeBaseHighPercentile {
+		addIssue(ValidationSeverityError, fmt.Sprintf("low percentile %v must not be greater than the high percentile %v",
+			*f.InstanceTypeBaseLowPercentile, *f.InstanceTypeBaseHighPercentile), "InstanceTypeBaseLowPercentile", "InstanceTypeBaseHighPercentile")
+	}
+	hasInstanceTypeBase := f.InstanceTypeBase != nil || (f.InstanceTypeBases != nil && len(*f.InstanceTypeBases) > 0)
+	if !hasInstanceTypeBase {
+		for _, name := range []string{"InstanceTypeBaseLowPercentile", "InstanceTypeBaseHighPercentile", "InstanceTypeBaseSimilarity", "SimilarityAttributes"} {
+			if !filtersValue.FieldByName(name).IsNil() {
+				addIssue(ValidationSeverityWarning, "is ignored without an instance type base", name)
+			}
+		}
+	}
+	if f.SimilarityAttributes != nil {
+		for _, attribute := range *f.SimilarityAttributes {
+			if !containsString(SimilarityAttributes(), attribute) {
+				addIssue(ValidationSeverityError, fmt.Sprintf("%s is not a known attribute, must be one of %v", attribute, SimilarityAttributes()), "SimilarityAttributes")
+			}
+		}
+	}
+	if f.FlexibleProfile != nil && !isTrue(f.Flexible) {
+		addIssue(ValidationSeverityWarning, "is ignored unless Flexible is set", "FlexibleProfile")
+	}
+	hasRegions := f.Regions != nil && len(*f.Regions) > 0
+	if f.RegionMode != nil && !hasRegions {
+		addIssue(ValidationSeverityWarning, "is ignored without multiple regions", "RegionMode")
+	}
+
+	if f.AvailabilityZones != nil && len(*f.AvailabilityZones) > 0 {
+		if hasRegions {
+			addIssue(ValidationSeverityError, "availability zones cannot be combined with multiple regions", "AvailabilityZones", "Regions")
+		} else if f.Region != nil {
+			for _, zone := range *f.AvailabilityZones {
+				matches := zoneNameRegionRE.FindStringSubmatch(zone)
+				if matches != nil && matches[1] != *f.Region {
+					addIssue(ValidationSeverityError, fmt.Sprintf("availability zone %s is not in region %s", zone, *f.Region), "AvailabilityZones", "Region")
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// ValidateFilters validates the filters like Filters.Validate and also checks that the availability zones exist in the selector's region.
+func (s Selector) ValidateFilters(ctx context.Context, filters Filters) (ValidationIssues, error) {
+	issues := filters.Validate()
+	if filters.AvailabilityZones == nil || len(*filters.AvailabilityZones) == 0 || (filters.Regions != nil && len(*filters.Regions) > 0) {
+		return issues, nil
+	}
+	azs, err := s.EC2.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{})
+	if err != nil {
+		return issues, fmt.Errorf("unable to describe availability zones: %w", err)
+	}
+	zones := []string{}
+	for _, zone := range azs.AvailabilityZones {
+		if zone.ZoneName != nil {
+			zones = append(zones, *zone.ZoneName)
+		}
+		if zone.ZoneId != nil {
+			zones = append(zones, *zone.ZoneId)
+		}
+	}
+	for _, zone := range *filters.AvailabilityZones {
+		if !containsString(zones, zone) {
+			issues = append(issues, ValidationIssue{
+				Severity: ValidationSeverityError,
+				Filters:  []string{"AvailabilityZones"},
+				Message:  fmt.Sprintf("availability zone %s is not a zone name or zone id of the region, must be one of %v", zone, zones),
+			})
+		}
+	}
+	return issues, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
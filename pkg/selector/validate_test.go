@@ -0,0 +1,162 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/bytequantity"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/ec2pricing"
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Helpers
+
+func issueFilters(issues selector.ValidationIssues) [][]string {
+	filters := [][]string{}
+	for _, issue := range issues {
+		filters = append(filters, issue.Filters)
+	}
+	return filters
+}
+
+// Tests
+
+func TestValidate_Valid(t *testing.T) {
+	issues := requirementsFilters(t).Validate()
+	h.Equals(t, 0, len(issues))
+	h.Ok(t, issues.Err())
+}
+
+func TestValidate_Ranges(t *testing.T) {
+	networkInterfaces := selector.NewRange[int32](2, 2)
+	networkInterfaces.LowerBoundExclusive = true
+	filters := selector.Filters{
+		VCpusRange:        selector.NewRange[int32](16, 4),
+		MemoryRange:       selector.NewRange(bytequantity.FromGiB(64), bytequantity.FromGiB(8)),
+		PricePerHour:      selector.NewRange[float64](-1, 1),
+		Generation:        selector.NewRange(5, 7),
+		GpusRange:         selector.RangeAtMost[int32](8),
+		NetworkInterfaces: networkInterfaces,
+		SpotDays:          aws.Int(120),
+	}
+	issues := filters.Validate()
+	h.Equals(t, [][]string{{"MemoryRange"}, {"NetworkInterfaces"}, {"VCpusRange"}, {"PricePerHour"}, {"SpotDays"}}, issueFilters(issues.Errors()))
+	h.Equals(t, 0, len(issues.Warnings()))
+	h.Nok(t, issues.Err())
+}
+
+func TestValidate_Contradictions(t *testing.T) {
+	usageClass := ec2types.UsageClassTypeSpot
+	hypervisor := ec2types.InstanceTypeHypervisorNitro
+	pricingModel := ec2pricing.PricingModelReserved1YrStandardNoUpfront
+	filters := selector.Filters{
+		BareMetal:      aws.Bool(true),
+		Burstable:      aws.Bool(true),
+		Hypervisor:     &hypervisor,
+		FreeTier:       aws.Bool(true),
+		UsageClass:     &usageClass,
+		PricingModel:   &pricingModel,
+		GpusRange:      selector.NewRange[int32](0, 0),
+		GPUModel:       aws.String("A10G"),
+		SpotVolatility: selector.RangeAtMost(0.1),
+		SpotDays:       aws.Int(0),
+	}
+	issues := filters.Validate()
+	h.Equals(t, [][]string{
+		{"BareMetal", "Burstable"},
+		{"BareMetal", "Hypervisor"},
+		{"FreeTier", "UsageClass"},
+		{"UsageClass", "PricingModel"},
+		{"GpusRange", "GPUManufacturer", "GPUModel"},
+		{"SpotVolatility", "SpotDays"},
+	}, issueFilters(issues.Errors()))
+
+	// contradictions are only reported when both filters are set to conflicting values
+	filters.Burstable = aws.Bool(false)
+	filters.BareMetal = nil
+	filters.UsageClass = nil
+	filters.GpusRange = nil
+	filters.SpotDays = nil
+	h.Equals(t, 0, len(filters.Validate()))
+}
+
+func TestValidate_Enums(t *testing.T) {
+	cpuManufacturer := selector.CPUManufacturer("arm")
+	cpuArchitecture := selector.ArchitectureTypeAMD64
+	regionMode := selector.RegionModeAny
+	usageClass := ec2types.UsageClassType("reserved")
+	tenancy := ec2pricing.Tenancy("host")
+	filters := selector.Filters{
+		CPUManufacturer: &cpuManufacturer,
+		CPUArchitecture: &cpuArchitecture,
+		UsageClass:      &usageClass,
+		Tenancy:         &tenancy,
+		DiskType:        aws.String("nvme"),
+		RegionMode:      &regionMode,
+		Regions:         &[]string{"us-east-1", "us-east-2"},
+		NameAttributes:  &[]string{"D", "flex", "x"},
+	}
+	issues := filters.Validate()
+	h.Equals(t, [][]string{{"CPUManufacturer"}, {"Tenancy"}, {"NameAttributes"}}, issueFilters(issues.Errors()))
+	h.Equals(t, [][]string{{"UsageClass"}, {"DiskType"}}, issueFilters(issues.Warnings()))
+}
+
+func TestValidate_IgnoredFilters(t *testing.T) {
+	regionMode := selector.RegionModeAll
+	filters := selector.Filters{
+		InstanceTypeBaseSimilarity: aws.Bool(true),
+		SimilarityAttributes:       &[]string{"vcpus", "cores"},
+		FlexibleProfile:            aws.String("graviton"),
+		RegionMode:                 &regionMode,
+		MaxResults:                 aws.Int(0),
+	}
+	issues := filters.Validate()
+	h.Equals(t, [][]string{{"SimilarityAttributes"}}, issueFilters(issues.Errors()))
+	h.Equals(t, [][]string{
+		{"MaxResults"},
+		{"InstanceTypeBaseSimilarity"},
+		{"SimilarityAttributes"},
+		{"FlexibleProfile"},
+		{"RegionMode"},
+	}, issueFilters(issues.Warnings()))
+}
+
+func TestValidate_AvailabilityZones(t *testing.T) {
+	filters := selector.Filters{
+		Region:            aws.String("us-east-2"),
+		AvailabilityZones: &[]string{"us-east-2a", "use2-az1", "us-west-2-lax-1a"},
+	}
+	h.Equals(t, [][]string{{"AvailabilityZones", "Region"}}, issueFilters(filters.Validate().Errors()))
+
+	filters.Regions = &[]string{"us-east-2", "us-west-2"}
+	h.Equals(t, [][]string{{"AvailabilityZones", "Regions"}}, issueFilters(filters.Validate().Errors()))
+}
+
+func TestValidateFilters_AvailabilityZones(t *testing.T) {
+	itf := getSelector(setupMock(t, describeAvailabilityZones, "us-east-2.json"))
+	ctx := context.Background()
+	issues, err := itf.ValidateFilters(ctx, selector.Filters{
+		AvailabilityZones: &[]string{"us-east-2a", "use2-az2", "us-east-2z"},
+	})
+	h.Ok(t, err)
+	h.Equals(t, 1, len(issues))
+	h.Equals(t, selector.ValidationSeverityError, issues[0].Severity)
+	h.Assert(t, strings.HasPrefix(issues[0].Message, "availability zone us-east-2z "), "Does not report the unknown zone: %s", issues[0])
+}
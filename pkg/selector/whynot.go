@@ -0,0 +1,173 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
+)
+
+// WhyNotReport describes why a single instance type is or is not selected by a Filters struct.
+type WhyNotReport struct {
+	// InstanceType is the instance type that was evaluated
+	InstanceType string
+
+	// Selected is true if the instance type satisfies every filter and is not truncated by MaxResults
+	Selected bool
+
+	// FailedFilters are the filters the instance type did not satisfy sorted by filter name
+	FailedFilters []FailedFilter
+
+	// ExcludedByLocation is true if the instance type is not offered in the availability zones or region
+	ExcludedByLocation bool
+
+	// ExcludedByDenyList is true if the instance type matches the deny list
+	ExcludedByDenyList bool
+
+	// ExcludedByAllowList is true if the instance type does not match the allow list
+	ExcludedByAllowList bool
+
+	// ExcludedByService is true if at least one of the failed filters was set by the service transform
+	ExcludedByService bool
+
+	// ExcludedByMaxResults is true if the instance type satisfies every filter but is ranked beyond MaxResults
+	ExcludedByMaxResults bool
+
+	// Rank is the 1-based position of the instance type within all matching instance types sorted by name,
+	// or by distance to the base instance type for similarity searches
+	// Rank is only populated when MaxResults is set and the instance type satisfies every filter
+	Rank int
+}
+
+// FailedFilter is a single filter that an instance type did not satisfy.
+type FailedFilter struct {
+	// Filter is the filter key (i.e. vcpusRange, location, allowList, denyList)
+	Filter string
+
+	// FilterValue is the value of the filter after the aggregate filter transforms
+	FilterValue interface{}
+
+	// InstanceSpec is the instance type's value for the filter
+	InstanceSpec interface{}
+
+	// SetByService is true if the filter value was set by the service transform
+	SetByService bool
+
+	// Err is populated if the filter could not be evaluated
+	Err error
+}
+
+// WhyNot accepts a Filters struct and an instance type name and reports every filter the instance type does not satisfy
+// along with the filter value and the instance type's actual spec value. The report also indicates whether the instance type
+// was excluded by location offerings, the allow or deny lists, the service transform, or MaxResults truncation.
+func (s Selector) WhyNot(ctx context.Context, filters Filters, instanceType string) (*WhyNotReport, error) {
+	instanceTypeDetails, err := s.InstanceTypesProvider.Get(ctx, []ec2types.InstanceType{ec2types.InstanceType(instanceType)})
+	if err != nil {
+		return nil, err
+	}
+	var instanceTypeInfo *instancetypes.Details
+	for _, it := range instanceTypeDetails {
+		if string(it.InstanceType) == instanceType {
+			// copy the details so that populating prices does not modify the cached instance type
+			details := *it
+			instanceTypeInfo = &details
+			break
+		}
+	}
+	if instanceTypeInfo == nil {
+		return nil, fmt.Errorf("error instance type %s is not a valid instance type", instanceType)
+	}
+
+	group, err := s.prepareFilterGroup(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	failures := s.findFilterFailures(ctx, group, instanceTypeInfo)
+
+	// failures which also occur with the same filter value when the service transform is not applied were not set by the service
+	preServiceFailures := map[string]filterPair{}
+	if filters.Service != nil && len(failures) > 0 {
+		preServiceFilters := filters
+		preServiceFilters.Service = nil
+		preServiceGroup, err := s.prepareFilterGroup(ctx, preServiceFilters)
+		if err != nil {
+			return nil, err
+		}
+		for _, failure := range s.findFilterFailures(ctx, preServiceGroup, instanceTypeInfo) {
+			preServiceFailures[failure.filterName] = failure.pair
+		}
+	}
+
+	report := &WhyNotReport{
+		InstanceType:  string(instanceTypeInfo.InstanceType),
+		FailedFilters: []FailedFilter{},
+	}
+	for _, failure := range failures {
+		failedFilter := FailedFilter{
+			Filter:       failure.filterName,
+			FilterValue:  reportedFilterValue(failure.pair.filterValue),
+			InstanceSpec: failure.pair.instanceSpec,
+			Err:          failure.err,
+		}
+		if filters.Service != nil {
+			preServicePair, ok := preServiceFailures[failure.filterName]
+			failedFilter.SetByService = !ok || !reflect.DeepEqual(reportedFilterValue(preServicePair.filterValue), reportedFilterValue(failure.pair.filterValue))
+		}
+		switch failure.filterName {
+		case locationFilterKey:
+			report.ExcludedByLocation = true
+		case denyList:
+			report.ExcludedByDenyList = true
+		case allowList:
+			report.ExcludedByAllowList = true
+		}
+		report.ExcludedByService = report.ExcludedByService || failedFilter.SetByService
+		report.FailedFilters = append(report.FailedFilters, failedFilter)
+	}
+	if len(failures) > 0 {
+		return report, nil
+	}
+
+	report.Selected = true
+	if filters.MaxResults == nil {
+		return report, nil
+	}
+	matchingInstanceTypes, err := s.selectInstanceTypes(ctx, func(ctx context.Context, instanceTypeInfo instancetypes.Details) (*instancetypes.Details, error) {
+		return s.matchFilterGroup(ctx, group, instanceTypeInfo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if isSimilaritySearch(filters) {
+		matchingInstanceTypes, err = s.rankBySimilarity(ctx, filters, matchingInstanceTypes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, it := range matchingInstanceTypes {
+		if it.InstanceType == instanceTypeInfo.InstanceType {
+			report.Rank = i + 1
+			break
+		}
+	}
+	if report.Rank > *filters.MaxResults {
+		report.Selected = false
+		report.ExcludedByMaxResults = true
+	}
+	return report, nil
+}
@@ -0,0 +1,137 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	h "github.com/aws/amazon-ec2-instance-selector/v3/pkg/test"
+)
+
+// Tests
+
+func TestWhyNot(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	vcpusRange := selector.NewRange[int32](4, 8)
+	filters := selector.Filters{
+		VCpusRange: vcpusRange,
+		BareMetal:  aws.Bool(false),
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "t3.micro")
+	h.Ok(t, err)
+	h.Equals(t, "t3.micro", report.InstanceType)
+	h.Assert(t, !report.Selected, "t3.micro should not be selected")
+	h.Equals(t, 1, len(report.FailedFilters))
+	h.Equals(t, "vcpusRange", report.FailedFilters[0].Filter)
+	h.Equals(t, vcpusRange, report.FailedFilters[0].FilterValue)
+	h.Equals(t, aws.Int32(2), report.FailedFilters[0].InstanceSpec)
+	h.Assert(t, !report.ExcludedByLocation && !report.ExcludedByService && !report.ExcludedByMaxResults, "t3.micro should only be excluded by vcpusRange")
+}
+
+func TestWhyNot_Selected(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	filters := selector.Filters{
+		VCpusRange: selector.NewRange[int32](2, 2),
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "t3.micro")
+	h.Ok(t, err)
+	h.Assert(t, report.Selected, "t3.micro should be selected")
+	h.Equals(t, []selector.FailedFilter{}, report.FailedFilters)
+	h.Equals(t, 0, report.Rank)
+}
+
+func TestWhyNot_Service(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	itf.ServiceRegistry.Register("emr", &selector.EMR{})
+	filters := selector.Filters{
+		Service:    aws.String("emr-5.20.0"),
+		VCpusRange: selector.NewRange[int32](4, 8),
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "t3.micro")
+	h.Ok(t, err)
+	h.Assert(t, report.ExcludedByService, "t3.micro should be excluded by the service")
+	failedFilters := map[string]selector.FailedFilter{}
+	for _, failedFilter := range report.FailedFilters {
+		failedFilters[failedFilter.Filter] = failedFilter
+	}
+	h.Assert(t, failedFilters["instanceTypes"].SetByService, "instanceTypes should be set by the service")
+	h.Assert(t, !failedFilters["vcpusRange"].SetByService, "vcpusRange should not be set by the service")
+}
+
+func TestWhyNot_AllowDenyAndLocation(t *testing.T) {
+	ec2Mock := mockedEC2{
+		DescribeInstanceTypesResp:         setupMock(t, describeInstanceTypes, "25_instances.json").DescribeInstanceTypesResp,
+		DescribeInstanceTypeOfferingsResp: setupMock(t, describeInstanceTypeOfferings, "us-east-2a_only_c5d12x.json").DescribeInstanceTypeOfferingsResp,
+		DescribeAvailabilityZonesResp:     setupMock(t, describeAvailabilityZones, "us-east-2.json").DescribeAvailabilityZonesResp,
+	}
+	itf := getSelector(ec2Mock)
+	filters := selector.Filters{
+		AllowList:         regexp.MustCompile("c4.*"),
+		DenyList:          regexp.MustCompile("c4.large"),
+		AvailabilityZones: &[]string{"us-east-2a"},
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "c4.large")
+	h.Ok(t, err)
+	h.Assert(t, report.ExcludedByLocation, "c4.large should be excluded by location")
+	h.Assert(t, report.ExcludedByDenyList, "c4.large should be excluded by the deny list")
+	h.Assert(t, !report.ExcludedByAllowList, "c4.large should not be excluded by the allow list")
+	h.Equals(t, "denyList", report.FailedFilters[0].Filter)
+	h.Equals(t, aws.String("c4.large"), report.FailedFilters[0].FilterValue)
+	h.Equals(t, "location", report.FailedFilters[1].Filter)
+	h.Equals(t, []string{"us-east-2a"}, report.FailedFilters[1].FilterValue)
+}
+
+func TestWhyNot_MaxResults(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "25_instances.json"))
+	filters := selector.Filters{
+		AllowList:  regexp.MustCompile("c4.*"),
+		MaxResults: aws.Int(2),
+	}
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, filters, "c4.large")
+	h.Ok(t, err)
+	h.Assert(t, !report.Selected, "c4.large should not be selected")
+	h.Assert(t, report.ExcludedByMaxResults, "c4.large should be excluded by max results")
+	h.Equals(t, 4, report.Rank)
+
+	report, err = itf.WhyNot(ctx, filters, "c4.2xlarge")
+	h.Ok(t, err)
+	h.Assert(t, report.Selected, "c4.2xlarge should be selected")
+	h.Equals(t, 1, report.Rank)
+}
+
+func TestWhyNot_InvalidInstanceType(t *testing.T) {
+	itf := getSelector(setupMock(t, describeInstanceTypes, "t3_micro_and_p3_16xl.json"))
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, selector.Filters{}, "m5.bogus")
+	h.Assert(t, report == nil, "Report should be nil")
+	h.Nok(t, err)
+}
+
+func TestWhyNot_Failure(t *testing.T) {
+	itf := getSelector(mockedEC2{DescribeInstanceTypesErr: errors.New("error")})
+	ctx := context.Background()
+	report, err := itf.WhyNot(ctx, selector.Filters{}, "t3.micro")
+	h.Assert(t, report == nil, "Report should be nil")
+	h.Nok(t, err)
+}
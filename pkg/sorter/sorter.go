@@ -21,6 +21,7 @@ import (
 
 	"github.com/oliveagle/jsonpath"
 
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancename"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
 )
 
@@ -37,6 +38,9 @@ const (
 
 	GPUCountField              = "gpus"
 	InferenceAcceleratorsField = "inference-accelerators"
+	// InstanceTypeNameField sorts instance type names naturally by family and size (i.e. c5.large < c5.xlarge < c5.2xlarge)
+	// while the .InstanceType json path sorts them alphabetically.
+	InstanceTypeNameField = "instance-type"
 
 	// shorthand flags.
 
@@ -50,6 +54,20 @@ const (
 	EBSOptimizedBaselineBandwidth  = "ebs-optimized-baseline-bandwidth"
 	EBSOptimizedBaselineThroughput = "ebs-optimized-baseline-throughput"
 	EBSOptimizedBaselineIOPS       = "ebs-optimized-baseline-iops"
+	ODPricePerVCPU                 = "on-demand-price-per-vcpu"
+	SpotPricePerVCPU               = "spot-price-per-vcpu"
+	ODPricePerGiBMemory            = "on-demand-price-per-gib-memory"
+	SpotPricePerGiBMemory          = "spot-price-per-gib-memory"
+	ODPricePerGPU                  = "on-demand-price-per-gpu"
+	SpotPricePerGPU                = "spot-price-per-gpu"
+	ODPricePerGiBGPUMemory         = "on-demand-price-per-gib-gpu-memory"
+	SpotPricePerGiBGPUMemory       = "spot-price-per-gib-gpu-memory"
+	ODPricePerGbpsNetwork          = "on-demand-price-per-gbps-network"
+	SpotPricePerGbpsNetwork        = "spot-price-per-gbps-network"
+	VCPUsToMemoryRatio             = "vcpus-to-memory-ratio"
+	PricingModelPrice              = "pricing-model-price"
+	SpotVolatility                 = "spot-volatility"
+	SpotDiscount                   = "spot-discount"
 
 	// JSON field paths for shorthand flags.
 
@@ -64,6 +82,20 @@ const (
 	ebsOptimizedBaselineBandwidthPath  = ".EbsInfo.EbsOptimizedInfo.BaselineBandwidthInMbps"
 	ebsOptimizedBaselineThroughputPath = ".EbsInfo.EbsOptimizedInfo.BaselineThroughputInMBps"
 	ebsOptimizedBaselineIOPSPath       = ".EbsInfo.EbsOptimizedInfo.BaselineIops"
+	odPricePerVCPUPath                 = ".OndemandPricePerVCPU"
+	spotPricePerVCPUPath               = ".SpotPricePerVCPU"
+	odPricePerGiBMemoryPath            = ".OndemandPricePerGiBMemory"
+	spotPricePerGiBMemoryPath          = ".SpotPricePerGiBMemory"
+	odPricePerGPUPath                  = ".OndemandPricePerGPU"
+	spotPricePerGPUPath                = ".SpotPricePerGPU"
+	odPricePerGiBGPUMemoryPath         = ".OndemandPricePerGiBGPUMemory"
+	spotPricePerGiBGPUMemoryPath       = ".SpotPricePerGiBGPUMemory"
+	odPricePerGbpsNetworkPath          = ".OndemandPricePerGbpsNetwork"
+	spotPricePerGbpsNetworkPath        = ".SpotPricePerGbpsNetwork"
+	vcpusToMemoryRatioPath             = ".MemoryGiBPerVCPU"
+	pricingModelPricePath              = ".PricingModelPricePerHour"
+	spotVolatilityPath                 = ".SpotVolatility"
+	spotDiscountPath                   = ".SpotDiscount"
 )
 
 // sorterNode represents a sortable instance type which holds the value
@@ -100,6 +132,20 @@ func Sort(instanceTypes []*instancetypes.Details, sortField string, sortDirectio
 		EBSOptimizedBaselineBandwidth:  ebsOptimizedBaselineBandwidthPath,
 		EBSOptimizedBaselineThroughput: ebsOptimizedBaselineThroughputPath,
 		EBSOptimizedBaselineIOPS:       ebsOptimizedBaselineIOPSPath,
+		ODPricePerVCPU:                 odPricePerVCPUPath,
+		SpotPricePerVCPU:               spotPricePerVCPUPath,
+		ODPricePerGiBMemory:            odPricePerGiBMemoryPath,
+		SpotPricePerGiBMemory:          spotPricePerGiBMemoryPath,
+		ODPricePerGPU:                  odPricePerGPUPath,
+		SpotPricePerGPU:                spotPricePerGPUPath,
+		ODPricePerGiBGPUMemory:         odPricePerGiBGPUMemoryPath,
+		SpotPricePerGiBGPUMemory:       spotPricePerGiBGPUMemoryPath,
+		ODPricePerGbpsNetwork:          odPricePerGbpsNetworkPath,
+		SpotPricePerGbpsNetwork:        spotPricePerGbpsNetworkPath,
+		VCPUsToMemoryRatio:             vcpusToMemoryRatioPath,
+		PricingModelPrice:              pricingModelPricePath,
+		SpotVolatility:                 spotVolatilityPath,
+		SpotDiscount:                   spotDiscountPath,
 	}
 
 	// determine if user used a shorthand for sorting flag
@@ -162,7 +208,7 @@ func newSorter(instanceTypes []*instancetypes.Details, sortField string, sortDir
 // matches one of the special flags.
 func formatSortField(sortField string) string {
 	// check to see if the sorting field matched one of the special exceptions
-	if sortField == GPUCountField || sortField == InferenceAcceleratorsField {
+	if sortField == GPUCountField || sortField == InferenceAcceleratorsField || sortField == InstanceTypeNameField {
 		return sortField
 	}
 
@@ -187,6 +233,11 @@ func newSorterNode(instanceType *instancetypes.Details, sortField string) (*sort
 			instanceType: instanceType,
 			fieldValue:   reflect.ValueOf(acceleratorsCount),
 		}, nil
+	case InstanceTypeNameField:
+		return &sorterNode{
+			instanceType: instanceType,
+			fieldValue:   reflect.ValueOf(string(instanceType.InstanceType)),
+		}, nil
 	}
 
 	// convert instance type into json
@@ -229,6 +280,17 @@ func (s *sorter) sort() error {
 		return nil
 	}
 
+	if s.sortField == InstanceTypeNameField {
+		sort.SliceStable(s.sorters, func(i int, j int) bool {
+			comparison := instancename.Compare(s.sorters[i].fieldValue.String(), s.sorters[j].fieldValue.String())
+			if s.isDescending {
+				return comparison > 0
+			}
+			return comparison < 0
+		})
+		return nil
+	}
+
 	var sortErr error = nil
 
 	sort.Slice(s.sorters, func(i int, j int) bool {
@@ -19,6 +19,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/instancetypes"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector/outputs"
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/sorter"
@@ -119,6 +121,133 @@ func TestSort_SpecialCases(t *testing.T) {
 	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected inference accelerators order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
 }
 
+func TestSort_PricePerformanceShorthands(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+	for i, pricePerVCPU := range []float64{0.03, 0.01, 0.02} {
+		pricePerVCPU := pricePerVCPU
+		instanceTypes[i].OndemandPricePerVCPU = &pricePerVCPU
+	}
+	instanceTypes[0].SpotPricePerGiBMemory = aws.Float64(0.002)
+	instanceTypes[2].SpotPricePerGiBMemory = aws.Float64(0.001)
+
+	sortedInstances, err := sorter.Sort(instanceTypes, sorter.ODPricePerVCPU, "asc")
+	expectedResults := []string{
+		"a1.4xlarge",
+		"a1.large",
+		"a1.2xlarge",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected on-demand price per vCPU order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+
+	// instance types without a price are sorted last
+	sortedInstances, err = sorter.Sort(instanceTypes, sorter.SpotPricePerGiBMemory, "asc")
+	expectedResults = []string{
+		"a1.large",
+		"a1.2xlarge",
+		"a1.4xlarge",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected spot price per GiB memory order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+}
+
+func TestSort_VCPUsToMemoryRatio(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+	for i, memoryGiBPerVCPU := range []float64{2, 4, 1} {
+		memoryGiBPerVCPU := memoryGiBPerVCPU
+		instanceTypes[i].MemoryGiBPerVCPU = &memoryGiBPerVCPU
+	}
+
+	sortedInstances, err := sorter.Sort(instanceTypes, sorter.VCPUsToMemoryRatio, "desc")
+	expectedResults := []string{
+		"a1.4xlarge",
+		"a1.2xlarge",
+		"a1.large",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected vcpus to memory ratio order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+}
+
+func TestSort_PricingModelPrice(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+	for i, pricingModelPrice := range []float64{0.2, 0.4, 0.05} {
+		pricingModelPrice := pricingModelPrice
+		instanceTypes[i].PricingModelPricePerHour = &pricingModelPrice
+	}
+
+	sortedInstances, err := sorter.Sort(instanceTypes, sorter.PricingModelPrice, "asc")
+	expectedResults := []string{
+		"a1.large",
+		"a1.2xlarge",
+		"a1.4xlarge",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected pricing model price order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+}
+
+func TestSort_SpotVolatility(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+	for i, spotVolatility := range []float64{0.3, 0.01, 0.12} {
+		spotVolatility := spotVolatility
+		instanceTypes[i].SpotVolatility = &spotVolatility
+	}
+
+	sortedInstances, err := sorter.Sort(instanceTypes, sorter.SpotVolatility, "asc")
+	expectedResults := []string{
+		"a1.4xlarge",
+		"a1.large",
+		"a1.2xlarge",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected spot volatility order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+}
+
+func TestSort_SpotDiscount(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+	for i, spotDiscount := range []float64{45.5, 72, 60} {
+		spotDiscount := spotDiscount
+		instanceTypes[i].SpotDiscount = &spotDiscount
+	}
+
+	sortedInstances, err := sorter.Sort(instanceTypes, sorter.SpotDiscount, "desc")
+	expectedResults := []string{
+		"a1.4xlarge",
+		"a1.large",
+		"a1.2xlarge",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected spot discount order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+}
+
+func TestSort_InstanceTypeName(t *testing.T) {
+	instanceTypes := getInstanceTypeDetails(t, "3_instances.json")
+
+	sortedInstances, err := sorter.Sort(instanceTypes, sorter.InstanceTypeNameField, "asc")
+	expectedResults := []string{
+		"a1.large",
+		"a1.2xlarge",
+		"a1.4xlarge",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected natural instance type name order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+
+	sortedInstances, err = sorter.Sort(instanceTypes, sorter.InstanceTypeNameField, "desc")
+	expectedResults = []string{
+		"a1.4xlarge",
+		"a1.2xlarge",
+		"a1.large",
+	}
+
+	h.Ok(t, err)
+	h.Assert(t, checkSortResults(sortedInstances, expectedResults), fmt.Sprintf("Expected descending natural instance type name order: [%s], but actual order: %s", strings.Join(expectedResults, ","), outputs.OneLineOutput(sortedInstances)))
+}
+
 func TestSort_OneElement(t *testing.T) {
 	instanceTypes := getInstanceTypeDetails(t, "1_instance.json")
 